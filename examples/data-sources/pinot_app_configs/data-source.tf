@@ -0,0 +1,6 @@
+# Read the controller app configs
+data "pinot_app_configs" "current" {}
+
+output "pinot_version" {
+  value = jsondecode(data.pinot_app_configs.current.app_configs).systemConfig.pinotVersion
+}
@@ -0,0 +1,13 @@
+# Fail the check when segments take more than 80% of the provisioned server disks
+variable "provisioned_bytes" {
+  type = number
+}
+
+data "pinot_cluster_capacity" "this" {}
+
+check "storage" {
+  assert {
+    condition     = data.pinot_cluster_capacity.this.total_bytes < 0.8 * var.provisioned_bytes
+    error_message = "Pinot segments take more than 80% of the provisioned disk."
+  }
+}
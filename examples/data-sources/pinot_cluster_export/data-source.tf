@@ -0,0 +1,7 @@
+# Write a snapshot of all schemas, tables and users for disaster recovery
+data "pinot_cluster_export" "this" {}
+
+resource "local_sensitive_file" "pinot_backup" {
+  filename = "${path.module}/backup/pinot-cluster.json"
+  content  = data.pinot_cluster_export.this.json
+}
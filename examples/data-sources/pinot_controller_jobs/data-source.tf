@@ -0,0 +1,9 @@
+# Check whether the latest reload of a table has finished
+data "pinot_controller_jobs" "user_events_reloads" {
+  table_name = "user_events_OFFLINE"
+  job_type   = "RELOAD_ALL_SEGMENTS"
+}
+
+output "user_events_reload_done" {
+  value = length(data.pinot_controller_jobs.user_events_reloads.jobs) == 0 || data.pinot_controller_jobs.user_events_reloads.jobs[0].state == "COMPLETED"
+}
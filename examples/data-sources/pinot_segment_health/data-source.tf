@@ -0,0 +1,11 @@
+# Assert that every segment of the table is fully replicated
+data "pinot_segment_health" "user_events" {
+  table_name = "user_events"
+}
+
+check "user_events_replication" {
+  assert {
+    condition     = data.pinot_segment_health.user_events.under_replicated_count == 0
+    error_message = "Under-replicated segments: ${join(", ", data.pinot_segment_health.user_events.under_replicated_segments)}"
+  }
+}
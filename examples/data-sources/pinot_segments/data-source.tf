@@ -0,0 +1,9 @@
+# Fail a health check when any segment of the table is in ERROR
+data "pinot_segments" "errored" {
+  table_name = "user_events_REALTIME"
+  state      = "ERROR"
+}
+
+output "errored_segments" {
+  value = data.pinot_segments.errored.segments
+}
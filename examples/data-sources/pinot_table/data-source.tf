@@ -0,0 +1,9 @@
+# Table owned by another team
+data "pinot_table" "clicks" {
+  table_name = "clicks"
+  table_type = "REALTIME"
+}
+
+output "clicks_replication" {
+  value = jsondecode(data.pinot_table.clicks.table_config).segmentsConfig.replication
+}
@@ -0,0 +1,8 @@
+# Report the last run of every minion task configured on a table
+data "pinot_table_tasks" "user_events" {
+  table_name = "user_events_OFFLINE"
+}
+
+output "user_events_failed_tasks" {
+  value = [for t in data.pinot_table_tasks.user_events.tasks : t.task_type if t.last_run_state == "FAILED"]
+}
@@ -0,0 +1,8 @@
+# Every table served by the "hot" tenant
+data "pinot_tables" "hot" {
+  tenant = "hot"
+}
+
+output "hot_tables" {
+  value = data.pinot_tables.hot.tables
+}
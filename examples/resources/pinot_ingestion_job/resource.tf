@@ -0,0 +1,14 @@
+# Load a small reference data file into an offline table, again whenever it changes
+resource "pinot_ingestion_job" "countries" {
+  table_name = "countries_OFFLINE"
+  file_path  = "data/countries.csv"
+
+  job_spec = jsonencode({
+    inputFormat                   = "csv"
+    "recordReader.prop.delimiter" = "|"
+  })
+
+  triggers = {
+    data = filesha256("data/countries.csv")
+  }
+}
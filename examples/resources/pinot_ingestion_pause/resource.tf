@@ -0,0 +1,15 @@
+# Pause all realtime ingestion for a maintenance window; set paused = false
+# and apply again afterwards to resume it.
+variable "maintenance" {
+  type    = bool
+  default = false
+}
+
+resource "pinot_ingestion_pause" "maintenance" {
+  paused          = var.maintenance
+  max_concurrency = 8
+}
+
+output "ingestion_results" {
+  value = pinot_ingestion_pause.maintenance.results
+}
@@ -0,0 +1,5 @@
+# Drain a server before maintenance
+resource "pinot_instance" "server_0" {
+  instance_name = "Server_pinot-server-0_8098"
+  enabled       = false
+}
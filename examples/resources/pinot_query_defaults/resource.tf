@@ -0,0 +1,5 @@
+# Cluster-wide defaults for queries that do not set these options themselves
+resource "pinot_query_defaults" "this" {
+  timeout_ms            = 15000
+  use_multistage_engine = true
+}
@@ -0,0 +1,4 @@
+# Manage every schema kept as JSON in the repository
+resource "pinot_schema_dir" "all" {
+  path = "${path.module}/schemas"
+}
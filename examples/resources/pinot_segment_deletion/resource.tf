@@ -0,0 +1,7 @@
+# Purge all 2023 data from an offline table
+resource "pinot_segment_deletion" "purge_2023" {
+  table_name = "user_events_OFFLINE"
+  start_time = "2023-01-01T00:00:00Z"
+  end_time   = "2024-01-01T00:00:00Z"
+  confirm    = true
+}
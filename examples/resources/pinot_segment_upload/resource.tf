@@ -0,0 +1,23 @@
+# Upload a segment built by an offline job, again whenever it is rebuilt
+resource "pinot_segment_upload" "events_2024_01" {
+  table_name = "events_OFFLINE"
+  file_path  = "build/segments/events_2024_01.tar.gz"
+
+  triggers = {
+    segment = filesha256("build/segments/events_2024_01.tar.gz")
+  }
+}
+
+# Compress an uncompressed segment tar on the way to a controller across a slow link
+resource "pinot_segment_upload" "events_2024_01_raw" {
+  table_name         = "events_OFFLINE"
+  file_path          = "build/segments/events_2024_01.tar"
+  segment_format     = "tar"
+  upload_compression = "gzip"
+}
+
+# Have the controller fetch a segment from the deep store
+resource "pinot_segment_upload" "events_2024_02" {
+  table_name = "events_OFFLINE"
+  uri        = "s3://pinot-segments/events/events_2024_02.tar.gz"
+}
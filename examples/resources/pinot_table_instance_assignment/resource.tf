@@ -0,0 +1,14 @@
+# Pin an offline table to two replica groups and move segments onto them
+resource "pinot_table_instance_assignment" "user_events" {
+  table_name      = "user_events"
+  partitions_type = "OFFLINE"
+  rebalance       = true
+
+  instance_partitions = jsonencode({
+    instancePartitionsName = "user_events_OFFLINE"
+    partitionToInstancesMap = {
+      "0_0" = ["Server_pinot-server-0_8098", "Server_pinot-server-1_8098"]
+      "0_1" = ["Server_pinot-server-2_8098", "Server_pinot-server-3_8098"]
+    }
+  })
+}
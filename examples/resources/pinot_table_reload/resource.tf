@@ -0,0 +1,9 @@
+# Reload tables whenever the index defaults change
+resource "pinot_table_reload" "after_index_defaults" {
+  tables          = ["user_events_OFFLINE", "user_events_REALTIME"]
+  max_concurrency = 2
+
+  triggers = {
+    index_defaults = sha1(file("cluster/index_defaults.json"))
+  }
+}
@@ -0,0 +1,28 @@
+# A table and its schema, created, updated and destroyed in the right order
+resource "pinot_table_with_schema" "events" {
+  table_name = "events"
+  table_type = "OFFLINE"
+
+  schema = jsonencode({
+    schemaName = "events"
+    dimensionFieldSpecs = [
+      { name = "user_id", dataType = "STRING" },
+    ]
+    dateTimeFieldSpecs = [
+      { name = "ts", dataType = "LONG", format = "1:MILLISECONDS:EPOCH", granularity = "1:MILLISECONDS" },
+    ]
+  })
+
+  table_config = jsonencode({
+    tableName = "events_OFFLINE"
+    tableType = "OFFLINE"
+    segmentsConfig = {
+      schemaName     = "events"
+      timeColumnName = "ts"
+      replication    = "1"
+    }
+    tenants          = {}
+    tableIndexConfig = {}
+    metadata         = {}
+  })
+}
@@ -0,0 +1,12 @@
+# Merge the segments of a backfill right after loading it, instead of waiting for the cron schedule
+resource "pinot_task_schedule" "events_merge" {
+  table_name = "events_OFFLINE"
+  task_type  = "MergeRollupTask"
+
+  wait_for_completion = true
+  wait_timeout        = "1h"
+
+  triggers = {
+    backfill = pinot_segment_upload.events_2024_01.id
+  }
+}
@@ -0,0 +1,14 @@
+# Server tenant with two servers for OFFLINE and one for REALTIME tables
+resource "pinot_tenant" "analytics_servers" {
+  tenant_name        = "analytics"
+  tenant_role        = "SERVER"
+  offline_instances  = 2
+  realtime_instances = 1
+}
+
+# Broker tenant with the same name
+resource "pinot_tenant" "analytics_brokers" {
+  tenant_name         = "analytics"
+  tenant_role         = "BROKER"
+  number_of_instances = 1
+}
@@ -0,0 +1,4 @@
+# Pin the time boundary of a hybrid table after an offline backfill
+resource "pinot_time_boundary" "user_events" {
+  table_name = "user_events"
+}
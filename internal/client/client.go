@@ -2,59 +2,388 @@
 package client
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type PinotClient struct {
 	controllerURL string
+	pathPrefix    string
 	httpClient    *http.Client
 	username      string
 	password      string
 	token         string
+	// tokenFile, when set with reloadTokenFile, is re-read before every request so rotated tokens are picked up.
+	tokenFile       string
+	reloadTokenFile bool
+	// readToken and writeToken, when set, replace the other credentials for GET/HEAD requests and for all other
+	// requests respectively, for controllers that grant reads and writes to different principals.
+	readToken  string
+	writeToken string
+	// managedByTags are the metadata.customConfigs entries resources add to the table configs they write.
+	managedByTags map[string]string
+	// protectTables makes table resources refuse to delete tables unless PINOT_ALLOW_DESTROY is set.
+	protectTables bool
+	// envHeaders are headers whose values are read from the environment before every request, e.g. traceparent.
+	envHeaders []string
+	// config is the ClientConfig the client was created with, for rebuilding its transport.
+	config ClientConfig
+	retry  RetryPolicy
+	// leader, when set, routes mutating requests to the lead controller among several controllers of the cluster.
+	leader *leaderRouter
+	// gzipRejected records that the controller answered a gzipped body with 415, shared by the client's copies.
+	gzipRejected *atomic.Bool
+	// version is the controller's Pinot version when the provider probed it, e.g. "1.2.0"; empty when unknown.
+	version string
 }
 
 func NewPinotClient(controllerURL, username, password string) (*PinotClient, error) {
-	return NewPinotClientWithToken(controllerURL, username, password, "")
+	return NewPinotClientWithToken(controllerURL, username, password, "", DefaultClientConfig())
 }
 
-func NewPinotClientWithToken(controllerURL, username, password, token string) (*PinotClient, error) {
+// NewPinotClientWithToken returns a client that authenticates with token, or with basic auth when token is empty.
+// Zero fields of cfg take their defaults from DefaultClientConfig.
+func NewPinotClientWithToken(controllerURL, username, password, token string, cfg ClientConfig) (*PinotClient, error) {
 	controllerURL = strings.TrimRight(controllerURL, "/")
+	cfg = cfg.withDefaults()
+	transport, err := newTransport(cfg, http.ProxyFromEnvironment)
+	if err != nil {
+		return nil, err
+	}
 	return &PinotClient{
 		controllerURL: controllerURL,
-		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		httpClient:    &http.Client{Timeout: cfg.Timeout, Transport: transport},
 		username:      username,
 		password:      password,
 		token:         token,
+		config:        cfg,
+		retry:         cfg.Retry,
+		gzipRejected:  new(atomic.Bool),
 	}, nil
 }
 
+// WithControllerURL returns a client for another controller that shares this client's credentials and HTTP client.
+func (c *PinotClient) WithControllerURL(controllerURL string) *PinotClient {
+	clone := *c
+	clone.controllerURL = strings.TrimRight(controllerURL, "/")
+	clone.leader = nil
+	clone.version = ""
+	return &clone
+}
+
+// WithControllers returns a client that knows the other controllers of its cluster. Reads still go to the
+// client's controller; POST, PUT, PATCH and DELETE requests go to the lead controller, which is detected on
+// the first write and again after a write to it fails.
+func (c *PinotClient) WithControllers(controllerURLs []string) *PinotClient {
+	controllers := []string{c.controllerURL}
+	for _, u := range controllerURLs {
+		u = strings.TrimRight(strings.TrimSpace(u), "/")
+		if u != "" && !slices.Contains(controllers, u) {
+			controllers = append(controllers, u)
+		}
+	}
+	clone := *c
+	clone.leader = nil
+	if len(controllers) > 1 {
+		clone.leader = &leaderRouter{controllers: controllers}
+	}
+	return &clone
+}
+
+// WithTokenFile returns a client that authenticates with the token stored in path, replacing any other credentials.
+// The file is read now and, when reload is true, again before every request; surrounding whitespace is ignored.
+// If a later read fails or finds the file empty, the token read here is used.
+func (c *PinotClient) WithTokenFile(path string, reload bool) (*PinotClient, error) {
+	token, err := readTokenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	clone := *c
+	clone.username, clone.password = "", ""
+	clone.token = token
+	clone.tokenFile = path
+	clone.reloadTokenFile = reload
+	return &clone, nil
+}
+
+func readTokenFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// WithReadWriteTokens returns a client that authenticates GET and HEAD requests with read and every other
+// request with write. An empty token leaves those requests on the client's other credentials.
+func (c *PinotClient) WithReadWriteTokens(read, write string) *PinotClient {
+	clone := *c
+	clone.readToken = strings.TrimSpace(read)
+	clone.writeToken = strings.TrimSpace(write)
+	return &clone
+}
+
+// tokenFor returns the token to send with a request of the given method, or "" to fall back to basic auth.
+func (c *PinotClient) tokenFor(method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		if c.readToken != "" {
+			return c.readToken
+		}
+	} else if c.writeToken != "" {
+		return c.writeToken
+	}
+	return strings.TrimSpace(c.currentToken())
+}
+
+// currentToken returns the token to send, re-reading the token file when the client reloads it.
+func (c *PinotClient) currentToken() string {
+	if c.reloadTokenFile && c.tokenFile != "" {
+		if token, err := readTokenFile(c.tokenFile); err == nil {
+			return token
+		}
+	}
+	return c.token
+}
+
+// newTransport returns a copy of the default transport with the timeouts and TLS settings of cfg that picks its
+// proxy with proxy.
+func newTransport(cfg ClientConfig, proxy func(*http.Request) (*url.URL, error)) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = proxy
+	t.DialContext = (&net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	t.TLSHandshakeTimeout = cfg.TLSHandshakeTimeout
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig
+	}
+	return t, nil
+}
+
+// WithProxyURL returns a client that sends every request through the proxy at proxyURL instead of the one
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY select.
+func (c *PinotClient) WithProxyURL(proxyURL string) (*PinotClient, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", proxyURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", proxyURL)
+	}
+	transport, err := newTransport(c.config, http.ProxyURL(u))
+	if err != nil {
+		return nil, err
+	}
+	clone := *c
+	clone.httpClient = &http.Client{Timeout: c.httpClient.Timeout, Transport: transport}
+	return &clone, nil
+}
+
+// WithManagedByTags returns a client that reports tags as the custom configs to record on managed tables.
+// Empty values are dropped.
+func (c *PinotClient) WithManagedByTags(tags map[string]string) *PinotClient {
+	clone := *c
+	clone.managedByTags = map[string]string{}
+	for k, v := range tags {
+		if v != "" {
+			clone.managedByTags[k] = v
+		}
+	}
+	return &clone
+}
+
+// ManagedByTags returns the custom configs set with WithManagedByTags.
+func (c *PinotClient) ManagedByTags() map[string]string {
+	return c.managedByTags
+}
+
+// WithTableProtection returns a client that reports whether table resources must refuse to delete tables.
+func (c *PinotClient) WithTableProtection(protect bool) *PinotClient {
+	clone := *c
+	clone.protectTables = protect
+	return &clone
+}
+
+// TablesProtected reports the setting of WithTableProtection.
+func (c *PinotClient) TablesProtected() bool {
+	return c.protectTables
+}
+
+// WithEnvHeaders returns a client that adds each named header to every request, with its value read from the
+// environment variable named by HeaderEnvVar at request time, so values that change during a run (e.g. traceparent)
+// are propagated. Unset or empty variables are skipped. Headers the client sets itself (Content-Type, Accept,
+// Authorization, If-Match) take precedence.
+func (c *PinotClient) WithEnvHeaders(names []string) *PinotClient {
+	clone := *c
+	clone.envHeaders = nil
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			clone.envHeaders = append(clone.envHeaders, name)
+		}
+	}
+	return &clone
+}
+
+// HeaderEnvVar is the environment variable a header's value is read from: the name upper-cased with dashes
+// replaced by underscores (traceparent reads TRACEPARENT, X-Request-Id reads X_REQUEST_ID).
+func HeaderEnvVar(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// WithAPIPathPrefix returns a client that prepends prefix to every controller path, for controllers
+// served below the root of a gateway (e.g. "/pinot" turns /schemas into /pinot/schemas).
+func (c *PinotClient) WithAPIPathPrefix(prefix string) *PinotClient {
+	clone := *c
+	clone.pathPrefix = NormalizePathPrefix(prefix)
+	return &clone
+}
+
+// baseURL is the controller URL joined with the API path prefix; request paths are appended to it.
+func (c *PinotClient) baseURL() string {
+	return c.controllerURL + c.pathPrefix
+}
+
+// NormalizePathPrefix returns prefix with a single leading slash and no trailing slash,
+// or "" when prefix is empty or only slashes.
+func NormalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
 func (c *PinotClient) doRequest(ctx context.Context, method, url string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+	respBody, _, err := c.doRequestWithHeaders(ctx, method, url, body, nil)
+	return respBody, err
+}
+
+// doRequestWithHeaders is doRequest with extra request headers; it also returns the response headers.
+// Failed requests are retried as the client's RetryPolicy allows.
+func (c *PinotClient) doRequestWithHeaders(ctx context.Context, method, url string, body interface{}, header http.Header) ([]byte, http.Header, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		respBody, respHeader, err := c.send(ctx, method, url, jsonBody, header)
+		if err == nil || !c.retry.shouldRetry(method, attempt, err) {
+			return respBody, respHeader, err
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		select {
+		case <-ctx.Done():
+			return respBody, respHeader, err
+		case <-time.After(c.retry.jitteredBackoff(attempt)):
+		}
+	}
+}
+
+// send performs a single attempt of a request, with the body gzipped when the client compresses requests.
+func (c *PinotClient) send(ctx context.Context, method, url string, jsonBody []byte, header http.Header) ([]byte, http.Header, error) {
+	if c.compresses(method, jsonBody) {
+		respBody, respHeader, err := c.sendGzipped(ctx, method, url, jsonBody, header)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnsupportedMediaType {
+			return respBody, respHeader, err
+		}
+		c.gzipRejected.Store(true)
+	}
+
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	return c.do(req, header, "application/json")
+}
+
+// compresses reports whether a request body is sent gzipped.
+func (c *PinotClient) compresses(method string, jsonBody []byte) bool {
+	return c.config.CompressRequests && (method == http.MethodPost || method == http.MethodPut) &&
+		len(jsonBody) > CompressionThreshold && c.gzipRejected != nil && !c.gzipRejected.Load()
+}
+
+// sendGzipped sends jsonBody gzipped with Content-Encoding: gzip.
+func (c *PinotClient) sendGzipped(ctx context.Context, method, url string, jsonBody []byte, header http.Header) ([]byte, http.Header, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(jsonBody); err != nil {
+		return nil, nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, &buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Encoding", "gzip")
+	return c.do(req, header, "application/json")
+}
 
-	req.Header.Set("Content-Type", "application/json")
+// do sets the environment-sourced, per-call, content and auth headers on req, sends it and returns the response
+// body, or an *APIError for 4xx and 5xx responses.
+func (c *PinotClient) do(req *http.Request, header http.Header, contentType string) ([]byte, http.Header, error) {
+	// Selects the database on multi-database clusters.
+	if db := strings.TrimSpace(os.Getenv("PINOT_DATABASE")); db != "" {
+		req.Header.Set("Database", db)
+	}
+	for _, name := range c.envHeaders {
+		if v := os.Getenv(HeaderEnvVar(name)); v != "" {
+			req.Header.Set(name, v)
+		}
+	}
+	for k, vs := range header {
+		req.Header.Del(k)
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	if tok := strings.TrimSpace(c.token); tok != "" {
+	if tok := c.tokenFor(req.Method); tok != "" {
 		switch {
 		case strings.HasPrefix(tok, "Bearer ") || strings.HasPrefix(tok, "Basic "):
 			req.Header.Set("Authorization", tok)
@@ -67,38 +396,383 @@ func (c *PinotClient) doRequest(ctx context.Context, method, url string, body in
 		req.SetBasicAuth(c.username, c.password)
 	}
 
+	routed := c.routeToLeader(req)
 	resp, err := c.httpClient.Do(req)
+	if routed && (err != nil || resp.StatusCode >= 500) {
+		c.leader.reset()
+	}
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, resp.Header, &APIError{StatusCode: resp.StatusCode, Message: apiErrorMessage(respBody)}
+	}
+
+	return respBody, resp.Header, nil
+}
+
+// leaderRouter caches the lead controller of a cluster with several controllers.
+type leaderRouter struct {
+	// controllers are the cluster's controller URLs; the first is the one the client sends reads to.
+	controllers []string
+	mu          sync.Mutex
+	leader      string
+}
+
+// routeToLeader points a mutating request at the lead controller and reports whether it did.
+// Requests to other hosts, e.g. built from a URL returned by the controller, are left alone.
+func (c *PinotClient) routeToLeader(req *http.Request) bool {
+	if c.leader == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return false
+	}
+	leader := c.leader.get(req.Context(), c)
+	current := req.URL.String()
+	if leader == "" || leader == c.controllerURL || !strings.HasPrefix(current, c.controllerURL) {
+		return false
+	}
+	u, err := url.Parse(leader + strings.TrimPrefix(current, c.controllerURL))
+	if err != nil {
+		return false
+	}
+	req.URL = u
+	req.Host = u.Host
+	return true
+}
+
+// get returns the cached lead controller URL, detecting it first when needed. It returns "" when no
+// controller could be asked, so the request goes to the client's own controller and detection is retried.
+func (r *leaderRouter) get(ctx context.Context, c *PinotClient) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.leader != "" {
+		return r.leader
+	}
+	for _, candidate := range r.controllers {
+		id, err := c.WithControllerURL(candidate).LeadControllerID(ctx)
+		if err != nil {
+			continue
+		}
+		// Without a single leader, or one that is not among the configured URLs, any controller takes writes.
+		r.leader = r.controllers[0]
+		for _, u := range r.controllers {
+			if controllerIDMatches(id, u) {
+				r.leader = u
+			}
+		}
+		return r.leader
+	}
+	return ""
+}
+
+// reset forgets the lead controller so the next write detects it again.
+func (r *leaderRouter) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leader = ""
+}
+
+// LeadControllerID returns the instance ID (e.g. Controller_pinot-controller-0_9000) of the lead controller as
+// reported by GET /leader/tables. It returns "" when leadership is spread over several controllers, as it is
+// when the lead controller resource is enabled.
+func (c *PinotClient) LeadControllerID(ctx context.Context) (string, error) {
+	respBody, err := c.doRequest(ctx, "GET", c.baseURL()+"/leader/tables", nil)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		LeadControllerEntryMap map[string]struct {
+			LeadControllerID string `json:"leadControllerId"`
+		} `json:"leadControllerEntryMap"`
+	}
+	if err := decodeJSON(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to decode lead controller response: %w", err)
+	}
+	id := ""
+	for _, entry := range result.LeadControllerEntryMap {
+		switch {
+		case entry.LeadControllerID == "" || entry.LeadControllerID == id:
+		case id == "":
+			id = entry.LeadControllerID
+		default:
+			return "", nil
+		}
+	}
+	return id, nil
+}
+
+// controllerIDMatches reports whether the controller instance ID Controller_<host>_<port> names the
+// controller at controllerURL.
+func controllerIDMatches(id, controllerURL string) bool {
+	hostPort, ok := strings.CutPrefix(id, "Controller_")
+	i := strings.LastIndex(hostPort, "_")
+	if !ok || i < 0 {
+		return false
+	}
+	u, err := url.Parse(controllerURL)
+	if err != nil {
+		return false
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return strings.EqualFold(u.Hostname(), hostPort[:i]) && port == hostPort[i+1:]
+}
+
+// APIError is returned when the controller answers with a 4xx or 5xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// StatusCode returns the HTTP status of the APIError wrapped by err, or 0 when err is not an API error.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 response from the controller.
+func IsNotFound(err error) bool { return StatusCode(err) == http.StatusNotFound }
+
+// IsConflict reports whether err is a 409 response from the controller.
+func IsConflict(err error) bool { return StatusCode(err) == http.StatusConflict }
+
+// IsPreconditionFailed reports whether err is a 412 response, i.e. an If-Match version that no longer matches.
+func IsPreconditionFailed(err error) bool { return StatusCode(err) == http.StatusPreconditionFailed }
+
+// conflictRetryAttempts and conflictRetryWait bound the retries of a request that conflicts with
+// an asynchronous controller operation such as a segment reload.
+var (
+	conflictRetryAttempts = 5
+	conflictRetryWait     = 2 * time.Second
+)
+
+// RetryOnConflict calls fn until it succeeds, fails with anything other than a 409, or the attempts run out.
+func RetryOnConflict(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !IsConflict(err) || attempt >= conflictRetryAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(conflictRetryWait):
+		}
+	}
+}
+
+// schemaRetryAttempts and schemaRetryWait bound the retries of a table create that the controller rejects
+// because the schema it references, created just before, is not visible to it yet.
+var (
+	schemaRetryAttempts = 3
+	schemaRetryWait     = time.Second
+)
+
+// isMissingSchema reports whether err is the controller rejecting a table config whose schema does not exist,
+// e.g. "Schema: events does not exist".
+func isMissingSchema(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode >= http.StatusInternalServerError {
+		return false
+	}
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "schema") && strings.Contains(msg, "does not exist")
+}
+
+// retryOnMissingSchema calls fn until it succeeds, fails with anything other than a missing schema, or the
+// attempts run out. Other errors are left to the caller, so this does not widen which requests are retried.
+func retryOnMissingSchema(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isMissingSchema(err) || attempt >= schemaRetryAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(schemaRetryWait):
+		}
+	}
+}
+
+// createIdempotent POSTs body via post. When the outcome is ambiguous (a transport error or 5xx, where the
+// controller may have created the resource before the response was lost) or the controller reports a conflict,
+// it fetches the resource with get and treats the create as done if the existing resource matches body.
+// If an ambiguous create left nothing behind, the POST is retried once. Keys in ignore (e.g. a password the
+// controller stores hashed) are skipped in the comparison.
+func createIdempotent(ctx context.Context, body interface{}, post func() error, get func() (map[string]interface{}, error), ignore ...string) error {
+	err := post()
+	if err == nil {
+		return nil
+	}
+	status := StatusCode(err)
+	ambiguous := status == 0 || status >= http.StatusInternalServerError
+	if !ambiguous && status != http.StatusConflict {
+		return err
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+
+	existing, getErr := get()
+	switch {
+	case getErr == nil:
+		if matchesDesired(body, existing, ignore) {
+			return nil
+		}
+		return err
+	case IsNotFound(getErr) && ambiguous:
+		return post()
+	default:
+		return err
+	}
+}
+
+// matchesDesired reports whether every key of the desired JSON body is present in existing with the same value.
+// Keys the controller added (defaults) are ignored, and scalars are compared by their string form because
+// Pinot echoes some numbers and booleans back as strings.
+func matchesDesired(desired interface{}, existing map[string]interface{}, ignore []string) bool {
+	b, err := json.Marshal(desired)
+	if err != nil {
+		return false
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal(b, &want); err != nil {
+		return false
+	}
+	for _, k := range ignore {
+		delete(want, k)
+	}
+	return jsonSubset(want, existing)
+}
+
+func jsonSubset(want, got interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok || !jsonSubset(wv, gv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !jsonSubset(w[i], g[i]) {
+				return false
+			}
+		}
+		return true
+	case nil:
+		return got == nil
+	default:
+		return got != nil && fmt.Sprint(w) == fmt.Sprint(got)
+	}
+}
+
+// errorMessageKeys are the keys controller versions use for the message in error bodies, in lookup order.
+var errorMessageKeys = []string{"error", "_error", "message"}
+
+// apiErrorMessage extracts the human-readable message from an error response body, falling back to the raw body.
+func apiErrorMessage(body []byte) string {
+	var parsed map[string]interface{}
+	if err := decodeJSON(body, &parsed); err == nil {
+		for _, k := range errorMessageKeys {
+			if msg, ok := parsed[k].(string); ok && strings.TrimSpace(msg) != "" {
+				return msg
+			}
+		}
+	}
+	return string(body)
+}
+
+// decodeJSON decodes the first JSON value in data into v. Some proxies append a newline-delimited
+// status object to responses, so further JSON values are ignored; anything else after the first value is an error.
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	for {
+		var trailing json.RawMessage
+		err := dec.Decode(&trailing)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid data after JSON value: %w", err)
+		}
 	}
+}
 
-	return respBody, nil
+// stringField returns a string field of a JSON request body.
+func stringField(body interface{}, key string) (string, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return "", err
+	}
+	v, ok := m[key].(string)
+	if !ok || v == "" {
+		return "", fmt.Errorf("%s not found", key)
+	}
+	return v, nil
 }
 
 // Schema operations.
 func (c *PinotClient) CreateSchema(ctx context.Context, schema interface{}) error {
-	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/schemas", c.controllerURL), schema)
-	return err
+	return createIdempotent(ctx, schema,
+		func() error {
+			_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/schemas", c.baseURL()), schema)
+			return err
+		},
+		func() (map[string]interface{}, error) {
+			name, err := stringField(schema, "schemaName")
+			if err != nil {
+				return nil, err
+			}
+			return c.GetSchema(ctx, name)
+		},
+	)
 }
 
 func (c *PinotClient) GetSchema(ctx context.Context, schemaName string) (map[string]interface{}, error) {
-	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/schemas/%s", c.controllerURL, schemaName), nil)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/schemas/%s", c.baseURL(), schemaName), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var schema map[string]interface{}
-	if err := json.Unmarshal(resp, &schema); err != nil {
+	if err := decodeJSON(resp, &schema); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
 	}
 
@@ -118,89 +792,1024 @@ func (c *PinotClient) UpdateSchema(ctx context.Context, schema interface{}) erro
 	if !ok {
 		return fmt.Errorf("schema name not found")
 	}
-	_, err = c.doRequest(ctx, "PUT", fmt.Sprintf("%s/schemas/%s", c.controllerURL, schemaName), schema)
-	return err
+	// Some controller versions reject schema updates with 409 while a segment reload is running.
+	// The reload is asynchronous, so wait a little and try again instead of failing the apply.
+	return RetryOnConflict(ctx, func() error {
+		_, err := c.doRequest(ctx, "PUT", fmt.Sprintf("%s/schemas/%s", c.baseURL(), schemaName), schema)
+		return err
+	})
 }
 
 func (c *PinotClient) DeleteSchema(ctx context.Context, schemaName string) error {
-	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/schemas/%s", c.controllerURL, schemaName), nil)
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/schemas/%s", c.baseURL(), schemaName), nil)
 	return err
 }
 
+// ListSchemas returns the names of every schema in the cluster, sorted.
+func (c *PinotClient) ListSchemas(ctx context.Context) ([]string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/schemas", c.baseURL()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	if err := decodeJSON(resp, &names); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema names: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // Table operations.
 func (c *PinotClient) CreateTable(ctx context.Context, tableConfig interface{}) error {
-	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tables", c.controllerURL), tableConfig)
-	return err
+	return createIdempotent(ctx, tableConfig,
+		func() error {
+			return retryOnMissingSchema(ctx, func() error {
+				_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tables", c.baseURL()), tableConfig)
+				return err
+			})
+		},
+		func() (map[string]interface{}, error) {
+			name, err := stringField(tableConfig, "tableName")
+			if err != nil {
+				return nil, err
+			}
+			return c.GetTable(ctx, name)
+		},
+	)
 }
 
 func (c *PinotClient) GetTable(ctx context.Context, tableName string) (map[string]interface{}, error) {
-	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tables/%s", c.controllerURL, tableName), nil)
+	config, _, err := c.GetTableWithVersion(ctx, tableName)
+	return config, err
+}
+
+// GetTableWithVersion returns the table config together with the ETag the controller sent for it,
+// or an empty version when the controller does not version table configs.
+func (c *PinotClient) GetTableWithVersion(ctx context.Context, tableName string) (map[string]interface{}, string, error) {
+	resp, header, err := c.doRequestWithHeaders(ctx, "GET", fmt.Sprintf("%s/tables/%s", c.baseURL(), tableName), nil, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	version := header.Get("ETag")
 
 	var response map[string]interface{}
-	if err := json.Unmarshal(resp, &response); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal table config: %w", err)
+	if err := decodeJSON(resp, &response); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal table config: %w", err)
 	}
 
 	if offlineConfig, ok := response["OFFLINE"].(map[string]interface{}); ok {
-		return offlineConfig, nil
+		return offlineConfig, version, nil
 	}
 	if realtimeConfig, ok := response["REALTIME"].(map[string]interface{}); ok {
-		return realtimeConfig, nil
+		return realtimeConfig, version, nil
 	}
-	return response, nil
+	return response, version, nil
 }
 
-func (c *PinotClient) UpdateTable(ctx context.Context, tableConfig interface{}) error {
-	jsonBytes, err := json.Marshal(tableConfig)
+// GetTableTypes returns which table types (OFFLINE, REALTIME) exist for a logical table name.
+func (c *PinotClient) GetTableTypes(ctx context.Context, logicalName string) ([]string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tables/%s", c.baseURL(), url.PathEscape(logicalName)), nil)
 	if err != nil {
-		return fmt.Errorf("failed to marshal table config: %w", err)
+		return nil, err
 	}
-	var tableMap map[string]interface{}
-	if err := json.Unmarshal(jsonBytes, &tableMap); err != nil {
-		return fmt.Errorf("failed to unmarshal table config: %w", err)
+
+	var response map[string]json.RawMessage
+	if err := decodeJSON(resp, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal table config: %w", err)
 	}
-	tableName, ok := tableMap["tableName"].(string)
-	if !ok {
-		return fmt.Errorf("table name not found")
+
+	var types []string
+	for _, typ := range []string{"OFFLINE", "REALTIME"} {
+		if raw, ok := response[typ]; ok && string(raw) != "null" {
+			types = append(types, typ)
+		}
 	}
-	_, err = c.doRequest(ctx, "PUT", fmt.Sprintf("%s/tables/%s", c.controllerURL, tableName), tableConfig)
-	return err
+	return types, nil
 }
 
-func (c *PinotClient) DeleteTable(ctx context.Context, tableName string) error {
-	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/tables/%s", c.controllerURL, tableName), nil)
-	return err
-}
+// GetTableState returns the current state (`enabled` or `disabled`) of a table.
+func (c *PinotClient) GetTableState(ctx context.Context, logicalName, tableType string) (string, error) {
+	endpoint := fmt.Sprintf("%s/tables/%s/state?type=%s",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(tableType)),
+	)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return "", err
+	}
 
-func (c *PinotClient) ReloadTable(ctx context.Context, logicalName, tableType string) error {
-	var missing []string
-	if logicalName == "" {
-		missing = append(missing, "logicalName")
+	var state struct {
+		State string `json:"state"`
 	}
-	if tableType == "" {
-		missing = append(missing, "tableType")
+	if err := decodeJSON(resp, &state); err != nil {
+		return "", fmt.Errorf("failed to unmarshal table state: %w", err)
 	}
-	if len(missing) > 0 {
-		return fmt.Errorf("%s is required", strings.Join(missing, " and "))
+	if state.State == "" {
+		return "", fmt.Errorf("table state response has no state")
 	}
-	u := fmt.Sprintf("%s/segments/%s/reload?type=%s",
-		c.controllerURL,
-		url.PathEscape(logicalName),
-		url.QueryEscape(strings.ToUpper(tableType)),
-	)
-	_, err := c.doRequest(ctx, "POST", u, nil)
-	return err
+	return strings.ToLower(state.State), nil
 }
 
-// User operations.
+// GetTableTuningConfig returns the tuner configs (tunerConfigs) of one type of a table from the combined
+// GET /tableConfigs/{name} view, or nil when the table has none.
+func (c *PinotClient) GetTableTuningConfig(ctx context.Context, logicalName, tableType string) ([]interface{}, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tableConfigs/%s", c.baseURL(), url.PathEscape(logicalName)), nil)
+	if err != nil {
+		return nil, err
+	}
 
-// CreateUser accepts any struct/map body.
-func (c *PinotClient) CreateUser(ctx context.Context, user interface{}) error {
-	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/users", c.controllerURL), user)
-	return err
+	var configs map[string]interface{}
+	if err := decodeJSON(resp, &configs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal table configs: %w", err)
+	}
+	tableConfig, _ := configs[strings.ToLower(tableType)].(map[string]interface{})
+	tuners, _ := tableConfig["tunerConfigs"].([]interface{})
+	if len(tuners) == 0 {
+		return nil, nil
+	}
+	return tuners, nil
+}
+
+// TableConfigs is the combined view of a logical table: its schema and the configs of its OFFLINE and
+// REALTIME tables, either of which may be nil.
+type TableConfigs struct {
+	TableName string                 `json:"tableName"`
+	Schema    map[string]interface{} `json:"schema"`
+	Offline   map[string]interface{} `json:"offline,omitempty"`
+	Realtime  map[string]interface{} `json:"realtime,omitempty"`
+}
+
+// ValidateTableConfigs checks a schema and its table configs together with POST /tableConfigs/validate, which
+// catches inconsistencies between them (e.g. a time column missing from the schema) without changing anything.
+// A rejected config yields an *APIError carrying the controller's message.
+func (c *PinotClient) ValidateTableConfigs(ctx context.Context, configs TableConfigs) error {
+	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tableConfigs/validate", c.baseURL()), configs)
+	return err
+}
+
+// GetTableIndexes returns, per column, the index types built on at least one segment of the table
+// (GET /tables/{name}/indexes). Index types are sorted; columns without any index are omitted.
+func (c *PinotClient) GetTableIndexes(ctx context.Context, logicalName, tableType string) (map[string][]string, error) {
+	endpoint := fmt.Sprintf("%s/tables/%s/indexes?type=%s",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(tableType)),
+	)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ColumnToIndexesCount map[string]map[string]float64 `json:"columnToIndexesCount"`
+	}
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal table indexes: %w", err)
+	}
+
+	out := make(map[string][]string, len(result.ColumnToIndexesCount))
+	for column, counts := range result.ColumnToIndexesCount {
+		var indexes []string
+		for index, count := range counts {
+			if count > 0 {
+				indexes = append(indexes, index)
+			}
+		}
+		if len(indexes) == 0 {
+			continue
+		}
+		sort.Strings(indexes)
+		out[column] = indexes
+	}
+	return out, nil
+}
+
+// ListTables returns the table names known to the controller, optionally filtered by type (OFFLINE or REALTIME).
+func (c *PinotClient) ListTables(ctx context.Context, tableType string) ([]string, error) {
+	endpoint := fmt.Sprintf("%s/tables", c.baseURL())
+	if tableType != "" {
+		endpoint += "?type=" + url.QueryEscape(strings.ToUpper(tableType))
+	}
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables struct {
+		Tables []string `json:"tables"`
+	}
+	if err := decodeJSON(resp, &tables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal table list: %w", err)
+	}
+	return tables.Tables, nil
+}
+
+// ListTableNames returns the tables of tableType (both types when empty) as <logical>_<TYPE>, sorted. Controllers
+// return either the raw or the type-suffixed name from the list endpoint.
+func (c *PinotClient) ListTableNames(ctx context.Context, tableType string) ([]string, error) {
+	tableTypes := []string{"OFFLINE", "REALTIME"}
+	if tableType != "" {
+		tableTypes = []string{strings.ToUpper(tableType)}
+	}
+	out := []string{}
+	for _, typ := range tableTypes {
+		names, err := c.ListTables(ctx, typ)
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s tables: %w", typ, err)
+		}
+		for _, name := range names {
+			if !strings.HasSuffix(name, "_"+typ) {
+				name += "_" + typ
+			}
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// ListTablesOnTenant returns the tables of tableType (both types when empty) whose broker or server tenant is
+// tenant, as <logical>_<TYPE>, sorted. The list endpoint cannot filter by tenant, so this reads the config of
+// every table: one request per table. Tables without a tenant are on DefaultTenant.
+func (c *PinotClient) ListTablesOnTenant(ctx context.Context, tableType, tenant string) ([]string, error) {
+	names, err := c.ListTableNames(ctx, tableType)
+	if err != nil {
+		return nil, err
+	}
+	out := []string{}
+	for _, name := range names {
+		config, err := c.GetTable(ctx, name)
+		if err != nil {
+			if IsNotFound(err) {
+				// Deleted since it was listed.
+				continue
+			}
+			return nil, fmt.Errorf("could not read table %s: %w", name, err)
+		}
+		tenants, _ := config["tenants"].(map[string]interface{})
+		for _, key := range []string{"broker", "server"} {
+			t, _ := tenants[key].(string)
+			if t == "" {
+				t = "DefaultTenant"
+			}
+			if t == tenant {
+				out = append(out, name)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c *PinotClient) UpdateTable(ctx context.Context, tableConfig interface{}) error {
+	_, err := c.UpdateTableIfMatch(ctx, tableConfig, "")
+	return err
+}
+
+// UpdateTableIfMatch updates the table only if its config is still at version, as returned by GetTableWithVersion;
+// otherwise the controller answers 412 (see IsPreconditionFailed). An empty version updates unconditionally.
+// It returns the ETag of the updated config, or "" when the controller sent none.
+func (c *PinotClient) UpdateTableIfMatch(ctx context.Context, tableConfig interface{}, version string) (string, error) {
+	jsonBytes, err := json.Marshal(tableConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal table config: %w", err)
+	}
+	var tableMap map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &tableMap); err != nil {
+		return "", fmt.Errorf("failed to unmarshal table config: %w", err)
+	}
+	tableName, ok := tableMap["tableName"].(string)
+	if !ok {
+		return "", fmt.Errorf("table name not found")
+	}
+	var header http.Header
+	if version != "" {
+		header = http.Header{"If-Match": []string{version}}
+	}
+	_, respHeader, err := c.doRequestWithHeaders(ctx, "PUT", fmt.Sprintf("%s/tables/%s", c.baseURL(), tableName), tableConfig, header)
+	if err != nil {
+		return "", err
+	}
+	return respHeader.Get("ETag"), nil
+}
+
+func (c *PinotClient) DeleteTable(ctx context.Context, tableName string) error {
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/tables/%s", c.baseURL(), tableName), nil)
+	return err
+}
+
+// DeleteTableByType deletes the OFFLINE or REALTIME table of a logical table, leaving a table of the other type
+// with the same name in place.
+func (c *PinotClient) DeleteTableByType(ctx context.Context, logicalName, tableType string) error {
+	q := url.Values{"type": {strings.ToUpper(tableType)}}
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/tables/%s?%s", c.baseURL(), url.PathEscape(logicalName), q.Encode()), nil)
+	return err
+}
+
+// ReloadTable reloads all segments of a table. With forceDownload the servers re-download every segment
+// from the deep store instead of rebuilding indexes from their local copies.
+func (c *PinotClient) ReloadTable(ctx context.Context, logicalName, tableType string, forceDownload bool) error {
+	var missing []string
+	if logicalName == "" {
+		missing = append(missing, "logicalName")
+	}
+	if tableType == "" {
+		missing = append(missing, "tableType")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s is required", strings.Join(missing, " and "))
+	}
+	u := fmt.Sprintf("%s/segments/%s/reload?type=%s",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(tableType)),
+	)
+	if forceDownload {
+		u += "&forceDownload=true"
+	}
+	_, err := c.doRequest(ctx, "POST", u, nil)
+	return err
+}
+
+// RefreshTable refreshes the metadata of all segments of a table without rebuilding their indexes, which is
+// much cheaper than ReloadTable for large tables. Controllers that predate the endpoint return 404.
+func (c *PinotClient) RefreshTable(ctx context.Context, logicalName, tableType string) error {
+	if logicalName == "" || tableType == "" {
+		return fmt.Errorf("logicalName and tableType are required")
+	}
+	u := fmt.Sprintf("%s/segments/%s/refresh?type=%s",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(tableType)),
+	)
+	_, err := c.doRequest(ctx, "POST", u, nil)
+	return err
+}
+
+// PauseConsumption stops the realtime table logicalName from consuming its stream. The consuming segments are
+// committed, so queries keep serving everything ingested so far.
+func (c *PinotClient) PauseConsumption(ctx context.Context, logicalName string) error {
+	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tables/%s/pauseConsumption", c.baseURL(), url.PathEscape(logicalName)), nil)
+	return err
+}
+
+// ResumeConsumption restarts consumption of a realtime table paused with PauseConsumption, from the offsets it
+// stopped at.
+func (c *PinotClient) ResumeConsumption(ctx context.Context, logicalName string) error {
+	q := url.Values{"consumeFrom": {"lastConsumed"}}
+	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tables/%s/resumeConsumption?%s", c.baseURL(), url.PathEscape(logicalName), q.Encode()), nil)
+	return err
+}
+
+// SetTimeBoundary sets the query time boundary of a hybrid table from its offline segments' metadata.
+// strategy is passed through to the controller when non-empty.
+func (c *PinotClient) SetTimeBoundary(ctx context.Context, logicalName, strategy string) error {
+	if logicalName == "" {
+		return fmt.Errorf("logicalName is required")
+	}
+	u := fmt.Sprintf("%s/tables/%s/timeBoundary", c.baseURL(), url.PathEscape(logicalName))
+	if strategy != "" {
+		u += "?strategy=" + url.QueryEscape(strategy)
+	}
+	_, err := c.doRequest(ctx, "POST", u, nil)
+	return err
+}
+
+// DeleteTimeBoundary removes a manually set time boundary so the broker falls back to its default detection.
+func (c *PinotClient) DeleteTimeBoundary(ctx context.Context, logicalName string) error {
+	if logicalName == "" {
+		return fmt.Errorf("logicalName is required")
+	}
+	u := fmt.Sprintf("%s/tables/%s/timeBoundary", c.baseURL(), url.PathEscape(logicalName))
+	_, err := c.doRequest(ctx, "DELETE", u, nil)
+	return err
+}
+
+// Instance partition operations.
+
+// GetInstancePartitions returns the instance partitions of the given type (OFFLINE, CONSUMING or COMPLETED) for a table,
+// or nil when the table has none of that type.
+func (c *PinotClient) GetInstancePartitions(ctx context.Context, logicalName, partitionsType string) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/tables/%s/instancePartitions?type=%s",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(partitionsType)),
+	)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response map[string]interface{}
+	if err := decodeJSON(resp, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instance partitions: %w", err)
+	}
+
+	// The controller keys the response by instance partitions type.
+	if partitions, ok := response[strings.ToUpper(partitionsType)].(map[string]interface{}); ok {
+		return partitions, nil
+	}
+	return nil, nil
+}
+
+// UpdateInstancePartitions replaces the instance partitions of a table. The body must include instancePartitionsName.
+func (c *PinotClient) UpdateInstancePartitions(ctx context.Context, logicalName string, partitions interface{}) error {
+	endpoint := fmt.Sprintf("%s/tables/%s/instancePartitions", c.baseURL(), url.PathEscape(logicalName))
+	_, err := c.doRequest(ctx, "PUT", endpoint, partitions)
+	return err
+}
+
+// DeleteInstancePartitions removes the stored instance partitions of the given type so the controller
+// computes them from the table config again.
+func (c *PinotClient) DeleteInstancePartitions(ctx context.Context, logicalName, partitionsType string) error {
+	endpoint := fmt.Sprintf("%s/tables/%s/instancePartitions?type=%s",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(partitionsType)),
+	)
+	_, err := c.doRequest(ctx, "DELETE", endpoint, nil)
+	return err
+}
+
+// GetEffectiveSegmentAssignment returns, per instance partitions type of the table (OFFLINE, or CONSUMING and
+// COMPLETED), the segment assignment strategy its instance partitions put in effect: ReplicaGroup when they hold
+// more than one replica group and Balanced otherwise, with their layout. Types without stored instance partitions
+// report "Balanced (default)", since the controller then spreads segments over every server of the tenant.
+func (c *PinotClient) GetEffectiveSegmentAssignment(ctx context.Context, logicalName, tableType string) (map[string]string, error) {
+	partitionsTypes := []string{"OFFLINE"}
+	if strings.EqualFold(tableType, "REALTIME") {
+		partitionsTypes = []string{"CONSUMING", "COMPLETED"}
+	}
+
+	endpoint := fmt.Sprintf("%s/tables/%s/instancePartitions", c.baseURL(), url.PathEscape(logicalName))
+	response := map[string]struct {
+		PartitionToInstancesMap map[string][]string `json:"partitionToInstancesMap"`
+	}{}
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil && !IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := decodeJSON(resp, &response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal instance partitions: %w", err)
+		}
+	}
+
+	out := make(map[string]string, len(partitionsTypes))
+	for _, typ := range partitionsTypes {
+		partitions, ok := response[typ]
+		if !ok || len(partitions.PartitionToInstancesMap) == 0 {
+			out[typ] = "Balanced (default)"
+			continue
+		}
+		// Keys are <partitionId>_<replicaGroupId>.
+		partitionIDs, replicaGroupIDs := map[string]bool{}, map[string]bool{}
+		for key := range partitions.PartitionToInstancesMap {
+			partition, replicaGroup, _ := strings.Cut(key, "_")
+			partitionIDs[partition] = true
+			replicaGroupIDs[replicaGroup] = true
+		}
+		strategy := "Balanced"
+		if len(replicaGroupIDs) > 1 {
+			strategy = "ReplicaGroup"
+		}
+		out[typ] = fmt.Sprintf("%s (%s, %s)", strategy,
+			plural(len(replicaGroupIDs), "replica group"), plural(len(partitionIDs), "partition"))
+	}
+	return out, nil
+}
+
+// plural formats n followed by noun, which gets an s unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// RebalanceOptions are the query parameters of a rebalance. Unset fields keep the controller's defaults.
+type RebalanceOptions struct {
+	// DryRun only computes the proposed assignment.
+	DryRun bool
+	// Bootstrap reassigns every segment from scratch instead of minimizing movement.
+	Bootstrap bool
+	// LowDiskMode adds new replicas only after old ones are removed, for servers short on disk.
+	LowDiskMode bool
+	// BestEfforts continues when the no-downtime constraint cannot be met instead of failing.
+	BestEfforts bool
+	// MinAvailableReplicas is the number of replicas kept serving during the rebalance; a negative value is the
+	// number that may be unavailable. Nil keeps the controller default.
+	MinAvailableReplicas *int64
+}
+
+// RebalanceTable rebalances the table so segment assignment follows the current instance partitions.
+// With dryRun set nothing is moved; the returned result describes the proposed assignment either way.
+func (c *PinotClient) RebalanceTable(ctx context.Context, logicalName, tableType string, dryRun bool) (map[string]interface{}, error) {
+	return c.RebalanceTableWithOptions(ctx, logicalName, tableType, RebalanceOptions{DryRun: dryRun})
+}
+
+// RebalanceTableWithOptions is RebalanceTable with the controller's rebalance options. The result carries the
+// rebalance job ID under jobId.
+func (c *PinotClient) RebalanceTableWithOptions(ctx context.Context, logicalName, tableType string, opts RebalanceOptions) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/tables/%s/rebalance?type=%s&reassignInstances=false&dryRun=%t",
+		c.baseURL(),
+		url.PathEscape(logicalName),
+		url.QueryEscape(strings.ToUpper(tableType)),
+		opts.DryRun,
+	)
+	if opts.Bootstrap {
+		endpoint += "&bootstrap=true"
+	}
+	if opts.LowDiskMode {
+		endpoint += "&lowDiskMode=true"
+	}
+	if opts.BestEfforts {
+		endpoint += "&bestEfforts=true"
+	}
+	if opts.MinAvailableReplicas != nil {
+		endpoint += fmt.Sprintf("&minAvailableReplicas=%d", *opts.MinAvailableReplicas)
+	}
+	resp, err := c.doRequest(ctx, "POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rebalance result: %w", err)
+	}
+	return result, nil
+}
+
+// Segment operations.
+
+// SegmentAssignment maps table type to segment name to instance to segment state, the shape of both
+// the ideal state and the external view.
+type SegmentAssignment map[string]map[string]map[string]string
+
+// GetExternalView returns the table's external view: the state every replica is actually in.
+// tableName may be the logical name (both table types are included) or carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) GetExternalView(ctx context.Context, tableName string) (SegmentAssignment, error) {
+	return c.getSegmentAssignment(ctx, tableName, "externalview")
+}
+
+// GetIdealState returns the table's ideal state: the state every replica should be in.
+func (c *PinotClient) GetIdealState(ctx context.Context, tableName string) (SegmentAssignment, error) {
+	return c.getSegmentAssignment(ctx, tableName, "idealstate")
+}
+
+func (c *PinotClient) getSegmentAssignment(ctx context.Context, tableName, view string) (SegmentAssignment, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tables/%s/%s", c.baseURL(), url.PathEscape(tableName), view), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// {"OFFLINE": {"<segment>": {"<instance>": "ONLINE"}}, "REALTIME": null}
+	var assignment SegmentAssignment
+	if err := decodeJSON(resp, &assignment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", view, err)
+	}
+	return assignment, nil
+}
+
+// GetSegmentsByState returns, sorted, the segments of a table with at least one replica in the given
+// external view state (e.g. ERROR). An empty state returns every segment. tableName may be the logical
+// name (both table types are included) or carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) GetSegmentsByState(ctx context.Context, tableName, state string) ([]string, error) {
+	views, err := c.GetExternalView(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, view := range views {
+		for segment, replicas := range view {
+			if state == "" {
+				segments = append(segments, segment)
+				continue
+			}
+			for _, s := range replicas {
+				if strings.EqualFold(s, state) {
+					segments = append(segments, segment)
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// SegmentReplicationStatus summarizes how well a table's segments are replicated.
+type SegmentReplicationStatus struct {
+	// TotalSegments is the number of segments in the ideal state.
+	TotalSegments int
+	// UnderReplicated lists, sorted, the segments with fewer serving replicas than the ideal state asks for.
+	UnderReplicated []string
+}
+
+// GetSegmentReplicationStatus compares the ideal state with the external view and reports the segments
+// that have fewer replicas in their target state (ONLINE or CONSUMING) than the ideal state assigns.
+func (c *PinotClient) GetSegmentReplicationStatus(ctx context.Context, tableName string) (*SegmentReplicationStatus, error) {
+	ideal, err := c.GetIdealState(ctx, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ideal state: %w", err)
+	}
+	external, err := c.GetExternalView(ctx, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read external view: %w", err)
+	}
+	return replicationStatus(ideal, external), nil
+}
+
+func replicationStatus(ideal, external SegmentAssignment) *SegmentReplicationStatus {
+	status := &SegmentReplicationStatus{}
+	for typ, segments := range ideal {
+		for segment, targets := range segments {
+			status.TotalSegments++
+			actual := external[typ][segment]
+			for instance, want := range targets {
+				if want != "ONLINE" && want != "CONSUMING" {
+					continue
+				}
+				if actual[instance] != want {
+					status.UnderReplicated = append(status.UnderReplicated, segment)
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(status.UnderReplicated)
+	return status
+}
+
+// GetSegmentMetadata returns the metadata of a single segment.
+func (c *PinotClient) GetSegmentMetadata(ctx context.Context, tableName, segmentName string) (map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/segments/%s/%s/metadata",
+		c.baseURL(), url.PathEscape(tableName), url.PathEscape(segmentName)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata map[string]interface{}
+	if err := decodeJSON(resp, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal segment metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// GetAllSegmentMetadata returns the metadata of every segment of a table, keyed by segment name, in one request.
+// columns limits the per-column metadata to the named columns; nil omits column metadata entirely, which keeps
+// the response small for tables with many segments.
+func (c *PinotClient) GetAllSegmentMetadata(ctx context.Context, tableName string, columns []string) (map[string]map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/segments/%s/metadata", c.baseURL(), url.PathEscape(tableName))
+	if len(columns) > 0 {
+		v := url.Values{"columns": columns}
+		endpoint += "?" + v.Encode()
+	}
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata map[string]map[string]interface{}
+	if err := decodeJSON(resp, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal segment metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// SelectSegmentsByTimeRange returns, sorted, the segments of a `<logical>_<TYPE>` table whose time range lies
+// entirely within [startMs, endMs). Segments that only overlap the range are excluded.
+func (c *PinotClient) SelectSegmentsByTimeRange(ctx context.Context, tableName string, startMs, endMs int64) ([]string, error) {
+	logical, typ, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/segments/%s/select?type=%s&startTimestamp=%d&endTimestamp=%d&excludeOverlapping=true",
+		c.baseURL(), url.PathEscape(logical), typ, startMs, endMs)
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// [{"OFFLINE": ["<segment>", ...]}]
+	var selected []map[string][]string
+	if err := decodeJSON(resp, &selected); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal selected segments: %w", err)
+	}
+
+	var segments []string
+	for _, byType := range selected {
+		segments = append(segments, byType[typ]...)
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// DeleteSegments deletes the named segments of a `<logical>_<TYPE>` table in one request.
+func (c *PinotClient) DeleteSegments(ctx context.Context, tableName string, segments []string) error {
+	logical, typ, err := splitTableName(tableName)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(ctx, "POST", fmt.Sprintf("%s/segments/%s/delete?type=%s", c.baseURL(), url.PathEscape(logical), typ), segments)
+	return err
+}
+
+// DeleteAllSegments deletes every segment in the ideal state of a `<logical>_<TYPE>` table, batchSize segments
+// per request, and returns how many were deleted. A failed batch does not stop the others; their errors are joined.
+func (c *PinotClient) DeleteAllSegments(ctx context.Context, tableName string, batchSize int) (int, error) {
+	ideal, err := c.GetIdealState(ctx, tableName)
+	if err != nil {
+		return 0, err
+	}
+	var segments []string
+	for _, view := range ideal {
+		for segment := range view {
+			segments = append(segments, segment)
+		}
+	}
+	sort.Strings(segments)
+	if batchSize <= 0 {
+		batchSize = max(len(segments), 1)
+	}
+
+	deleted := 0
+	var errs []error
+	for start := 0; start < len(segments); start += batchSize {
+		batch := segments[start:min(start+batchSize, len(segments))]
+		if err := c.DeleteSegments(ctx, tableName, batch); err != nil {
+			errs = append(errs, fmt.Errorf("segments %s to %s: %w", batch[0], batch[len(batch)-1], err))
+			continue
+		}
+		deleted += len(batch)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// DeleteSegmentsByTimeRange deletes the segments of a `<logical>_<TYPE>` table whose time range lies entirely
+// within [startMs, endMs) and returns their names. As a safeguard it refuses to delete every segment of the table.
+func (c *PinotClient) DeleteSegmentsByTimeRange(ctx context.Context, tableName string, startMs, endMs int64) ([]string, error) {
+	if startMs < 0 || endMs <= startMs {
+		return nil, fmt.Errorf("invalid time range [%d, %d)", startMs, endMs)
+	}
+
+	segments, err := c.SelectSegmentsByTimeRange(ctx, tableName, startMs, endMs)
+	if err != nil {
+		return nil, fmt.Errorf("could not select segments: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	all, err := c.GetSegmentsByState(ctx, tableName, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not list segments: %w", err)
+	}
+	if len(segments) >= len(all) {
+		return nil, fmt.Errorf("the time range matches all %d segments of table %s; refusing to delete every segment", len(all), tableName)
+	}
+
+	if err := c.DeleteSegments(ctx, tableName, segments); err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+// Segment tar formats accepted by UploadSegmentFile.
+const (
+	SegmentFormatTar   = "tar"
+	SegmentFormatTarGz = "tar.gz"
+)
+
+// SegmentUploadOptions tune how UploadSegmentFile sends a segment.
+type SegmentUploadOptions struct {
+	// Format is the format the file must have, SegmentFormatTar or SegmentFormatTarGz; empty accepts either.
+	Format string
+	// Gzip compresses the request body on the fly and sends it with Content-Encoding: gzip, to save bandwidth
+	// on uncompressed tars. It is refused for gzipped files, which would not shrink further.
+	Gzip bool
+}
+
+// UploadSegmentFile uploads a segment tar (usually a .tar.gz) from the local file system to the table
+// (POST /segments?tableName=). The file is streamed, so segments of any size can be uploaded. tableName must
+// carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) UploadSegmentFile(ctx context.Context, tableName, filePath string, opts SegmentUploadOptions) error {
+	logical, typ, err := splitTableName(tableName)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open segment file: %w", err)
+	}
+	defer f.Close()
+	gzipped, err := checkSegmentTar(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filePath, err)
+	}
+	format := SegmentFormatTar
+	if gzipped {
+		format = SegmentFormatTarGz
+	}
+	if opts.Format != "" && opts.Format != format {
+		return fmt.Errorf("%s: is a %s, not a %s", filePath, format, opts.Format)
+	}
+	if opts.Gzip && gzipped {
+		return fmt.Errorf("%s: is already gzipped; upload it without gzip compression", filePath)
+	}
+
+	endpoint := fmt.Sprintf("%s/segments?tableName=%s&tableType=%s", c.baseURL(), url.QueryEscape(logical), typ)
+	_, err = c.postFile(ctx, endpoint, f, opts.Gzip)
+	return err
+}
+
+// UploadSegmentURI has the controller download a segment tar from uri, e.g. in the deep store, and add it to the
+// table (POST /segments with UPLOAD_TYPE: URI). tableName must carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) UploadSegmentURI(ctx context.Context, tableName, uri string) error {
+	logical, typ, err := splitTableName(tableName)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/segments?tableName=%s&tableType=%s", c.baseURL(), url.QueryEscape(logical), typ)
+	header := http.Header{"UPLOAD_TYPE": {"URI"}, "DOWNLOAD_URI": {uri}}
+	_, _, err = c.doRequestWithHeaders(ctx, "POST", endpoint, nil, header)
+	return err
+}
+
+// IngestionJobSpec describes a batch ingestion the controller runs itself, meant for small files; large
+// ingestions belong in a standalone ingestion job. Exactly one of FilePath and SourceURI is used.
+type IngestionJobSpec struct {
+	// BatchConfig is Pinot's batchConfigMap, e.g. inputFormat and recordReader.prop.* entries.
+	BatchConfig map[string]string
+	// FilePath is a local file uploaded with the request.
+	FilePath string
+	// SourceURI is a file the controller reads itself, e.g. from the deep store; used when FilePath is empty.
+	SourceURI string
+}
+
+// IngestFromFile ingests one file into a `<logical>_<TYPE>` table through POST /ingestFromFile, or
+// /ingestFromURI when spec names a SourceURI, and returns the controller's status message. The controller
+// builds and uploads the segment before it answers; it does not register the ingestion as a job.
+func (c *PinotClient) IngestFromFile(ctx context.Context, tableName string, spec IngestionJobSpec) (string, error) {
+	if _, _, err := splitTableName(tableName); err != nil {
+		return "", err
+	}
+	batchConfig, err := json.Marshal(spec.BatchConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal batch config: %w", err)
+	}
+	query := url.Values{"tableNameWithType": {tableName}, "batchConfigMapStr": {string(batchConfig)}}
+
+	var respBody []byte
+	if spec.FilePath == "" {
+		if spec.SourceURI == "" {
+			return "", fmt.Errorf("ingestion of %s needs a file path or a source URI", tableName)
+		}
+		query.Set("sourceURIStr", spec.SourceURI)
+		respBody, err = c.doRequest(ctx, "POST", c.baseURL()+"/ingestFromURI?"+query.Encode(), nil)
+	} else {
+		var f *os.File
+		if f, err = os.Open(spec.FilePath); err != nil {
+			return "", fmt.Errorf("failed to open file to ingest: %w", err)
+		}
+		defer f.Close()
+		respBody, err = c.postFile(ctx, c.baseURL()+"/ingestFromFile?"+query.Encode(), f, false)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Status string `json:"status"`
+	}
+	if err := decodeJSON(respBody, &result); err == nil && result.Status != "" {
+		return result.Status, nil
+	}
+	return strings.TrimSpace(string(respBody)), nil
+}
+
+// postFile streams f to endpoint as the "file" part of a multipart form, gzipping the whole body with
+// Content-Encoding: gzip when gzipBody is set.
+func (c *PinotClient) postFile(ctx context.Context, endpoint string, f *os.File, gzipBody bool) ([]byte, error) {
+	body, writer := io.Pipe()
+	var (
+		out io.Writer = writer
+		gz  *gzip.Writer
+	)
+	if gzipBody {
+		gz = gzip.NewWriter(writer)
+		out = gz
+	}
+	form := multipart.NewWriter(out)
+	go func() {
+		part, err := form.CreateFormFile("file", filepath.Base(f.Name()))
+		if err == nil {
+			_, err = io.Copy(part, f)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		if err == nil && gz != nil {
+			err = gz.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, body)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	var header http.Header
+	if gzipBody {
+		header = http.Header{"Content-Encoding": {"gzip"}}
+	}
+	respBody, _, err := c.do(req, header, form.FormDataContentType())
+	// Unblock the writer if the request failed before reading the whole file.
+	body.Close()
+	return respBody, err
+}
+
+// checkSegmentTar reports whether f is a tar archive, optionally gzip-compressed, and whether it is gzipped.
+// It rewinds f.
+func checkSegmentTar(f *os.File) (bool, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.IsDir() {
+		return false, fmt.Errorf("is a directory, not a segment tar")
+	}
+
+	var r io.Reader = f
+	magic := make([]byte, 2)
+	gzipped := false
+	if _, err := io.ReadFull(f, magic); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return false, fmt.Errorf("not a valid gzip file: %w", err)
+		}
+		defer gz.Close()
+		r, gzipped = gz, true
+	} else if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	if _, err := tar.NewReader(r).Next(); err != nil {
+		return false, fmt.Errorf("not a segment tar: %w", err)
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return gzipped, err
+}
+
+// splitTableName splits `<logical>_<TYPE>` into the logical name and the table type.
+func splitTableName(tableName string) (string, string, error) {
+	for _, typ := range []string{"OFFLINE", "REALTIME"} {
+		if logical := strings.TrimSuffix(tableName, "_"+typ); logical != tableName && logical != "" {
+			return logical, typ, nil
+		}
+	}
+	return "", "", fmt.Errorf("table %q must be in the form <logical>_OFFLINE or <logical>_REALTIME", tableName)
+}
+
+// User operations.
+
+// CreateUser accepts any struct/map body.
+func (c *PinotClient) CreateUser(ctx context.Context, user interface{}) error {
+	return createIdempotent(ctx, user,
+		func() error {
+			_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/users", c.baseURL()), user)
+			return err
+		},
+		func() (map[string]interface{}, error) {
+			username, err := stringField(user, "username")
+			if err != nil {
+				return nil, err
+			}
+			component, err := stringField(user, "component")
+			if err != nil {
+				return nil, err
+			}
+			m, err := c.GetUser(ctx, username, component)
+			if err != nil {
+				return nil, err
+			}
+			// Some controllers wrap the user in an object keyed by <username>_<COMPONENT>.
+			if inner, ok := m[username+"_"+strings.ToUpper(component)].(map[string]interface{}); ok {
+				return inner, nil
+			}
+			return m, nil
+		},
+		// The controller stores a hash of the password.
+		"password",
+	)
 }
 
 // GetUser requires component in query; returns either a wrapper map keyed by usernameWithComponent,
@@ -210,19 +1819,38 @@ func (c *PinotClient) GetUser(ctx context.Context, username, component string) (
 	// send both for compatibility across controller versions
 	v.Set("component", strings.ToUpper(component))
 	v.Set("componentType", strings.ToUpper(component))
-	endpoint := fmt.Sprintf("%s/users/%s?%s", c.controllerURL, url.PathEscape(username), v.Encode())
+	endpoint := fmt.Sprintf("%s/users/%s?%s", c.baseURL(), url.PathEscape(username), v.Encode())
 
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	var m map[string]interface{}
-	if err := json.Unmarshal(resp, &m); err != nil {
+	if err := decodeJSON(resp, &m); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal user JSON: %w", err)
 	}
 	return m, nil
 }
 
+// ListUsers returns every user of every component, keyed by <username>_<COMPONENT>. Passwords are returned as
+// the controller stores them (hashed).
+func (c *PinotClient) ListUsers(ctx context.Context) (map[string]map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/users", c.baseURL()), nil)
+	if err != nil {
+		return nil, err
+	}
+	var users struct {
+		Users map[string]map[string]interface{} `json:"users"`
+	}
+	if err := decodeJSON(resp, &users); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal users: %w", err)
+	}
+	if users.Users == nil {
+		users.Users = map[string]map[string]interface{}{}
+	}
+	return users.Users, nil
+}
+
 func (c *PinotClient) UpdateUser(ctx context.Context, user interface{}) error {
 	jsonBytes, err := json.Marshal(user)
 	if err != nil {
@@ -247,7 +1875,7 @@ func (c *PinotClient) UpdateUser(ctx context.Context, user interface{}) error {
 	v.Set("componentType", strings.ToUpper(component))
 
 	endpoint := fmt.Sprintf("%s/users/%s?%s",
-		c.controllerURL,
+		c.baseURL(),
 		url.PathEscape(username),
 		v.Encode(),
 	)
@@ -267,10 +1895,629 @@ func (c *PinotClient) DeleteUserWithComponent(ctx context.Context, username, com
 	v.Set("component", strings.ToUpper(component))
 	v.Set("componentType", strings.ToUpper(component))
 	endpoint := fmt.Sprintf("%s/users/%s?%s",
-		c.controllerURL,
+		c.baseURL(),
 		url.PathEscape(username),
 		v.Encode(),
 	)
 	_, err := c.doRequest(ctx, "DELETE", endpoint, nil)
 	return err
 }
+
+// Instance operations.
+
+// GetInstanceState returns whether the instance is currently enabled in the cluster.
+func (c *PinotClient) GetInstanceState(ctx context.Context, instanceName string) (bool, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/instances/%s", c.baseURL(), url.PathEscape(instanceName)), nil)
+	if err != nil {
+		return false, err
+	}
+
+	var instance map[string]interface{}
+	if err := decodeJSON(resp, &instance); err != nil {
+		return false, fmt.Errorf("failed to unmarshal instance: %w", err)
+	}
+
+	enabled, ok := instance["enabled"].(bool)
+	if !ok {
+		return false, fmt.Errorf("instance %q response has no enabled flag", instanceName)
+	}
+	return enabled, nil
+}
+
+// SetInstanceAdminState enables or disables an instance, e.g. to drain a server before a restart.
+func (c *PinotClient) SetInstanceAdminState(ctx context.Context, instanceName string, enabled bool) error {
+	state := "DISABLE"
+	if enabled {
+		state = "ENABLE"
+	}
+	endpoint := fmt.Sprintf("%s/instances/%s/state?state=%s",
+		c.baseURL(),
+		url.PathEscape(instanceName),
+		state,
+	)
+	_, err := c.doRequest(ctx, "PUT", endpoint, nil)
+	return err
+}
+
+// Cluster operations.
+
+// GetVersion returns the Pinot version the controller runs (GET /version), e.g. "1.2.0". The controller reports
+// the version of each of its components; the pinot-controller one is preferred.
+func (c *PinotClient) GetVersion(ctx context.Context) (string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/version", c.baseURL()), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var versions map[string]string
+	if err := decodeJSON(resp, &versions); err != nil {
+		return "", fmt.Errorf("failed to unmarshal version: %w", err)
+	}
+	if v := versions["pinot-controller"]; v != "" {
+		return v, nil
+	}
+	components := make([]string, 0, len(versions))
+	for component, v := range versions {
+		if v != "" {
+			components = append(components, component)
+		}
+	}
+	if len(components) == 0 {
+		return "", fmt.Errorf("the controller did not report a version")
+	}
+	sort.Strings(components)
+	return versions[components[0]], nil
+}
+
+// WithControllerVersion returns a client that records version as the controller's Pinot version, so features
+// the controller lacks can be reported before they are used. Clients for another controller forget it.
+func (c *PinotClient) WithControllerVersion(version string) *PinotClient {
+	clone := *c
+	clone.version = strings.TrimSpace(version)
+	return &clone
+}
+
+// ControllerVersion returns the controller version recorded with WithControllerVersion, or "" when unknown.
+func (c *PinotClient) ControllerVersion() string {
+	return c.version
+}
+
+// Minimum Pinot versions of features that are checked when the controller version is known.
+const (
+	MinVersionPauseConsumption = "0.11.0"
+)
+
+// SupportsPauseConsumption reports whether the controller can pause and resume stream consumption. It is true
+// when the controller version is unknown.
+func (c *PinotClient) SupportsPauseConsumption() bool {
+	return c.versionAtLeast(MinVersionPauseConsumption)
+}
+
+// versionAtLeast reports whether the recorded controller version is min or later, comparing the numeric
+// major.minor.patch prefix (so "1.2.0-SNAPSHOT" counts as 1.2.0). An unknown or unparsable version counts as new
+// enough, leaving the controller to reject what it does not support.
+func (c *PinotClient) versionAtLeast(min string) bool {
+	have, ok := parseVersion(c.version)
+	if !ok {
+		return true
+	}
+	want, _ := parseVersion(min)
+	return slices.Compare(have[:], want[:]) >= 0
+}
+
+// parseVersion parses the leading major.minor.patch numbers of a version; missing parts are 0 and further
+// parts are ignored.
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return out, false
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// GetClusterConfigs returns the cluster-wide configs stored in ZooKeeper, with every value as a string.
+func (c *PinotClient) GetClusterConfigs(ctx context.Context) (map[string]string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/cluster/configs", c.baseURL()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := decodeJSON(resp, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cluster configs: %w", err)
+	}
+	configs := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			configs[k] = s
+		} else {
+			configs[k] = fmt.Sprint(v)
+		}
+	}
+	return configs, nil
+}
+
+// UpdateClusterConfigs sets the given cluster configs, leaving all others untouched.
+func (c *PinotClient) UpdateClusterConfigs(ctx context.Context, configs map[string]string) error {
+	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/cluster/configs", c.baseURL()), configs)
+	return err
+}
+
+// DeleteClusterConfig removes a single cluster config.
+func (c *PinotClient) DeleteClusterConfig(ctx context.Context, name string) error {
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/cluster/configs/%s", c.baseURL(), url.PathEscape(name)), nil)
+	return err
+}
+
+// GetAppConfigs returns the controller's application configs (JVM, runtime, system and Pinot configs).
+func (c *PinotClient) GetAppConfigs(ctx context.Context) (map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/appconfigs", c.baseURL()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs map[string]interface{}
+	if err := decodeJSON(resp, &configs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal app configs: %w", err)
+	}
+	return configs, nil
+}
+
+// Storage operations.
+
+// TableSize is the storage a table's segments take on the servers, as reported by GET /tables/{name}/size.
+// Sizes count every replica.
+type TableSize struct {
+	// ReportedBytes sums the sizes the servers reported; EstimatedBytes extrapolates it to segments whose
+	// servers did not answer.
+	ReportedBytes  int64
+	EstimatedBytes int64
+	// Tables holds the reported size per table as <logical>_<TYPE>, and Servers per server instance.
+	Tables  map[string]int64
+	Servers map[string]int64
+}
+
+// GetTableSize returns the storage of the OFFLINE and REALTIME tables named logicalName.
+func (c *PinotClient) GetTableSize(ctx context.Context, logicalName string) (*TableSize, error) {
+	endpoint := fmt.Sprintf("%s/tables/%s/size?detailed=true", c.baseURL(), url.PathEscape(logicalName))
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	type segmentSizes struct {
+		ReportedSizeInBytes  int64 `json:"reportedSizeInBytes"`
+		EstimatedSizeInBytes int64 `json:"estimatedSizeInBytes"`
+		Segments             map[string]struct {
+			ServerInfo map[string]struct {
+				DiskSizeInBytes int64 `json:"diskSizeInBytes"`
+			} `json:"serverInfo"`
+		} `json:"segments"`
+	}
+	var result struct {
+		OfflineSegments  *segmentSizes `json:"offlineSegments"`
+		RealtimeSegments *segmentSizes `json:"realtimeSegments"`
+	}
+	if err := decodeJSON(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal table size: %w", err)
+	}
+
+	size := &TableSize{Tables: map[string]int64{}, Servers: map[string]int64{}}
+	for typ, sizes := range map[string]*segmentSizes{"OFFLINE": result.OfflineSegments, "REALTIME": result.RealtimeSegments} {
+		if sizes == nil {
+			continue
+		}
+		size.ReportedBytes += sizes.ReportedSizeInBytes
+		size.EstimatedBytes += sizes.EstimatedSizeInBytes
+		size.Tables[logicalName+"_"+typ] = sizes.ReportedSizeInBytes
+		for _, segment := range sizes.Segments {
+			for server, info := range segment.ServerInfo {
+				// Servers that did not answer report -1.
+				if info.DiskSizeInBytes > 0 {
+					size.Servers[server] += info.DiskSizeInBytes
+				}
+			}
+		}
+	}
+	return size, nil
+}
+
+// GetClusterStorage adds up GetTableSize over every table of the cluster, one request per table. The controller
+// does not report the disk capacity of the servers, only what the segments take.
+func (c *PinotClient) GetClusterStorage(ctx context.Context) (*TableSize, error) {
+	names, err := c.ListTables(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not list tables: %w", err)
+	}
+	logicalNames := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSuffix(strings.TrimSuffix(name, "_OFFLINE"), "_REALTIME")
+		logicalNames[name] = true
+	}
+
+	total := &TableSize{Tables: map[string]int64{}, Servers: map[string]int64{}}
+	for name := range logicalNames {
+		size, err := c.GetTableSize(ctx, name)
+		if IsNotFound(err) {
+			// Deleted since it was listed.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read the size of table %s: %w", name, err)
+		}
+		total.ReportedBytes += size.ReportedBytes
+		total.EstimatedBytes += size.EstimatedBytes
+		for table, n := range size.Tables {
+			total.Tables[table] = n
+		}
+		for server, n := range size.Servers {
+			total.Servers[server] += n
+		}
+	}
+	return total, nil
+}
+
+// Tenant operations.
+
+// ListTenants returns the names of the server and broker tenants known to the controller.
+func (c *PinotClient) ListTenants(ctx context.Context) (serverTenants, brokerTenants []string, err error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tenants", c.baseURL()), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tenants struct {
+		ServerTenants []string `json:"SERVER_TENANTS"`
+		BrokerTenants []string `json:"BROKER_TENANTS"`
+	}
+	if err := decodeJSON(resp, &tenants); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal tenants: %w", err)
+	}
+	return tenants.ServerTenants, tenants.BrokerTenants, nil
+}
+
+// Tenant is a server or broker tenant as sent to POST and PUT /tenants. Server tenants set OfflineInstances and
+// RealtimeInstances, broker tenants NumberOfInstances.
+type Tenant struct {
+	TenantName        string `json:"tenantName"`
+	TenantRole        string `json:"tenantRole"`
+	NumberOfInstances int    `json:"numberOfInstances,omitempty"`
+	OfflineInstances  int    `json:"offlineInstances,omitempty"`
+	RealtimeInstances int    `json:"realtimeInstances,omitempty"`
+}
+
+// CreateTenant tags untagged instances for a new tenant.
+func (c *PinotClient) CreateTenant(ctx context.Context, tenant Tenant) error {
+	_, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tenants", c.baseURL()), tenant)
+	return err
+}
+
+// UpdateTenant changes the number of instances tagged for a tenant.
+func (c *PinotClient) UpdateTenant(ctx context.Context, tenant Tenant) error {
+	_, err := c.doRequest(ctx, "PUT", fmt.Sprintf("%s/tenants", c.baseURL()), tenant)
+	return err
+}
+
+// GetTenant returns the instances of the tenant with the given role (SERVER or BROKER), sorted. The controller
+// answers 404 when no instance carries the tenant's tags.
+func (c *PinotClient) GetTenant(ctx context.Context, name, role string) ([]string, error) {
+	q := url.Values{"type": {strings.ToLower(role)}}
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tenants/%s?%s", c.baseURL(), url.PathEscape(name), q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances struct {
+		ServerInstances []string `json:"ServerInstances"`
+		BrokerInstances []string `json:"BrokerInstances"`
+	}
+	if err := decodeJSON(resp, &instances); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tenant instances: %w", err)
+	}
+	out := instances.ServerInstances
+	if strings.EqualFold(role, "BROKER") {
+		out = instances.BrokerInstances
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// DeleteTenant untags the instances of the tenant with the given role (SERVER or BROKER).
+func (c *PinotClient) DeleteTenant(ctx context.Context, name, role string) error {
+	q := url.Values{"type": {strings.ToUpper(role)}}
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/tenants/%s?%s", c.baseURL(), url.PathEscape(name), q.Encode()), nil)
+	return err
+}
+
+// GetTenantServers returns the servers tagged for tableType ("OFFLINE" or "REALTIME") in the given server tenant.
+func (c *PinotClient) GetTenantServers(ctx context.Context, tenant, tableType string) ([]string, error) {
+	q := url.Values{"type": {"server"}, "tableType": {strings.ToUpper(tableType)}}
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tenants/%s?%s", c.baseURL(), url.PathEscape(tenant), q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances struct {
+		ServerInstances []string `json:"ServerInstances"`
+	}
+	if err := decodeJSON(resp, &instances); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tenant instances: %w", err)
+	}
+	return instances.ServerInstances, nil
+}
+
+// CountTenantServers returns how many servers of a server tenant are tagged for OFFLINE and for REALTIME tables,
+// which can fall short of what was requested when the cluster had too few untagged servers.
+func (c *PinotClient) CountTenantServers(ctx context.Context, tenant string) (offline, realtime int, err error) {
+	offlineServers, err := c.GetTenantServers(ctx, tenant, "OFFLINE")
+	if err != nil {
+		return 0, 0, err
+	}
+	realtimeServers, err := c.GetTenantServers(ctx, tenant, "REALTIME")
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(offlineServers), len(realtimeServers), nil
+}
+
+// TableTask is a minion task type configured on a table, with its most recent run.
+type TableTask struct {
+	TaskType string
+	Config   map[string]string
+	// LastRun and LastRunState are empty when the task type has not run for the table yet.
+	LastRun      string
+	LastRunState string
+}
+
+// GetTaskStates returns the state (e.g. COMPLETED, IN_PROGRESS, FAILED) of every run of taskType for the table,
+// keyed by task name. tableName must carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) GetTaskStates(ctx context.Context, taskType, tableName string) (map[string]string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tasks/%s/%s/state", c.baseURL(), url.PathEscape(taskType), url.PathEscape(tableName)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var states map[string]string
+	if err := decodeJSON(resp, &states); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task states: %w", err)
+	}
+	return states, nil
+}
+
+// GetTableTasks returns the task types in the table's task.taskTypeConfigsMap, sorted by type, each with its
+// most recent run. tableName must carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) GetTableTasks(ctx context.Context, tableName string) ([]TableTask, error) {
+	config, err := c.GetTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	taskConfig, _ := config["task"].(map[string]interface{})
+	configs, _ := taskConfig["taskTypeConfigsMap"].(map[string]interface{})
+
+	tasks := make([]TableTask, 0, len(configs))
+	for taskType, raw := range configs {
+		task := TableTask{TaskType: taskType, Config: map[string]string{}}
+		if m, ok := raw.(map[string]interface{}); ok {
+			for k, v := range m {
+				task.Config[k] = fmt.Sprint(v)
+			}
+		}
+
+		states, err := c.GetTaskStates(ctx, taskType, tableName)
+		if err != nil && !IsNotFound(err) {
+			return nil, fmt.Errorf("failed to read %s runs: %w", taskType, err)
+		}
+		if name := latestTaskName(states); name != "" {
+			task.LastRun, task.LastRunState = name, states[name]
+		}
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].TaskType < tasks[j].TaskType })
+	return tasks, nil
+}
+
+// ScheduleTask has the controller generate a run of taskType for the table right away (POST /tasks/schedule)
+// instead of waiting for its cron schedule, and returns the names of the scheduled tasks. It returns none when
+// the task generator found nothing to do. tableName must carry the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) ScheduleTask(ctx context.Context, taskType, tableName string) ([]string, error) {
+	q := url.Values{"taskType": {taskType}, "tableName": {tableName}}
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("%s/tasks/schedule?%s", c.baseURL(), q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Task type to the comma-separated names of the tasks scheduled for it, or null when none were.
+	var scheduled map[string]*string
+	if err := decodeJSON(resp, &scheduled); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scheduled tasks: %w", err)
+	}
+	var names []string
+	if list := scheduled[taskType]; list != nil {
+		for _, name := range strings.Split(*list, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// GetSubtaskStates returns the state of every subtask of a task run (e.g. Task_MergeRollupTask_<id>_<millis>),
+// keyed by subtask name. Subtasks that have not started yet have an empty state.
+func (c *PinotClient) GetSubtaskStates(ctx context.Context, taskName string) (map[string]string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tasks/subtask/%s/state", c.baseURL(), url.PathEscape(taskName)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var states map[string]string
+	if err := decodeJSON(resp, &states); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subtask states: %w", err)
+	}
+	return states, nil
+}
+
+// subtaskFailedStates are the terminal subtask states other than COMPLETED.
+var subtaskFailedStates = map[string]bool{
+	"FAILED":       true,
+	"ERROR":        true,
+	"TASK_ERROR":   true,
+	"TASK_ABORTED": true,
+	"TIMED_OUT":    true,
+	"DROPPED":      true,
+}
+
+// WaitForTask polls the subtasks of taskName every interval until each has COMPLETED or failed. It returns an
+// error naming the failed subtasks, if any, or ctx's error once ctx is done; bound the wait with a deadline on ctx.
+func (c *PinotClient) WaitForTask(ctx context.Context, taskName string, interval time.Duration) error {
+	for {
+		states, err := c.GetSubtaskStates(ctx, taskName)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+
+		var failed []string
+		pending := 0
+		for name, state := range states {
+			switch {
+			case state == "COMPLETED":
+			case subtaskFailedStates[state]:
+				failed = append(failed, fmt.Sprintf("%s (%s)", name, state))
+			default:
+				pending++
+			}
+		}
+		// A task that has not generated its subtasks yet is still pending.
+		if len(states) > 0 && pending == 0 {
+			if len(failed) > 0 {
+				sort.Strings(failed)
+				return fmt.Errorf("task %s has %d failed subtasks: %s", taskName, len(failed), strings.Join(failed, ", "))
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("task %s still has %d of %d subtasks running: %w", taskName, pending, len(states), ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// latestTaskName returns the most recent task in states. Task names end in their creation time in epoch
+// milliseconds (Task_<type>_<id>_<millis>); names without one sort before those with one, then by name.
+func latestTaskName(states map[string]string) string {
+	var latest string
+	var latestMs int64 = -1
+	for name := range states {
+		var ms int64 = -1
+		if i := strings.LastIndex(name, "_"); i >= 0 {
+			if n, err := strconv.ParseInt(name[i+1:], 10, 64); err == nil {
+				ms = n
+			}
+		}
+		if ms > latestMs || (ms == latestMs && name > latest) {
+			latest, latestMs = name, ms
+		}
+	}
+	return latest
+}
+
+// ControllerJob is an asynchronous job (e.g. a reload or rebalance) the controller tracks for a table.
+type ControllerJob struct {
+	JobID            string
+	JobType          string
+	SubmissionTimeMs int64
+	// State is IN_PROGRESS or COMPLETED for reloads and the rebalance status (e.g. DONE, FAILED) for rebalances;
+	// empty when the controller does not report one.
+	State    string
+	Metadata map[string]string
+}
+
+// controllerJobReloadTypes are the job types whose progress is read from the segment reload status.
+var controllerJobReloadTypes = map[string]bool{"RELOAD_SEGMENT": true, "RELOAD_ALL_SEGMENTS": true}
+
+// GetControllerJobs returns the jobs the controller tracks for the table, newest first. tableName must carry
+// the _OFFLINE/_REALTIME suffix.
+func (c *PinotClient) GetControllerJobs(ctx context.Context, tableName string) ([]ControllerJob, error) {
+	logical, typ, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/tables/%s/controllerJobs?type=%s", c.baseURL(), url.PathEscape(logical), typ), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]map[string]interface{}
+	if err := decodeJSON(resp, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal controller jobs: %w", err)
+	}
+
+	jobs := make([]ControllerJob, 0, len(raw))
+	for id, fields := range raw {
+		job := ControllerJob{JobID: id, Metadata: map[string]string{}}
+		for k, v := range fields {
+			job.Metadata[k] = fmt.Sprint(v)
+		}
+		job.JobType = job.Metadata["jobType"]
+		job.SubmissionTimeMs, _ = strconv.ParseInt(job.Metadata["submissionTimeMs"], 10, 64)
+
+		switch {
+		case controllerJobReloadTypes[job.JobType]:
+			job.State, err = c.reloadJobState(ctx, id)
+			if err != nil && !IsNotFound(err) {
+				return nil, fmt.Errorf("failed to read the status of reload job %s: %w", id, err)
+			}
+		case job.Metadata["REBALANCE_PROGRESS_STATS"] != "":
+			var stats struct {
+				Status string `json:"status"`
+			}
+			if json.Unmarshal([]byte(job.Metadata["REBALANCE_PROGRESS_STATS"]), &stats) == nil {
+				job.State = stats.Status
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].SubmissionTimeMs != jobs[j].SubmissionTimeMs {
+			return jobs[i].SubmissionTimeMs > jobs[j].SubmissionTimeMs
+		}
+		return jobs[i].JobID < jobs[j].JobID
+	})
+	return jobs, nil
+}
+
+// reloadJobState reports whether every segment of a reload job has been reloaded.
+func (c *PinotClient) reloadJobState(ctx context.Context, jobID string) (string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("%s/segments/segmentReloadStatus/%s", c.baseURL(), url.PathEscape(jobID)), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var status struct {
+		TotalSegmentCount int64 `json:"totalSegmentCount"`
+		SuccessCount      int64 `json:"successCount"`
+	}
+	if err := decodeJSON(resp, &status); err != nil {
+		return "", fmt.Errorf("failed to unmarshal reload status: %w", err)
+	}
+	if status.SuccessCount >= status.TotalSegmentCount {
+		return "COMPLETED", nil
+	}
+	return "IN_PROGRESS", nil
+}
@@ -0,0 +1,1618 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		wantErr bool
+	}{
+		"plain":                   {body: `{"schemaName":"events"}`},
+		"trailing whitespace":     {body: "{\"schemaName\":\"events\"}\n\r\n  "},
+		"trailing status object":  {body: "{\"schemaName\":\"events\"}\n{\"status\":\"OK\"}\n"},
+		"several trailing values": {body: `{"schemaName":"events"} {"status":"OK"} "done"`},
+		"trailing garbage":        {body: `{"schemaName":"events"} <html>`, wantErr: true},
+		"invalid first value":     {body: `not json`, wantErr: true},
+		"empty":                   {body: ``, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		var v map[string]interface{}
+		err := decodeJSON([]byte(tc.body), &v)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", name, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if v["schemaName"] != "events" {
+			t.Errorf("%s: decoded %v", name, v)
+		}
+	}
+}
+
+func TestGetSchemaIgnoresTrailingStatusObject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{\"schemaName\":\"events\"}\n{\"status\":\"OK\"}\n"))
+	}))
+	defer srv.Close()
+
+	c, err := NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewPinotClient: %v", err)
+	}
+	schema, err := c.GetSchema(t.Context(), "events")
+	if err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if schema["schemaName"] != "events" {
+		t.Fatalf("unexpected schema: %v", schema)
+	}
+}
+
+func TestGetTableIndexes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tables/events/indexes" || r.URL.Query().Get("type") != "OFFLINE" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"totalOnlineSegments":2,"columnToIndexesCount":{` +
+			`"country":{"dictionary":2,"inverted_index":2,"range_index":0},` +
+			`"payload":{"dictionary":0}}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewPinotClient: %v", err)
+	}
+	got, err := c.GetTableIndexes(t.Context(), "events", "offline")
+	if err != nil {
+		t.Fatalf("GetTableIndexes: %v", err)
+	}
+	want := map[string][]string{"country": {"dictionary", "inverted_index"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetTableIndexes = %v, want %v", got, want)
+	}
+}
+
+func TestGetEffectiveSegmentAssignment(t *testing.T) {
+	stored := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tables/events/instancePartitions" || r.URL.RawQuery != "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if !stored {
+			http.Error(w, `{"code":404,"error":"Failed to find instance partitions for table: events"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{` +
+			`"OFFLINE":{"instancePartitionsName":"events_OFFLINE","partitionToInstancesMap":{"0_0":["s1"]}},` +
+			`"CONSUMING":{"instancePartitionsName":"events_CONSUMING","partitionToInstancesMap":` +
+			`{"0_0":["s1"],"0_1":["s2"],"1_0":["s3"],"1_1":["s4"]}}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewPinotClient: %v", err)
+	}
+	cases := []struct {
+		tableType string
+		stored    bool
+		want      map[string]string
+	}{
+		{tableType: "OFFLINE", stored: true, want: map[string]string{"OFFLINE": "Balanced (1 replica group, 1 partition)"}},
+		{tableType: "realtime", stored: true, want: map[string]string{
+			"CONSUMING": "ReplicaGroup (2 replica groups, 2 partitions)",
+			"COMPLETED": "Balanced (default)",
+		}},
+		{tableType: "OFFLINE", stored: false, want: map[string]string{"OFFLINE": "Balanced (default)"}},
+	}
+	for _, tc := range cases {
+		stored = tc.stored
+		got, err := c.GetEffectiveSegmentAssignment(t.Context(), "events", tc.tableType)
+		if err != nil {
+			t.Fatalf("GetEffectiveSegmentAssignment(%s): %v", tc.tableType, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("GetEffectiveSegmentAssignment(%s, stored=%v) = %v, want %v", tc.tableType, tc.stored, got, tc.want)
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"error key":        {body: `{"code":400,"error":"Invalid table config"}`, want: "Invalid table config"},
+		"_error key":       {body: `{"_code":404,"_error":"Table not found"}`, want: "Table not found"},
+		"message key":      {body: `{"status":409,"message":"Schema already exists"}`, want: "Schema already exists"},
+		"error before msg": {body: `{"error":"first","message":"second"}`, want: "first"},
+		"empty error":      {body: `{"error":"","message":"fallback"}`, want: "fallback"},
+		"no known key":     {body: `{"status":"FAILED"}`, want: `{"status":"FAILED"}`},
+		"not JSON":         {body: "<html>Bad Gateway</html>", want: "<html>Bad Gateway</html>"},
+	}
+
+	for name, tc := range cases {
+		if got := apiErrorMessage([]byte(tc.body)); got != tc.want {
+			t.Errorf("%s: got %q, want %q", name, got, tc.want)
+		}
+	}
+}
+
+func TestWithControllerURL(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"schemaName":"events"}`))
+	}))
+	defer srv.Close()
+
+	base, err := NewPinotClientWithToken("http://unused.invalid", "", "", "Bearer tok", DefaultClientConfig())
+	if err != nil {
+		t.Fatalf("NewPinotClientWithToken: %v", err)
+	}
+	if _, err := base.WithControllerURL(srv.URL+"/").GetSchema(t.Context(), "events"); err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if gotAuth != "Bearer tok" {
+		t.Errorf("Authorization = %q, want the base client's token", gotAuth)
+	}
+	if base.controllerURL != "http://unused.invalid" {
+		t.Errorf("base client was modified: %s", base.controllerURL)
+	}
+}
+
+func TestWithAPIPathPrefix(t *testing.T) {
+	for _, prefix := range []string{"pinot", "/pinot", "/pinot/", " pinot/ "} {
+		var gotPath string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			_, _ = w.Write([]byte(`{"schemaName":"events"}`))
+		}))
+
+		c, _ := NewPinotClient(srv.URL+"/", "", "")
+		c = c.WithAPIPathPrefix(prefix).WithControllerURL(srv.URL)
+		_, err := c.GetSchema(t.Context(), "events")
+		srv.Close()
+		if err != nil {
+			t.Fatalf("prefix %q: GetSchema: %v", prefix, err)
+		}
+		if gotPath != "/pinot/schemas/events" {
+			t.Errorf("prefix %q: path = %q, want /pinot/schemas/events", prefix, gotPath)
+		}
+	}
+
+	if got := NormalizePathPrefix("/"); got != "" {
+		t.Errorf("NormalizePathPrefix(\"/\") = %q, want empty", got)
+	}
+}
+
+func TestGetSegmentsByState(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"OFFLINE":null,"REALTIME":{` +
+			`"events__0__1":{"Server_a":"ONLINE","Server_b":"ERROR"},` +
+			`"events__0__0":{"Server_a":"ONLINE","Server_b":"ONLINE"},` +
+			`"events__0__2":{"Server_a":"CONSUMING"}}}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewPinotClient: %v", err)
+	}
+
+	cases := map[string][]string{
+		"ERROR":     {"events__0__1"},
+		"ONLINE":    {"events__0__0", "events__0__1"},
+		"CONSUMING": {"events__0__2"},
+		"OFFLINE":   nil,
+		"":          {"events__0__0", "events__0__1", "events__0__2"},
+	}
+	for state, want := range cases {
+		got, err := c.GetSegmentsByState(t.Context(), "events_REALTIME", state)
+		if err != nil {
+			t.Fatalf("GetSegmentsByState(%q): %v", state, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetSegmentsByState(%q) = %v, want %v", state, got, want)
+		}
+	}
+}
+
+func TestUpdateSchemaRetriesConflict(t *testing.T) {
+	defer func(attempts int, wait time.Duration) {
+		conflictRetryAttempts, conflictRetryWait = attempts, wait
+	}(conflictRetryAttempts, conflictRetryWait)
+	conflictRetryAttempts, conflictRetryWait = 3, time.Millisecond
+
+	for name, tc := range map[string]struct {
+		conflicts int
+		wantErr   bool
+		wantCalls int
+	}{
+		"succeeds after conflicts": {conflicts: 2, wantCalls: 3},
+		"gives up after attempts":  {conflicts: 5, wantErr: true, wantCalls: 3},
+	} {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= tc.conflicts {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":"reload in progress"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"OK"}`))
+		}))
+
+		c, _ := NewPinotClient(srv.URL, "", "")
+		err := c.UpdateSchema(t.Context(), map[string]interface{}{"schemaName": "events"})
+		srv.Close()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", name, err, tc.wantErr)
+		}
+		if calls != tc.wantCalls {
+			t.Errorf("%s: calls = %d, want %d", name, calls, tc.wantCalls)
+		}
+	}
+}
+
+func TestCreateTableRetriesMissingSchema(t *testing.T) {
+	defer func(attempts int, wait time.Duration) {
+		schemaRetryAttempts, schemaRetryWait = attempts, wait
+	}(schemaRetryAttempts, schemaRetryWait)
+	schemaRetryAttempts, schemaRetryWait = 3, time.Millisecond
+
+	for name, tc := range map[string]struct {
+		failures  int
+		message   string
+		wantErr   bool
+		wantCalls int
+	}{
+		"succeeds once the schema is visible": {failures: 2, message: "Invalid table config: Schema: events does not exist", wantCalls: 3},
+		"gives up after attempts":             {failures: 5, message: "Invalid table config: Schema: events does not exist", wantErr: true, wantCalls: 3},
+		"other bad requests are not retried":  {failures: 5, message: "Invalid table config: replication must be positive", wantErr: true, wantCalls: 1},
+	} {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls <= tc.failures {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprintf(w, `{"code":400,"error":%q}`, tc.message)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"Table events_OFFLINE successfully added"}`))
+		}))
+
+		c, _ := NewPinotClient(srv.URL, "", "")
+		err := c.CreateTable(t.Context(), map[string]interface{}{"tableName": "events_OFFLINE", "tableType": "OFFLINE"})
+		srv.Close()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", name, err, tc.wantErr)
+		}
+		if calls != tc.wantCalls {
+			t.Errorf("%s: calls = %d, want %d", name, calls, tc.wantCalls)
+		}
+	}
+}
+
+func TestAPIErrorStatusCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"Schema events not found"}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	_, err := c.GetSchema(t.Context(), "events")
+	if !IsNotFound(err) {
+		t.Fatalf("IsNotFound(%v) = false", err)
+	}
+	if got := err.Error(); got != "API error (status 404): Schema events not found" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if code := StatusCode(fmt.Errorf("wrapped: %w", err)); code != http.StatusNotFound {
+		t.Errorf("StatusCode of wrapped error = %d", code)
+	}
+	if code := StatusCode(errors.New("request failed")); code != 0 {
+		t.Errorf("StatusCode of non-API error = %d", code)
+	}
+}
+
+func TestDeleteSegmentsByTimeRange(t *testing.T) {
+	var deleted []string
+	selected := `[{"OFFLINE":["events_2","events_1"]}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/segments/events/select":
+			if q := r.URL.Query(); q.Get("type") != "OFFLINE" || q.Get("startTimestamp") != "1000" ||
+				q.Get("endTimestamp") != "2000" || q.Get("excludeOverlapping") != "true" {
+				t.Errorf("unexpected select query %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(selected))
+		case r.URL.Path == "/tables/events_OFFLINE/externalview":
+			_, _ = w.Write([]byte(`{"OFFLINE":{"events_1":{"s1":"ONLINE"},"events_2":{"s1":"ONLINE"},"events_3":{"s1":"ONLINE"}}}`))
+		case r.URL.Path == "/segments/events/delete" && r.Method == http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&deleted); err != nil {
+				t.Errorf("decode delete body: %v", err)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	got, err := c.DeleteSegmentsByTimeRange(t.Context(), "events_OFFLINE", 1000, 2000)
+	if err != nil {
+		t.Fatalf("DeleteSegmentsByTimeRange: %v", err)
+	}
+	want := []string{"events_1", "events_2"}
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got %v, deleted %v, want %v", got, deleted, want)
+	}
+
+	// A range covering every segment must not delete anything.
+	deleted = nil
+	selected = `[{"OFFLINE":["events_1","events_2","events_3"]}]`
+	if _, err := c.DeleteSegmentsByTimeRange(t.Context(), "events_OFFLINE", 1000, 2000); err == nil {
+		t.Error("expected an error when the range matches every segment")
+	}
+	if deleted != nil {
+		t.Errorf("segments deleted despite the guard: %v", deleted)
+	}
+
+	if _, err := c.DeleteSegmentsByTimeRange(t.Context(), "events_OFFLINE", 2000, 1000); err == nil {
+		t.Error("expected an error for an empty range")
+	}
+}
+
+func TestGetAllSegmentMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/segments/events_OFFLINE/metadata" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query()["columns"]; !reflect.DeepEqual(got, []string{"userId", "ts"}) {
+			t.Errorf("columns = %v", got)
+		}
+		_, _ = w.Write([]byte(`{"events_1":{"segmentName":"events_1","totalDocs":10},"events_2":{"segmentName":"events_2","totalDocs":20}}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	got, err := c.GetAllSegmentMetadata(t.Context(), "events_OFFLINE", []string{"userId", "ts"})
+	if err != nil {
+		t.Fatalf("GetAllSegmentMetadata: %v", err)
+	}
+	if len(got) != 2 || got["events_2"]["totalDocs"] != float64(20) {
+		t.Errorf("unexpected metadata %v", got)
+	}
+}
+
+// segmentMetadataServer serves both the bulk and the per-segment metadata endpoints for n segments.
+func segmentMetadataServer(n int) *httptest.Server {
+	all := make(map[string]map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("events_%d", i)
+		all[name] = map[string]interface{}{"segmentName": name, "totalDocs": i}
+	}
+	bulk, _ := json.Marshal(all)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/segments/events_OFFLINE/metadata" {
+			_, _ = w.Write(bulk)
+			return
+		}
+		// /segments/events_OFFLINE/<segment>/metadata
+		parts := strings.Split(r.URL.Path, "/")
+		one, _ := json.Marshal(all[parts[3]])
+		_, _ = w.Write(one)
+	}))
+}
+
+const benchmarkSegments = 200
+
+func BenchmarkSegmentMetadataBulk(b *testing.B) {
+	srv := segmentMetadataServer(benchmarkSegments)
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+
+	for b.Loop() {
+		if _, err := c.GetAllSegmentMetadata(b.Context(), "events_OFFLINE", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSegmentMetadataPerSegment(b *testing.B) {
+	srv := segmentMetadataServer(benchmarkSegments)
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+
+	for b.Loop() {
+		for i := 0; i < benchmarkSegments; i++ {
+			if _, err := c.GetSegmentMetadata(b.Context(), "events_OFFLINE", fmt.Sprintf("events_%d", i)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestReplicationStatus(t *testing.T) {
+	ideal := SegmentAssignment{
+		"OFFLINE": {
+			"events_1": {"s1": "ONLINE", "s2": "ONLINE"},
+			"events_2": {"s1": "ONLINE", "s2": "ONLINE"},
+			"events_3": {"s1": "ONLINE", "s2": "ONLINE"},
+			"events_4": {"s1": "OFFLINE"},
+		},
+		"REALTIME": {
+			"events__0__1": {"s1": "CONSUMING"},
+		},
+	}
+	external := SegmentAssignment{
+		"OFFLINE": {
+			"events_1": {"s1": "ONLINE", "s2": "ONLINE"},
+			"events_2": {"s1": "ONLINE", "s2": "ERROR"},
+			"events_4": {"s1": "OFFLINE"},
+		},
+		"REALTIME": {
+			"events__0__1": {"s1": "CONSUMING"},
+		},
+	}
+
+	got := replicationStatus(ideal, external)
+	if got.TotalSegments != 5 {
+		t.Errorf("TotalSegments = %d, want 5", got.TotalSegments)
+	}
+	if want := []string{"events_2", "events_3"}; !reflect.DeepEqual(got.UnderReplicated, want) {
+		t.Errorf("UnderReplicated = %v, want %v", got.UnderReplicated, want)
+	}
+}
+
+func TestCreateTableIdempotent(t *testing.T) {
+	cases := map[string]struct {
+		postStatus int
+		existing   string // empty: GET returns 404
+		wantErr    bool
+		wantPosts  int
+	}{
+		"lost response, table was created": {
+			postStatus: http.StatusGatewayTimeout,
+			existing:   `{"OFFLINE":{"tableName":"events_OFFLINE","tableType":"OFFLINE","segmentsConfig":{"replication":"1","schemaName":"events"}}}`,
+			wantPosts:  1,
+		},
+		"lost response, nothing created": {
+			postStatus: http.StatusGatewayTimeout,
+			wantErr:    true,
+			wantPosts:  2,
+		},
+		"conflict with matching table": {
+			postStatus: http.StatusConflict,
+			existing:   `{"OFFLINE":{"tableName":"events_OFFLINE","tableType":"OFFLINE","segmentsConfig":{"replication":1}}}`,
+			wantPosts:  1,
+		},
+		"conflict with different table": {
+			postStatus: http.StatusConflict,
+			existing:   `{"OFFLINE":{"tableName":"events_OFFLINE","tableType":"OFFLINE","segmentsConfig":{"replication":"3"}}}`,
+			wantErr:    true,
+			wantPosts:  1,
+		},
+		"validation error is not retried": {
+			postStatus: http.StatusBadRequest,
+			wantErr:    true,
+			wantPosts:  1,
+		},
+	}
+
+	for name, tc := range cases {
+		posts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				posts++
+				w.WriteHeader(tc.postStatus)
+				return
+			}
+			if tc.existing == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(tc.existing))
+		}))
+
+		c, _ := NewPinotClient(srv.URL, "", "")
+		err := c.CreateTable(t.Context(), map[string]interface{}{
+			"tableName":      "events_OFFLINE",
+			"tableType":      "OFFLINE",
+			"segmentsConfig": map[string]interface{}{"replication": "1"},
+		})
+		srv.Close()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", name, err, tc.wantErr)
+		}
+		if posts != tc.wantPosts {
+			t.Errorf("%s: posts = %d, want %d", name, posts, tc.wantPosts)
+		}
+	}
+}
+
+func TestUpdateTableIfMatch(t *testing.T) {
+	current := `"v2"`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", current)
+			_, _ = w.Write([]byte(`{"OFFLINE":{"tableName":"events_OFFLINE"}}`))
+		case http.MethodPut:
+			if m := r.Header.Get("If-Match"); m != "" && m != current {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			current = `"v3"`
+			w.Header().Set("ETag", current)
+			_, _ = w.Write([]byte(`{"status":"updated"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	_, version, err := c.GetTableWithVersion(t.Context(), "events_OFFLINE")
+	if err != nil || version != `"v2"` {
+		t.Fatalf("GetTableWithVersion = %q, %v; want \"v2\"", version, err)
+	}
+
+	config := map[string]interface{}{"tableName": "events_OFFLINE"}
+	if _, err := c.UpdateTableIfMatch(t.Context(), config, `"v1"`); !IsPreconditionFailed(err) {
+		t.Errorf("stale version: err = %v, want 412", err)
+	}
+	got, err := c.UpdateTableIfMatch(t.Context(), config, version)
+	if err != nil || got != `"v3"` {
+		t.Errorf("UpdateTableIfMatch = %q, %v; want \"v3\"", got, err)
+	}
+	if err := c.UpdateTable(t.Context(), config); err != nil {
+		t.Errorf("unconditional update: %v", err)
+	}
+}
+
+func TestWithTokenFile(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"schemaName":"events"}`))
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeToken := func(token string) {
+		t.Helper()
+		if err := os.WriteFile(tokenFile, []byte(token), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base, _ := NewPinotClient(srv.URL, "admin", "pw")
+
+	writeToken("Bearer first\n")
+	once, err := base.WithTokenFile(tokenFile, false)
+	if err != nil {
+		t.Fatalf("WithTokenFile: %v", err)
+	}
+	reloading, _ := base.WithTokenFile(tokenFile, true)
+
+	writeToken("  Bearer second  \n")
+	for c, want := range map[*PinotClient]string{once: "Bearer first", reloading: "Bearer second"} {
+		if _, err := c.GetSchema(t.Context(), "events"); err != nil {
+			t.Fatalf("GetSchema: %v", err)
+		}
+		if gotAuth != want {
+			t.Errorf("Authorization = %q, want %q", gotAuth, want)
+		}
+	}
+
+	// A rotation caught mid-write falls back to the token read at construction.
+	writeToken("")
+	if _, err := reloading.GetSchema(t.Context(), "events"); err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if gotAuth != "Bearer first" {
+		t.Errorf("Authorization = %q, want the initial token", gotAuth)
+	}
+
+	if _, err := base.WithTokenFile(filepath.Join(t.TempDir(), "missing"), false); err == nil {
+		t.Errorf("expected an error for a missing token file")
+	}
+}
+
+func TestWithReadWriteTokens(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"schemaName":"events"}`))
+	}))
+	defer srv.Close()
+	base, _ := NewPinotClient(srv.URL, "admin", "pw")
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:pw"))
+
+	for name, tc := range map[string]struct {
+		client              *PinotClient
+		wantRead, wantWrite string
+	}{
+		"both":       {client: base.WithReadWriteTokens("Bearer reader", "Bearer writer"), wantRead: "Bearer reader", wantWrite: "Bearer writer"},
+		"read only":  {client: base.WithReadWriteTokens("Bearer reader", ""), wantRead: "Bearer reader", wantWrite: basic},
+		"write only": {client: base.WithReadWriteTokens("", "Bearer writer"), wantRead: basic, wantWrite: "Bearer writer"},
+		"neither":    {client: base.WithReadWriteTokens("", ""), wantRead: basic, wantWrite: basic},
+	} {
+		if _, err := tc.client.GetSchema(t.Context(), "events"); err != nil {
+			t.Fatalf("%s: GetSchema: %v", name, err)
+		}
+		if gotAuth != tc.wantRead {
+			t.Errorf("%s: GET Authorization = %q, want %q", name, gotAuth, tc.wantRead)
+		}
+		if err := tc.client.DeleteSchema(t.Context(), "events"); err != nil {
+			t.Fatalf("%s: DeleteSchema: %v", name, err)
+		}
+		if gotAuth != tc.wantWrite {
+			t.Errorf("%s: DELETE Authorization = %q, want %q", name, gotAuth, tc.wantWrite)
+		}
+	}
+}
+
+func TestDeleteTableByType(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, fmt.Sprintf("%s %s auth=%q db=%q", r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"), r.Header.Get("Database")))
+		if len(requests) == 1 {
+			http.Error(w, `{"code":503,"error":"Controller busy"}`, http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"Tables: [events_OFFLINE] deleted"}`))
+	}))
+	defer srv.Close()
+	cfg := DefaultClientConfig()
+	cfg.Retry = RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, StatusCodes: []int{http.StatusServiceUnavailable}}
+	base, _ := NewPinotClientWithToken(srv.URL, "", "", "Bearer admin", cfg)
+	c := base.WithAPIPathPrefix("/pinot").WithReadWriteTokens("", "Bearer writer")
+	t.Setenv("PINOT_DATABASE", "analytics")
+
+	if err := c.DeleteTableByType(t.Context(), "events", "offline"); err != nil {
+		t.Fatalf("DeleteTableByType: %v", err)
+	}
+	want := `DELETE /pinot/tables/events?type=OFFLINE auth="Bearer writer" db="analytics"`
+	if len(requests) != 2 || requests[0] != want || requests[1] != want {
+		t.Errorf("requests = %q, want %q retried once", requests, want)
+	}
+}
+
+func TestWithProxyURL(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		_, _ = w.Write([]byte(`{"schemaName":"events"}`))
+	}))
+	defer proxy.Close()
+
+	base, _ := NewPinotClient("http://pinot-controller.invalid:9000", "", "")
+	c, err := base.WithProxyURL(proxy.URL)
+	if err != nil {
+		t.Fatalf("WithProxyURL: %v", err)
+	}
+	if _, err := c.GetSchema(t.Context(), "events"); err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if gotURL != "http://pinot-controller.invalid:9000/schemas/events" {
+		t.Errorf("proxy got %q, want the controller URL", gotURL)
+	}
+	if base.httpClient == c.httpClient {
+		t.Errorf("base client's HTTP client was shared")
+	}
+
+	for _, bad := range []string{"proxy.corp:3128", "ftp://proxy.corp", "://"} {
+		if _, err := base.WithProxyURL(bad); err == nil {
+			t.Errorf("WithProxyURL(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestGetTableTasks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tables/events_OFFLINE":
+			_, _ = w.Write([]byte(`{"OFFLINE":{"tableName":"events_OFFLINE","task":{"taskTypeConfigsMap":{` +
+				`"MergeRollupTask":{"1day.bucketTimePeriod":"1d"},"PurgeTask":{"schedule":"0 0 * * * ?"}}}}}`))
+		case "/tasks/MergeRollupTask/events_OFFLINE/state":
+			_, _ = w.Write([]byte(`{"Task_MergeRollupTask_a1_1700000000000":"COMPLETED","Task_MergeRollupTask_b2_1700000900000":"FAILED"}`))
+		case "/tasks/PurgeTask/events_OFFLINE/state":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	tasks, err := c.GetTableTasks(t.Context(), "events_OFFLINE")
+	if err != nil {
+		t.Fatalf("GetTableTasks: %v", err)
+	}
+	want := []TableTask{
+		{
+			TaskType:     "MergeRollupTask",
+			Config:       map[string]string{"1day.bucketTimePeriod": "1d"},
+			LastRun:      "Task_MergeRollupTask_b2_1700000900000",
+			LastRunState: "FAILED",
+		},
+		{TaskType: "PurgeTask", Config: map[string]string{"schedule": "0 0 * * * ?"}},
+	}
+	if !reflect.DeepEqual(tasks, want) {
+		t.Errorf("GetTableTasks = %+v, want %+v", tasks, want)
+	}
+}
+
+func TestDeleteAllSegments(t *testing.T) {
+	var batches [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tables/events_OFFLINE/idealstate":
+			_, _ = w.Write([]byte(`{"OFFLINE":{"s1":{"Server_a":"ONLINE"},"s2":{"Server_a":"ONLINE"},"s3":{"Server_a":"ONLINE"},"s4":{"Server_a":"ONLINE"},"s5":{"Server_a":"ONLINE"}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/segments/events/delete":
+			var batch []string
+			_ = json.NewDecoder(r.Body).Decode(&batch)
+			batches = append(batches, batch)
+			if batch[0] == "s3" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"deleted"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	deleted, err := c.DeleteAllSegments(t.Context(), "events_OFFLINE", 2)
+	if deleted != 3 {
+		t.Errorf("deleted = %d, want 3", deleted)
+	}
+	if err == nil || !strings.Contains(err.Error(), "s3 to s4") {
+		t.Errorf("err = %v, want the failed batch s3 to s4", err)
+	}
+	want := [][]string{{"s1", "s2"}, {"s3", "s4"}, {"s5"}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+}
+
+func TestGetControllerJobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tables/events/controllerJobs":
+			if got := r.URL.Query().Get("type"); got != "OFFLINE" {
+				t.Errorf("type = %q, want OFFLINE", got)
+			}
+			_, _ = w.Write([]byte(`{` +
+				`"r1":{"jobId":"r1","jobType":"RELOAD_ALL_SEGMENTS","submissionTimeMs":"1700000000000"},` +
+				`"r2":{"jobId":"r2","jobType":"RELOAD_ALL_SEGMENTS","submissionTimeMs":"1700000300000"},` +
+				`"b1":{"jobId":"b1","jobType":"TABLE_REBALANCE","submissionTimeMs":"1700000600000",` +
+				`"REBALANCE_PROGRESS_STATS":"{\"status\":\"IN_PROGRESS\"}"}}`))
+		case "/segments/segmentReloadStatus/r1":
+			_, _ = w.Write([]byte(`{"totalSegmentCount":4,"successCount":4}`))
+		case "/segments/segmentReloadStatus/r2":
+			_, _ = w.Write([]byte(`{"totalSegmentCount":4,"successCount":1}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	jobs, err := c.GetControllerJobs(t.Context(), "events_OFFLINE")
+	if err != nil {
+		t.Fatalf("GetControllerJobs: %v", err)
+	}
+	var got []string
+	for _, j := range jobs {
+		got = append(got, j.JobID+" "+j.JobType+" "+j.State)
+	}
+	want := []string{"b1 TABLE_REBALANCE IN_PROGRESS", "r2 RELOAD_ALL_SEGMENTS IN_PROGRESS", "r1 RELOAD_ALL_SEGMENTS COMPLETED"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("jobs = %v, want %v", got, want)
+	}
+	if jobs[0].SubmissionTimeMs != 1700000600000 {
+		t.Errorf("SubmissionTimeMs = %d, want 1700000600000", jobs[0].SubmissionTimeMs)
+	}
+
+	if _, err := c.GetControllerJobs(t.Context(), "events"); err == nil {
+		t.Error("GetControllerJobs without a table type suffix should fail")
+	}
+}
+
+func TestWithEnvHeaders(t *testing.T) {
+	t.Setenv("TRACEPARENT", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	t.Setenv("TRACESTATE", "")
+	t.Setenv("IF_MATCH", "from-env")
+
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	c = c.WithEnvHeaders([]string{"traceparent", " tracestate ", "If-Match", ""})
+	if _, err := c.UpdateTableIfMatch(t.Context(), map[string]interface{}{"tableName": "t_OFFLINE"}, `"v1"`); err != nil {
+		t.Fatalf("UpdateTableIfMatch: %v", err)
+	}
+	if v := got.Get("traceparent"); v != "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01" {
+		t.Errorf("traceparent = %q", v)
+	}
+	if _, ok := got["Tracestate"]; ok {
+		t.Error("tracestate should be skipped when TRACESTATE is empty")
+	}
+	if v := got.Values("If-Match"); len(v) != 1 || v[0] != `"v1"` {
+		t.Errorf("If-Match = %q, want the request's own version", v)
+	}
+
+	// Values are read at request time.
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if _, err := c.GetSchema(t.Context(), "s"); err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if v := got.Get("traceparent"); v != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("traceparent after change = %q", v)
+	}
+}
+
+func TestRebalanceTableWithOptions(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"jobId":"job-1","status":"IN_PROGRESS"}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	minAvailable := int64(-1)
+	result, err := c.RebalanceTableWithOptions(t.Context(), "events", "offline", RebalanceOptions{
+		LowDiskMode:          true,
+		BestEfforts:          true,
+		MinAvailableReplicas: &minAvailable,
+	})
+	if err != nil {
+		t.Fatalf("RebalanceTableWithOptions: %v", err)
+	}
+	if result["jobId"] != "job-1" {
+		t.Errorf("jobId = %v, want job-1", result["jobId"])
+	}
+	want := url.Values{
+		"type":                 {"OFFLINE"},
+		"reassignInstances":    {"false"},
+		"dryRun":               {"false"},
+		"lowDiskMode":          {"true"},
+		"bestEfforts":          {"true"},
+		"minAvailableReplicas": {"-1"},
+	}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("query = %v, want %v", query, want)
+	}
+
+	if _, err := c.RebalanceTable(t.Context(), "events", "OFFLINE", true); err != nil {
+		t.Fatalf("RebalanceTable: %v", err)
+	}
+	if query.Get("dryRun") != "true" || query.Has("bootstrap") || query.Has("minAvailableReplicas") {
+		t.Errorf("query = %v, want only the defaults with dryRun=true", query)
+	}
+}
+
+func TestGetTableTuningConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tableConfigs/events":
+			_, _ = w.Write([]byte(`{"tableName":"events","offline":{"tableName":"events_OFFLINE"},` +
+				`"realtime":{"tableName":"events_REALTIME","tunerConfigs":[{"name":"realtimeAutoIndexTuner","tunerProperties":{}}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	tuners, err := c.GetTableTuningConfig(t.Context(), "events", "REALTIME")
+	if err != nil {
+		t.Fatalf("GetTableTuningConfig: %v", err)
+	}
+	want := []interface{}{map[string]interface{}{"name": "realtimeAutoIndexTuner", "tunerProperties": map[string]interface{}{}}}
+	if !reflect.DeepEqual(tuners, want) {
+		t.Errorf("tuners = %v, want %v", tuners, want)
+	}
+
+	if tuners, err := c.GetTableTuningConfig(t.Context(), "events", "OFFLINE"); err != nil || tuners != nil {
+		t.Errorf("OFFLINE = %v, %v; want nil, nil", tuners, err)
+	}
+	if _, err := c.GetTableTuningConfig(t.Context(), "missing", "OFFLINE"); !IsNotFound(err) {
+		t.Errorf("missing table err = %v, want 404", err)
+	}
+}
+
+func TestScheduleTask(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tasks/schedule" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.Query()
+		if query.Get("tableName") == "idle_OFFLINE" {
+			_, _ = w.Write([]byte(`{"MergeRollupTask":null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"MergeRollupTask":"Task_MergeRollupTask_a_1700000000000,Task_MergeRollupTask_b_1700000000001"}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	names, err := c.ScheduleTask(t.Context(), "MergeRollupTask", "events_OFFLINE")
+	if err != nil {
+		t.Fatalf("ScheduleTask: %v", err)
+	}
+	if want := []string{"Task_MergeRollupTask_a_1700000000000", "Task_MergeRollupTask_b_1700000000001"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+	if query.Get("taskType") != "MergeRollupTask" || query.Get("tableName") != "events_OFFLINE" {
+		t.Errorf("query = %v", query)
+	}
+	if names, err := c.ScheduleTask(t.Context(), "MergeRollupTask", "idle_OFFLINE"); err != nil || len(names) != 0 {
+		t.Errorf("idle table: names = %v, err = %v; want none", names, err)
+	}
+}
+
+func TestWaitForTask(t *testing.T) {
+	polls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		task := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/subtask/"), "/state")
+		polls[task]++
+		switch {
+		case task == "Task_ok" && polls[task] == 1:
+			w.WriteHeader(http.StatusNotFound)
+		case task == "Task_ok" && polls[task] == 2:
+			_, _ = w.Write([]byte(`{"Task_ok_0":"RUNNING","Task_ok_1":null}`))
+		case task == "Task_ok":
+			_, _ = w.Write([]byte(`{"Task_ok_0":"COMPLETED","Task_ok_1":"COMPLETED"}`))
+		case task == "Task_bad":
+			_, _ = w.Write([]byte(`{"Task_bad_0":"COMPLETED","Task_bad_1":"TASK_ERROR","Task_bad_2":"TIMED_OUT"}`))
+		default:
+			_, _ = w.Write([]byte(`{"Task_slow_0":"RUNNING"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	if err := c.WaitForTask(t.Context(), "Task_ok", time.Millisecond); err != nil {
+		t.Errorf("Task_ok: %v", err)
+	}
+	if polls["Task_ok"] != 3 {
+		t.Errorf("Task_ok polled %d times, want 3", polls["Task_ok"])
+	}
+
+	err := c.WaitForTask(t.Context(), "Task_bad", time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "Task_bad_1 (TASK_ERROR), Task_bad_2 (TIMED_OUT)") {
+		t.Errorf("Task_bad err = %v, want the failed subtasks", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.WaitForTask(ctx, "Task_slow", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Task_slow err = %v, want a deadline error", err)
+	}
+}
+
+func TestListTablesOnTenant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tables" && r.URL.Query().Get("type") == "OFFLINE":
+			_, _ = w.Write([]byte(`{"tables":["b","a_OFFLINE","gone"]}`))
+		case r.URL.Path == "/tables" && r.URL.Query().Get("type") == "REALTIME":
+			_, _ = w.Write([]byte(`{"tables":["c_REALTIME"]}`))
+		case r.URL.Path == "/tables/a_OFFLINE":
+			_, _ = w.Write([]byte(`{"OFFLINE":{"tableName":"a_OFFLINE","tenants":{"broker":"hot","server":"hot"}}}`))
+		case r.URL.Path == "/tables/b_OFFLINE":
+			_, _ = w.Write([]byte(`{"OFFLINE":{"tableName":"b_OFFLINE"}}`))
+		case r.URL.Path == "/tables/c_REALTIME":
+			_, _ = w.Write([]byte(`{"REALTIME":{"tableName":"c_REALTIME","tenants":{"broker":"DefaultTenant","server":"hot"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	names, err := c.ListTableNames(t.Context(), "")
+	if err != nil {
+		t.Fatalf("ListTableNames: %v", err)
+	}
+	if want := []string{"a_OFFLINE", "b_OFFLINE", "c_REALTIME", "gone_OFFLINE"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListTableNames = %v, want %v", names, want)
+	}
+
+	cases := map[string]struct {
+		tableType, tenant string
+		want              []string
+	}{
+		"hot":             {"", "hot", []string{"a_OFFLINE", "c_REALTIME"}},
+		"hot offline":     {"OFFLINE", "hot", []string{"a_OFFLINE"}},
+		"default":         {"", "DefaultTenant", []string{"b_OFFLINE", "c_REALTIME"}},
+		"unknown tenant":  {"", "cold", []string{}},
+		"default offline": {"offline", "DefaultTenant", []string{"b_OFFLINE"}},
+	}
+	for name, tc := range cases {
+		got, err := c.ListTablesOnTenant(t.Context(), tc.tableType, tc.tenant)
+		if err != nil {
+			t.Errorf("%s: ListTablesOnTenant: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: ListTablesOnTenant = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestUploadSegmentFile(t *testing.T) {
+	dir := t.TempDir()
+	segment := filepath.Join(dir, "events_0.tar.gz")
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	content := []byte("segment metadata")
+	_ = tw.WriteHeader(&tar.Header{Name: "events_0/metadata.properties", Mode: 0o600, Size: int64(len(content))})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gz.Close()
+	if err := os.WriteFile(segment, archive.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	notTar := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(notTar, []byte("not a segment"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploaded []byte
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("FormFile: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if header.Filename != "events_0.tar.gz" {
+			t.Errorf("filename = %q", header.Filename)
+		}
+		uploaded, _ = io.ReadAll(file)
+		_, _ = w.Write([]byte(`{"status":"Successfully uploaded segment"}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	if err := c.UploadSegmentFile(t.Context(), "events_OFFLINE", segment, SegmentUploadOptions{}); err != nil {
+		t.Fatalf("UploadSegmentFile: %v", err)
+	}
+	if !bytes.Equal(uploaded, archive.Bytes()) {
+		t.Errorf("uploaded %d bytes, want the %d-byte segment", len(uploaded), archive.Len())
+	}
+	if query.Get("tableName") != "events" || query.Get("tableType") != "OFFLINE" {
+		t.Errorf("query = %v", query)
+	}
+
+	for name, path := range map[string]string{"not a tar": notTar, "missing": filepath.Join(dir, "missing.tar.gz"), "directory": dir} {
+		if err := c.UploadSegmentFile(t.Context(), "events_OFFLINE", path, SegmentUploadOptions{}); err == nil {
+			t.Errorf("%s: UploadSegmentFile should fail", name)
+		}
+	}
+}
+
+func TestUploadSegmentFileOptions(t *testing.T) {
+	dir := t.TempDir()
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	content := []byte("segment metadata")
+	_ = tw.WriteHeader(&tar.Header{Name: "events_0/metadata.properties", Mode: 0o600, Size: int64(len(content))})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	segment := filepath.Join(dir, "events_0.tar")
+	if err := os.WriteFile(segment, archive.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, _ = gz.Write(archive.Bytes())
+	_ = gz.Close()
+	gzSegment := filepath.Join(dir, "events_0.tar.gz")
+	if err := os.WriteFile(gzSegment, gzipped.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploaded []byte
+	var encoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		if encoding == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("gzip.NewReader: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			r.Body = zr
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("FormFile: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		uploaded, _ = io.ReadAll(file)
+		_, _ = w.Write([]byte(`{"status":"Successfully uploaded segment"}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	if err := c.UploadSegmentFile(t.Context(), "events_OFFLINE", segment, SegmentUploadOptions{Format: SegmentFormatTar, Gzip: true}); err != nil {
+		t.Fatalf("UploadSegmentFile: %v", err)
+	}
+	if encoding != "gzip" || !bytes.Equal(uploaded, archive.Bytes()) {
+		t.Errorf("Content-Encoding = %q, uploaded %d bytes; want a gzipped upload of the %d-byte tar", encoding, len(uploaded), archive.Len())
+	}
+
+	for name, tc := range map[string]struct {
+		path string
+		opts SegmentUploadOptions
+	}{
+		"tar as tar.gz":  {path: segment, opts: SegmentUploadOptions{Format: SegmentFormatTarGz}},
+		"tar.gz as tar":  {path: gzSegment, opts: SegmentUploadOptions{Format: SegmentFormatTar}},
+		"gzipped tar.gz": {path: gzSegment, opts: SegmentUploadOptions{Gzip: true}},
+	} {
+		if err := c.UploadSegmentFile(t.Context(), "events_OFFLINE", tc.path, tc.opts); err == nil {
+			t.Errorf("%s: UploadSegmentFile should fail", name)
+		}
+	}
+}
+
+func TestUploadSegmentURI(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = fmt.Sprintf("%s %s type=%s uri=%s", r.Method, r.URL.RequestURI(), r.Header.Get("UPLOAD_TYPE"), r.Header.Get("DOWNLOAD_URI"))
+		_, _ = w.Write([]byte(`{"status":"Successfully uploaded segment"}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	if err := c.UploadSegmentURI(t.Context(), "events_OFFLINE", "s3://segments/events_0.tar.gz"); err != nil {
+		t.Fatalf("UploadSegmentURI: %v", err)
+	}
+	if want := "POST /segments?tableName=events&tableType=OFFLINE type=URI uri=s3://segments/events_0.tar.gz"; got != want {
+		t.Errorf("request = %q, want %q", got, want)
+	}
+	if err := c.UploadSegmentURI(t.Context(), "events", "s3://segments/events_0.tar.gz"); err == nil {
+		t.Error("UploadSegmentURI without a table type should fail")
+	}
+}
+
+func TestWithControllersRoutesWritesToLeader(t *testing.T) {
+	var mu sync.Mutex
+	var hits []string
+	var leaderID string
+	var failLeader bool
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if r.URL.Path == "/leader/tables" {
+				_, _ = fmt.Fprintf(w, `{"leadControllerResourceEnabled":false,"leadControllerEntryMap":{"leadControllerResource_0":{"leadControllerId":%q,"tableNames":[]}}}`, leaderID)
+				return
+			}
+			hits = append(hits, name+" "+r.Method)
+			if name == "b" && failLeader {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}
+	a := httptest.NewServer(handler("a"))
+	defer a.Close()
+	b := httptest.NewServer(handler("b"))
+	defer b.Close()
+	controllerID := func(srv *httptest.Server) string {
+		u, _ := url.Parse(srv.URL)
+		return "Controller_" + u.Hostname() + "_" + u.Port()
+	}
+	leaderID = controllerID(b)
+
+	c, _ := NewPinotClient(a.URL, "", "")
+	c = c.WithControllers([]string{b.URL + "/", a.URL, ""})
+	if _, err := c.GetSchema(t.Context(), "s"); err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if err := c.DeleteSchema(t.Context(), "s"); err != nil {
+		t.Fatalf("DeleteSchema: %v", err)
+	}
+	if want := []string{"a GET", "b DELETE"}; !reflect.DeepEqual(hits, want) {
+		t.Errorf("requests = %v, want %v", hits, want)
+	}
+
+	// A failed write to the leader makes the next one detect it again.
+	mu.Lock()
+	hits, failLeader = nil, true
+	mu.Unlock()
+	if err := c.DeleteSchema(t.Context(), "s"); err == nil {
+		t.Fatal("expected the write to the failing leader to fail")
+	}
+	mu.Lock()
+	leaderID = controllerID(a)
+	mu.Unlock()
+	if err := c.DeleteSchema(t.Context(), "s"); err != nil {
+		t.Fatalf("DeleteSchema: %v", err)
+	}
+	if want := []string{"b DELETE", "a DELETE"}; !reflect.DeepEqual(hits, want) {
+		t.Errorf("requests = %v, want %v", hits, want)
+	}
+}
+
+func TestLeadControllerID(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"single leader": {
+			body: `{"leadControllerEntryMap":{"leadControllerResource_0":{"leadControllerId":"Controller_c0_9000"},"leadControllerResource_1":{"leadControllerId":"Controller_c0_9000"}}}`,
+			want: "Controller_c0_9000",
+		},
+		"spread leadership": {
+			body: `{"leadControllerEntryMap":{"leadControllerResource_0":{"leadControllerId":"Controller_c0_9000"},"leadControllerResource_1":{"leadControllerId":"Controller_c1_9000"}}}`,
+		},
+		"no entries": {body: `{"leadControllerEntryMap":{}}`},
+	}
+	for name, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(tc.body))
+		}))
+		c, _ := NewPinotClient(srv.URL, "", "")
+		got, err := c.LeadControllerID(t.Context())
+		srv.Close()
+		if err != nil || got != tc.want {
+			t.Errorf("%s: got %q, %v; want %q", name, got, err, tc.want)
+		}
+	}
+
+	for id, want := range map[string]bool{
+		"Controller_pinot-controller-0_9000": true,
+		"Controller_pinot-controller-0_9001": false,
+		"Controller_other_9000":              false,
+		"Broker_pinot-controller-0_9000":     false,
+	} {
+		if got := controllerIDMatches(id, "http://pinot-controller-0:9000"); got != want {
+			t.Errorf("controllerIDMatches(%q) = %v, want %v", id, got, want)
+		}
+	}
+	if !controllerIDMatches("Controller_pinot_443", "https://pinot") {
+		t.Error("default https port not matched")
+	}
+}
+
+func TestIngestFromFile(t *testing.T) {
+	var gotPath string
+	var gotQuery url.Values
+	var gotFile string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.Query()
+		if f, _, err := r.FormFile("file"); err == nil {
+			b, _ := io.ReadAll(f)
+			gotFile = string(b)
+		}
+		_, _ = w.Write([]byte(`{"status":"Successfully ingested file into table: events_OFFLINE"}`))
+	}))
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+	batchConfig := map[string]string{"inputFormat": "csv", "recordReader.prop.delimiter": "|"}
+
+	path := filepath.Join(t.TempDir(), "events.csv")
+	if err := os.WriteFile(path, []byte("id|name\n1|a\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	status, err := c.IngestFromFile(t.Context(), "events_OFFLINE", IngestionJobSpec{BatchConfig: batchConfig, FilePath: path})
+	if err != nil {
+		t.Fatalf("IngestFromFile: %v", err)
+	}
+	if status != "Successfully ingested file into table: events_OFFLINE" {
+		t.Errorf("status = %q", status)
+	}
+	if gotPath != "/ingestFromFile" || gotQuery.Get("tableNameWithType") != "events_OFFLINE" || gotFile != "id|name\n1|a\n" {
+		t.Errorf("request = %s %v with file %q", gotPath, gotQuery, gotFile)
+	}
+	var sent map[string]string
+	if err := json.Unmarshal([]byte(gotQuery.Get("batchConfigMapStr")), &sent); err != nil || !reflect.DeepEqual(sent, batchConfig) {
+		t.Errorf("batchConfigMapStr = %q", gotQuery.Get("batchConfigMapStr"))
+	}
+
+	if _, err := c.IngestFromFile(t.Context(), "events_OFFLINE", IngestionJobSpec{BatchConfig: batchConfig, SourceURI: "s3://bucket/events.csv"}); err != nil {
+		t.Fatalf("IngestFromFile from URI: %v", err)
+	}
+	if gotPath != "/ingestFromURI" || gotQuery.Get("sourceURIStr") != "s3://bucket/events.csv" {
+		t.Errorf("request = %s %v", gotPath, gotQuery)
+	}
+
+	if _, err := c.IngestFromFile(t.Context(), "events", IngestionJobSpec{SourceURI: "s3://bucket/events.csv"}); err == nil {
+		t.Error("expected an error for a table name without type suffix")
+	}
+	if _, err := c.IngestFromFile(t.Context(), "events_OFFLINE", IngestionJobSpec{}); err == nil {
+		t.Error("expected an error without a file or URI")
+	}
+}
+
+func TestListSchemas(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`["orders","events"]`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	names, err := c.ListSchemas(t.Context())
+	if err != nil {
+		t.Fatalf("ListSchemas: %v", err)
+	}
+	if want := []string{"events", "orders"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"users":{"admin_CONTROLLER":{"username":"admin","password":"$2a$10$hash","component":"CONTROLLER","role":"ADMIN"}}}`))
+	}))
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+
+	users, err := c.ListUsers(t.Context())
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if got := users["admin_CONTROLLER"]["role"]; len(users) != 1 || got != "ADMIN" {
+		t.Errorf("users = %v", users)
+	}
+}
+
+func TestTenantCRUD(t *testing.T) {
+	var calls []string
+	var bodies []Tenant
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.RequestURI())
+		if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPut) {
+			var body Tenant
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"ServerInstances":["Server_b_8098","Server_a_8098"],"BrokerInstances":["Broker_a_8099"],"tenantName":"analytics"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+
+	server := Tenant{TenantName: "analytics", TenantRole: "SERVER", OfflineInstances: 2, RealtimeInstances: 1}
+	if err := c.CreateTenant(t.Context(), server); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	if err := c.UpdateTenant(t.Context(), Tenant{TenantName: "analytics", TenantRole: "BROKER", NumberOfInstances: 1}); err != nil {
+		t.Fatalf("UpdateTenant: %v", err)
+	}
+	servers, err := c.GetTenant(t.Context(), "analytics", "SERVER")
+	if err != nil {
+		t.Fatalf("GetTenant: %v", err)
+	}
+	if want := []string{"Server_a_8098", "Server_b_8098"}; !reflect.DeepEqual(servers, want) {
+		t.Errorf("servers = %v, want %v", servers, want)
+	}
+	brokers, err := c.GetTenant(t.Context(), "analytics", "BROKER")
+	if err != nil {
+		t.Fatalf("GetTenant: %v", err)
+	}
+	if want := []string{"Broker_a_8099"}; !reflect.DeepEqual(brokers, want) {
+		t.Errorf("brokers = %v, want %v", brokers, want)
+	}
+	if err := c.DeleteTenant(t.Context(), "analytics", "server"); err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+
+	wantCalls := []string{
+		"POST /tenants",
+		"PUT /tenants",
+		"GET /tenants/analytics?type=server",
+		"GET /tenants/analytics?type=broker",
+		"DELETE /tenants/analytics?type=SERVER",
+	}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", calls, wantCalls)
+	}
+	if len(bodies) != 2 || bodies[0] != server || bodies[1].NumberOfInstances != 1 {
+		t.Errorf("bodies = %+v", bodies)
+	}
+}
+
+func TestCountTenantServers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenants/analytics" || r.URL.Query().Get("type") != "server" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch r.URL.Query().Get("tableType") {
+		case "OFFLINE":
+			_, _ = w.Write([]byte(`{"ServerInstances":["Server_a_8098","Server_b_8098"],"tenantName":"analytics"}`))
+		case "REALTIME":
+			_, _ = w.Write([]byte(`{"ServerInstances":["Server_c_8098"],"tenantName":"analytics"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	offline, realtime, err := c.CountTenantServers(t.Context(), "analytics")
+	if err != nil {
+		t.Fatalf("CountTenantServers: %v", err)
+	}
+	if offline != 2 || realtime != 1 {
+		t.Errorf("counts = %d offline, %d realtime; want 2, 1", offline, realtime)
+	}
+}
+
+func TestGetClusterStorage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tables":
+			_, _ = w.Write([]byte(`{"tables":["events","orders_OFFLINE","gone"]}`))
+		case "/tables/events/size":
+			if r.URL.Query().Get("detailed") != "true" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"tableName":"events","reportedSizeInBytes":700,"estimatedSizeInBytes":900,` +
+				`"offlineSegments":{"reportedSizeInBytes":300,"estimatedSizeInBytes":500,"segments":{` +
+				`"s1":{"serverInfo":{"Server_a_8098":{"diskSizeInBytes":100},"Server_b_8098":{"diskSizeInBytes":200},"Server_c_8098":{"diskSizeInBytes":-1}}}}},` +
+				`"realtimeSegments":{"reportedSizeInBytes":400,"estimatedSizeInBytes":400,"segments":{` +
+				`"s2":{"serverInfo":{"Server_a_8098":{"diskSizeInBytes":400}}}}}}`))
+		case "/tables/orders/size":
+			_, _ = w.Write([]byte(`{"tableName":"orders","reportedSizeInBytes":50,"estimatedSizeInBytes":50,` +
+				`"offlineSegments":{"reportedSizeInBytes":50,"estimatedSizeInBytes":50,"segments":{` +
+				`"s3":{"serverInfo":{"Server_b_8098":{"diskSizeInBytes":50}}}}}}`))
+		default:
+			http.Error(w, `{"code":404,"error":"table not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, _ := NewPinotClient(srv.URL, "", "")
+	got, err := c.GetClusterStorage(t.Context())
+	if err != nil {
+		t.Fatalf("GetClusterStorage: %v", err)
+	}
+	want := &TableSize{
+		ReportedBytes:  750,
+		EstimatedBytes: 950,
+		Tables:         map[string]int64{"events_OFFLINE": 300, "events_REALTIME": 400, "orders_OFFLINE": 50},
+		Servers:        map[string]int64{"Server_a_8098": 500, "Server_b_8098": 250},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetClusterStorage = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	body := `{"pinot-segment-spi":"1.2.0","pinot-controller":"1.2.0-SNAPSHOT"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+
+	if v, err := c.GetVersion(t.Context()); err != nil || v != "1.2.0-SNAPSHOT" {
+		t.Errorf("GetVersion = %q, %v; want the pinot-controller version", v, err)
+	}
+	body = `{"pinot-spi":"0.10.0","pinot-common":"0.10.0"}`
+	if v, err := c.GetVersion(t.Context()); err != nil || v != "0.10.0" {
+		t.Errorf("GetVersion without pinot-controller = %q, %v", v, err)
+	}
+	body = `{}`
+	if _, err := c.GetVersion(t.Context()); err == nil {
+		t.Error("GetVersion without any version should fail")
+	}
+
+	for version, want := range map[string]bool{
+		"":               true,
+		"unknown":        true,
+		"0.10.0":         false,
+		"0.11.0":         true,
+		"0.11.0-preview": true,
+		"0.9":            false,
+		"1.2.0.1":        true,
+	} {
+		if got := c.WithControllerVersion(version).SupportsPauseConsumption(); got != want {
+			t.Errorf("version %q: SupportsPauseConsumption = %v, want %v", version, got, want)
+		}
+	}
+	if v := c.WithControllerVersion("0.10.0").WithControllerURL("http://other:9000").ControllerVersion(); v != "" {
+		t.Errorf("client for another controller kept version %q", v)
+	}
+}
+
+func TestValidateTableConfigs(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tableConfigs/validate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		got = nil
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got["offline"] != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":400,"error":"Invalid TableConfigs: events. Time column: ts not found in schema"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c, _ := NewPinotClient(srv.URL, "", "")
+
+	configs := TableConfigs{
+		TableName: "events",
+		Schema:    map[string]interface{}{"schemaName": "events"},
+		Realtime:  map[string]interface{}{"tableName": "events_REALTIME"},
+	}
+	if err := c.ValidateTableConfigs(t.Context(), configs); err != nil {
+		t.Fatalf("ValidateTableConfigs: %v", err)
+	}
+	if _, ok := got["offline"]; ok || got["tableName"] != "events" || got["realtime"] == nil {
+		t.Errorf("request body = %v", got)
+	}
+
+	configs.Offline = map[string]interface{}{"tableName": "events_OFFLINE"}
+	err := c.ValidateTableConfigs(t.Context(), configs)
+	if StatusCode(err) != http.StatusBadRequest || !strings.Contains(err.Error(), "Time column: ts not found in schema") {
+		t.Errorf("err = %v, want the controller's validation message", err)
+	}
+}
@@ -0,0 +1,198 @@
+// internal/client/config.go
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ClientConfig holds the transport settings of a PinotClient.
+type ClientConfig struct {
+	// Timeout bounds each HTTP request, including reading the response body. Zero means the default.
+	Timeout time.Duration
+	// DialTimeout bounds establishing a TCP connection and TLSHandshakeTimeout the TLS handshake that follows;
+	// both are separate from Timeout. Zero means the default.
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+	Retry               RetryPolicy
+	// PinnedCertSHA256 lists SHA-256 fingerprints (hex, colons optional) of the controller's certificate. When set,
+	// the server certificate is accepted if its fingerprint matches one of them, instead of verifying its chain
+	// and host name, e.g. for self-signed certificates on dev clusters.
+	PinnedCertSHA256 []string
+	// CompressRequests gzips JSON bodies of POST and PUT requests larger than CompressionThreshold. The first
+	// request the controller rejects with 415 Unsupported Media Type is sent again uncompressed, and
+	// compression stays off for the client from then on.
+	CompressRequests bool
+}
+
+// RetryPolicy controls how failed requests are retried. Idempotent requests (GET, PUT, DELETE) are retried after
+// a network error or a response with one of StatusCodes; TLS and certificate pin failures are not retried. Other
+// requests, such as POST, are only retried when the connection could not be established, so the controller never
+// saw them.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt; zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry; it doubles for every further retry up to MaxBackoff.
+	// Each wait is jittered down to half its length so clients that failed together do not retry together.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	StatusCodes    []int
+}
+
+// Defaults of ClientConfig. DefaultClientConfig itself does not retry (MaxRetries is 0); the provider sets
+// MaxRetries from max_retries, which defaults to 3, so provider requests are retried. The dial and TLS handshake
+// timeouts match http.DefaultTransport.
+const (
+	DefaultTimeout             = 30 * time.Second
+	DefaultDialTimeout         = 30 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	DefaultInitialBackoff      = 1 * time.Second
+	DefaultMaxBackoff          = 30 * time.Second
+)
+
+// CompressionThreshold is the size in bytes above which CompressRequests gzips a request body.
+const CompressionThreshold = 32 << 10
+
+// DefaultClientConfig returns the settings NewPinotClient uses.
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Timeout:             DefaultTimeout,
+		DialTimeout:         DefaultDialTimeout,
+		TLSHandshakeTimeout: DefaultTLSHandshakeTimeout,
+		Retry: RetryPolicy{
+			InitialBackoff: DefaultInitialBackoff,
+			MaxBackoff:     DefaultMaxBackoff,
+			StatusCodes:    []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		},
+	}
+}
+
+// withDefaults fills zero fields of cfg from DefaultClientConfig. StatusCodes is left as is, so an empty list
+// retries transport errors only.
+func (cfg ClientConfig) withDefaults() ClientConfig {
+	def := DefaultClientConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = def.Timeout
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = def.DialTimeout
+	}
+	if cfg.TLSHandshakeTimeout <= 0 {
+		cfg.TLSHandshakeTimeout = def.TLSHandshakeTimeout
+	}
+	if cfg.Retry.InitialBackoff <= 0 {
+		cfg.Retry.InitialBackoff = def.Retry.InitialBackoff
+	}
+	if cfg.Retry.MaxBackoff <= 0 {
+		cfg.Retry.MaxBackoff = def.Retry.MaxBackoff
+	}
+	return cfg
+}
+
+// tlsConfig returns the TLS settings for the transport, or nil to keep Go's defaults.
+func (cfg ClientConfig) tlsConfig() (*tls.Config, error) {
+	if len(cfg.PinnedCertSHA256) == 0 {
+		return nil, nil
+	}
+	pins := make([][]byte, 0, len(cfg.PinnedCertSHA256))
+	for _, p := range cfg.PinnedCertSHA256 {
+		pin, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(p), ":", ""))
+		if err != nil || len(pin) != sha256.Size {
+			return nil, fmt.Errorf("invalid certificate fingerprint %q: must be a hex-encoded SHA-256 digest", p)
+		}
+		pins = append(pins, pin)
+	}
+	return &tls.Config{
+		// The pin replaces chain and host name verification; VerifyPeerCertificate still runs.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("server sent no certificate")
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			for _, pin := range pins {
+				if bytes.Equal(sum[:], pin) {
+					return nil
+				}
+			}
+			return fmt.Errorf("server certificate fingerprint %s matches no pinned fingerprint", hex.EncodeToString(sum[:]))
+		},
+	}, nil
+}
+
+// shouldRetry reports whether the attempt-th retry (counting from 1) of a method request that failed with err
+// is allowed.
+func (p RetryPolicy) shouldRetry(method string, attempt int, err error) bool {
+	if attempt > p.MaxRetries {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+	default:
+		return notSent(err)
+	}
+	code := StatusCode(err)
+	if code == 0 {
+		return networkError(err)
+	}
+	for _, c := range p.StatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// notSent reports whether err is a failure to connect to the controller, before any part of the request was sent.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// networkError reports whether err is a failure of the connection to the controller that a new attempt may not
+// hit: a failed dial, read or write, a timeout, or a connection closed or reset mid-request. TLS verification and
+// certificate pin failures are not, as retrying them fails the same way.
+func networkError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// jitteredBackoff returns backoff(attempt) reduced by a random amount of up to half.
+func (p RetryPolicy) jitteredBackoff(attempt int) time.Duration {
+	d := p.backoff(attempt)
+	if d <= 1 {
+		return d
+	}
+	return d - rand.N(d/2)
+}
+
+// backoff returns the wait before the attempt-th retry (counting from 1).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
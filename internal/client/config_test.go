@@ -0,0 +1,284 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	failures := 2
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method]++
+		if calls[r.Method] <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"schemaName":"events"}`))
+	}))
+	defer srv.Close()
+
+	cfg := DefaultClientConfig()
+	cfg.Retry.MaxRetries = 2
+	cfg.Retry.InitialBackoff = time.Millisecond
+	c, _ := NewPinotClientWithToken(srv.URL, "", "", "", cfg)
+
+	if _, err := c.GetSchema(t.Context(), "events"); err != nil {
+		t.Fatalf("GetSchema after %d failures: %v", failures, err)
+	}
+	if calls[http.MethodGet] != 3 {
+		t.Errorf("GET attempts = %d, want 3", calls[http.MethodGet])
+	}
+
+	// POSTs are not idempotent and are never retried by the policy.
+	if _, err := c.doRequest(t.Context(), http.MethodPost, srv.URL+"/schemas", nil); StatusCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("POST err = %v, want the first 503", err)
+	}
+	if calls[http.MethodPost] != 1 {
+		t.Errorf("POST attempts = %d, want 1", calls[http.MethodPost])
+	}
+
+	c, _ = NewPinotClient(srv.URL, "", "")
+	calls = map[string]int{}
+	if _, err := c.GetSchema(t.Context(), "events"); err == nil || calls[http.MethodGet] != 1 {
+		t.Errorf("default config retried: err = %v, attempts = %d", err, calls[http.MethodGet])
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := p.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestRetryPolicyPostOnlyWhenNotSent(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 3, StatusCodes: []int{http.StatusServiceUnavailable}}
+
+	// Nothing listens on a closed server's address, so the dial fails before the request is sent.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.URL
+	srv.Close()
+	c, _ := NewPinotClient(addr, "", "")
+	_, dialErr := c.doRequest(t.Context(), http.MethodPost, addr+"/tables", map[string]string{"tableName": "events"})
+	if dialErr == nil {
+		t.Fatal("POST to a closed server succeeded")
+	}
+
+	for name, tc := range map[string]struct {
+		err  error
+		want bool
+	}{
+		"dial error":        {err: dialErr, want: true},
+		"error after send":  {err: fmt.Errorf("request failed: %w", &net.OpError{Op: "read", Err: io.ErrUnexpectedEOF}), want: false},
+		"retryable status":  {err: &APIError{StatusCode: http.StatusServiceUnavailable}, want: false},
+		"unrelated failure": {err: errors.New("failed to read response"), want: false},
+	} {
+		if got := p.shouldRetry(http.MethodPost, 1, tc.err); got != tc.want {
+			t.Errorf("%s: shouldRetry(POST) = %v, want %v", name, got, tc.want)
+		}
+	}
+	if !p.shouldRetry(http.MethodGet, 1, fmt.Errorf("request failed: %w", &net.OpError{Op: "read", Err: io.ErrUnexpectedEOF})) {
+		t.Error("GET after a send failure was not retried")
+	}
+}
+
+func TestRetryPolicyNetworkErrorsOnly(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 3}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	untrusted, _ := NewPinotClient(srv.URL, "", "")
+	_, verifyErr := untrusted.GetSchema(t.Context(), "events")
+	cfg := DefaultClientConfig()
+	cfg.PinnedCertSHA256 = []string{strings.Repeat("ab", sha256.Size)}
+	pinned, _ := NewPinotClientWithToken(srv.URL, "", "", "", cfg)
+	_, pinErr := pinned.GetSchema(t.Context(), "events")
+
+	for name, tc := range map[string]struct {
+		err  error
+		want bool
+	}{
+		"certificate verification": {err: verifyErr, want: false},
+		"pin mismatch":             {err: pinErr, want: false},
+		"connection reset":         {err: fmt.Errorf("request failed: %w", &net.OpError{Op: "read", Err: syscall.ECONNRESET}), want: true},
+		"bare connection reset":    {err: fmt.Errorf("request failed: %w", syscall.ECONNRESET), want: true},
+		"unexpected EOF":           {err: fmt.Errorf("request failed: %w", io.ErrUnexpectedEOF), want: true},
+		"timeout":                  {err: &url.Error{Op: "Get", URL: srv.URL, Err: context.DeadlineExceeded}, want: true},
+		"unrelated failure":        {err: errors.New("failed to read response"), want: false},
+	} {
+		if tc.err == nil {
+			t.Fatalf("%s: request unexpectedly succeeded", name)
+		}
+		if got := p.shouldRetry(http.MethodGet, 1, tc.err); got != tc.want {
+			t.Errorf("%s: shouldRetry(GET, %v) = %v, want %v", name, tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for i := 0; i < 100; i++ {
+		if got := p.jitteredBackoff(2); got <= time.Second || got > 2*time.Second {
+			t.Fatalf("jitteredBackoff(2) = %v, want within (1s, 2s]", got)
+		}
+	}
+}
+
+func TestClientConfigDefaults(t *testing.T) {
+	c, _ := NewPinotClientWithToken("http://localhost:9000", "", "", "", ClientConfig{})
+	if c.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("timeout = %v, want %v", c.httpClient.Timeout, DefaultTimeout)
+	}
+	if c.retry.MaxRetries != 0 || c.retry.InitialBackoff != DefaultInitialBackoff {
+		t.Errorf("retry policy = %+v, want defaults without retries", c.retry)
+	}
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", c.httpClient.Transport)
+	}
+	if transport.TLSHandshakeTimeout != DefaultTLSHandshakeTimeout {
+		t.Errorf("TLS handshake timeout = %v, want %v", transport.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout)
+	}
+}
+
+func TestTransportTimeouts(t *testing.T) {
+	cfg := DefaultClientConfig()
+	cfg.TLSHandshakeTimeout = 3 * time.Second
+	c, err := NewPinotClientWithToken("http://localhost:9000", "", "", "", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, err = c.WithProxyURL("http://proxy:3128"); err != nil {
+		t.Fatal(err)
+	}
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport = %T, want *http.Transport", c.httpClient.Transport)
+	}
+	if transport.TLSHandshakeTimeout != 3*time.Second {
+		t.Errorf("TLS handshake timeout = %v, want 3s", transport.TLSHandshakeTimeout)
+	}
+	if transport.DialContext == nil {
+		t.Error("DialContext not set")
+	}
+}
+
+func TestPinnedCertSHA256(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	sum := sha256.Sum256(srv.Certificate().Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+	var colons []string
+	for i := 0; i < len(fingerprint); i += 2 {
+		colons = append(colons, fingerprint[i:i+2])
+	}
+
+	cases := map[string]struct {
+		pins    []string
+		wantErr bool
+	}{
+		"no pin verifies the chain": {wantErr: true},
+		"matching pin":              {pins: []string{strings.Repeat("00", sha256.Size), strings.Join(colons, ":")}},
+		"other pin":                 {pins: []string{strings.Repeat("ab", sha256.Size)}, wantErr: true},
+	}
+	for name, tc := range cases {
+		cfg := DefaultClientConfig()
+		cfg.PinnedCertSHA256 = tc.pins
+		c, err := NewPinotClientWithToken(srv.URL, "", "", "", cfg)
+		if err != nil {
+			t.Fatalf("%s: NewPinotClientWithToken: %v", name, err)
+		}
+		_, err = c.GetSchema(t.Context(), "s")
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %t", name, err, tc.wantErr)
+		}
+	}
+
+	cfg := DefaultClientConfig()
+	cfg.PinnedCertSHA256 = []string{"abc"}
+	if _, err := NewPinotClientWithToken(srv.URL, "", "", "", cfg); err == nil {
+		t.Error("an invalid fingerprint should be rejected")
+	}
+}
+
+func TestCompressRequests(t *testing.T) {
+	large := map[string]string{"payload": strings.Repeat("x", CompressionThreshold)}
+	small := map[string]string{"payload": "x"}
+
+	for _, supported := range []bool{true, false} {
+		var encodings []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := r.Header.Get("Content-Encoding")
+			encodings = append(encodings, r.Method+" "+encoding)
+			body := io.Reader(r.Body)
+			if encoding == "gzip" {
+				if !supported {
+					w.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+				gz, err := gzip.NewReader(r.Body)
+				if err != nil {
+					t.Errorf("gzip.NewReader: %v", err)
+					return
+				}
+				body = gz
+			}
+			var got map[string]string
+			if err := json.NewDecoder(body).Decode(&got); err != nil {
+				t.Errorf("decode %s body: %v", encoding, err)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}))
+
+		cfg := DefaultClientConfig()
+		cfg.CompressRequests = true
+		c, err := NewPinotClientWithToken(srv.URL, "", "", "", cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, req := range []struct {
+			method string
+			body   interface{}
+		}{
+			{http.MethodPost, large},
+			{http.MethodPut, small},
+			{http.MethodPut, large},
+		} {
+			if _, err := c.WithAPIPathPrefix("").doRequest(t.Context(), req.method, srv.URL+"/tables", req.body); err != nil {
+				t.Errorf("supported=%v: %s: %v", supported, req.method, err)
+			}
+		}
+		srv.Close()
+
+		want := []string{"POST gzip", "PUT ", "PUT gzip"}
+		if !supported {
+			// The 415 is retried uncompressed, and compression stays off for copies of the client too.
+			want = []string{"POST gzip", "POST ", "PUT ", "PUT "}
+		}
+		if !reflect.DeepEqual(encodings, want) {
+			t.Errorf("supported=%v: requests = %q, want %q", supported, encodings, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// internal/provider/api_error.go
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"terraform-provider-pinot/internal/client"
+)
+
+// addAPIError adds an error diagnostic for a failed controller call. action says what was attempted
+// (e.g. "Could not create table"); the detail depends on the status code the controller returned.
+func addAPIError(diags *diag.Diagnostics, summary, action string, err error) {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, action+": "+err.Error())
+		return
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest:
+		diags.AddError(summary, action+": the controller rejected the configuration: "+apiErr.Message)
+	case http.StatusUnauthorized:
+		diags.AddError("Pinot Authentication Failed",
+			action+": the controller did not accept the provider's credentials. "+
+				"Check token or username/password (or PINOT_TOKEN, PINOT_USERNAME and PINOT_PASSWORD): "+apiErr.Message)
+	case http.StatusForbidden:
+		diags.AddError("Pinot Authorization Failed",
+			action+": the provider's credentials are not allowed to perform this operation: "+apiErr.Message)
+	case http.StatusConflict:
+		diags.AddError(summary, action+": the controller reported a conflict, which usually means the resource "+
+			"already exists or another operation is still running: "+apiErr.Message)
+	default:
+		diags.AddError(summary, action+": "+err.Error())
+	}
+}
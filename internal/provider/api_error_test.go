@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestAddAPIError(t *testing.T) {
+	cases := map[string]struct {
+		err         error
+		wantSummary string
+		wantDetail  string
+	}{
+		"validation": {
+			err:         &client.APIError{StatusCode: 400, Message: "Invalid table config"},
+			wantSummary: "Error Creating Pinot Table",
+			wantDetail:  "rejected the configuration: Invalid table config",
+		},
+		"unauthenticated": {
+			err:         &client.APIError{StatusCode: 401, Message: "HTTP 401 Unauthorized"},
+			wantSummary: "Pinot Authentication Failed",
+			wantDetail:  "did not accept the provider's credentials",
+		},
+		"forbidden": {
+			err:         &client.APIError{StatusCode: 403, Message: "Permission is denied"},
+			wantSummary: "Pinot Authorization Failed",
+			wantDetail:  "not allowed to perform this operation",
+		},
+		"server error": {
+			err:         &client.APIError{StatusCode: 500, Message: "boom"},
+			wantSummary: "Error Creating Pinot Table",
+			wantDetail:  "API error (status 500): boom",
+		},
+		"transport error": {
+			err:         errors.New("request failed: connection refused"),
+			wantSummary: "Error Creating Pinot Table",
+			wantDetail:  "connection refused",
+		},
+	}
+
+	for name, tc := range cases {
+		var diags diag.Diagnostics
+		addAPIError(&diags, "Error Creating Pinot Table", "Could not create table events_OFFLINE", tc.err)
+		if len(diags) != 1 {
+			t.Fatalf("%s: expected one diagnostic, got %d", name, len(diags))
+		}
+		if got := diags[0].Summary(); got != tc.wantSummary {
+			t.Errorf("%s: summary = %q, want %q", name, got, tc.wantSummary)
+		}
+		if got := diags[0].Detail(); !strings.Contains(got, tc.wantDetail) {
+			t.Errorf("%s: detail %q does not contain %q", name, got, tc.wantDetail)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// internal/provider/app_configs_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &AppConfigsDataSource{}
+
+type AppConfigsDataSource struct {
+	client *client.PinotClient
+}
+
+type AppConfigsDataSourceModel struct {
+	ID         types.String         `tfsdk:"id"`
+	AppConfigs jsontypes.Normalized `tfsdk:"app_configs"`
+}
+
+// redactedValue replaces sensitive values in data returned from the controller.
+const redactedValue = "<redacted>"
+
+// sensitiveKeyFragments marks config keys whose values must never reach state.
+var sensitiveKeyFragments = []string{"password", "secret", "token", "credential", "jaas"}
+
+func NewAppConfigsDataSource() datasource.DataSource {
+	return &AppConfigsDataSource{}
+}
+
+func (d *AppConfigsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_configs"
+}
+
+func (d *AppConfigsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the Pinot Controller application configs via `GET /appconfigs`. Values of keys that look sensitive (passwords, secrets, tokens, credentials) are redacted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier (always `appconfigs`).",
+			},
+			"app_configs": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "JSON document returned by the controller, with sensitive values redacted. Use `jsondecode()` to read individual settings.",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+		},
+	}
+}
+
+func (d *AppConfigsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AppConfigsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppConfigsDataSourceModel
+
+	configs, err := d.client.GetAppConfigs(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Pinot App Configs",
+			"Could not read app configs: "+err.Error(),
+		)
+		return
+	}
+
+	scrubbed, _ := scrubSensitiveValues(configs).(map[string]interface{})
+	configsJSON, err := canonicalJSON(scrubbed)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Marshaling App Configs",
+			"Could not marshal app configs to JSON: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("appconfigs")
+	data.AppConfigs = jsontypes.NewNormalizedValue(configsJSON)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// scrubSensitiveValues returns a copy of v with the values of sensitive-looking keys redacted at any depth.
+func scrubSensitiveValues(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, el := range t {
+			if isSensitiveKey(k) {
+				out[k] = redactedValue
+				continue
+			}
+			out[k] = scrubSensitiveValues(el)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, el := range t {
+			out[i] = scrubSensitiveValues(el)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(k, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+// internal/provider/canonical_json.go
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// canonicalJSON renders a JSON object for state: keys sorted at every level, no HTML escaping
+// (so `<`, `>` and `&` in transform functions or URLs stay readable) and no trailing newline.
+// Equivalent inputs always produce identical output.
+func canonicalJSON(v map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
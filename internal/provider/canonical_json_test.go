@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	inputs := []string{
+		`{"b":1,"a":{"y":[{"k2":true,"k1":null}],"x":"<&>"}}`,
+		`{"a":{"x":"<&>","y":[{"k1":null,"k2":true}]},"b":1}`,
+		`{ "a" : { "x" : "<&>", "y" : [ { "k2" : true, "k1" : null } ] }, "b" : 1.0 }`,
+	}
+	want := `{"a":{"x":"<&>","y":[{"k1":null,"k2":true}]},"b":1}`
+
+	for _, in := range inputs {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(in), &m); err != nil {
+			t.Fatalf("invalid test JSON %s: %v", in, err)
+		}
+		// Repeat to catch any dependence on map iteration order.
+		for i := 0; i < 20; i++ {
+			got, err := canonicalJSON(m)
+			if err != nil {
+				t.Fatalf("canonicalJSON(%s): %v", in, err)
+			}
+			if got != want {
+				t.Fatalf("canonicalJSON(%s) = %s, want %s", in, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// internal/provider/cluster_capacity_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &ClusterCapacityDataSource{}
+
+type ClusterCapacityDataSource struct {
+	client *client.PinotClient
+}
+
+type ClusterCapacityDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	TotalBytes types.Int64  `tfsdk:"total_bytes"`
+	UsedBytes  types.Int64  `tfsdk:"used_bytes"`
+	Servers    types.Map    `tfsdk:"servers"` // map[string]int64
+	Tables     types.Map    `tfsdk:"tables"`  // map[string]int64
+}
+
+func NewClusterCapacityDataSource() datasource.DataSource {
+	return &ClusterCapacityDataSource{}
+}
+
+func (d *ClusterCapacityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_capacity"
+}
+
+func (d *ClusterCapacityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the storage the cluster's segments take on the servers, in total, per server and per table, via `GET /tables/{name}/size`. " +
+			"Sizes count every replica. The controller does not report the disk capacity of the servers, so compare these against the capacity you provisioned. " +
+			"Reads the size of every table, one request per table.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier (always `cluster_capacity`).",
+			},
+			"total_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Estimated bytes the segments of all tables take, including segments on servers that did not report their size.",
+			},
+			"used_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Bytes the servers reported for the segments of all tables. Lower than `total_bytes` when servers did not answer.",
+			},
+			"servers": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "Bytes reported per server instance (e.g. `Server_pinot-server-0_8098`). Servers without segments or that did not answer are omitted.",
+			},
+			"tables": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "Bytes reported per table as `<logical>_<TYPE>`.",
+			},
+		},
+	}
+}
+
+func (d *ClusterCapacityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterCapacityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterCapacityDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storage, err := d.client.GetClusterStorage(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Cluster Capacity", "Could not read table sizes", err)
+		return
+	}
+
+	data.ID = types.StringValue("cluster_capacity")
+	data.TotalBytes = types.Int64Value(storage.EstimatedBytes)
+	data.UsedBytes = types.Int64Value(storage.ReportedBytes)
+	servers, diags := types.MapValueFrom(ctx, types.Int64Type, storage.Servers)
+	resp.Diagnostics.Append(diags...)
+	tables, diags := types.MapValueFrom(ctx, types.Int64Type, storage.Tables)
+	resp.Diagnostics.Append(diags...)
+	data.Servers = servers
+	data.Tables = tables
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
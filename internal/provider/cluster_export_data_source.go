@@ -0,0 +1,198 @@
+// internal/provider/cluster_export_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &ClusterExportDataSource{}
+
+type ClusterExportDataSource struct {
+	client *client.PinotClient
+}
+
+type ClusterExportDataSourceModel struct {
+	ID     types.String         `tfsdk:"id"`
+	JSON   jsontypes.Normalized `tfsdk:"json"`
+	Counts types.Map            `tfsdk:"counts"` // map[string]int64
+}
+
+func NewClusterExportDataSource() datasource.DataSource {
+	return &ClusterExportDataSource{}
+}
+
+func (d *ClusterExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_export"
+}
+
+func (d *ClusterExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports every schema, table config and user of the cluster as a single JSON document, e.g. to write it to a file for backup. " +
+			"User passwords and SASL JAAS credentials in table configs are left out, and the values of credential keys in stream and batch config maps " +
+			"(`*.password`, `basic.auth.user.info` and `sasl.*`) are replaced with `" + redactedValue + "`; secrets under other keys are exported as is. " +
+			"Reads every schema and table, one request each.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier (always `cluster_export`).",
+			},
+			"json": schema.StringAttribute{
+				CustomType: jsontypes.NormalizedType{},
+				Computed:   true,
+				Sensitive:  true,
+				MarkdownDescription: "The export: an object with `schemas` keyed by schema name, `tables` keyed by `<logical>_<TYPE>` and `users` keyed by `<username>_<COMPONENT>`. " +
+					"Schemas and table configs are as the controller returns them apart from the credentials above, ready to pass to `pinot_schema` and `pinot_table`. " +
+					"Sensitive, since table configs can still hold secrets under other keys.",
+			},
+			"counts": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "Number of exported `schemas`, `tables` and `users`.",
+			},
+		},
+	}
+}
+
+func (d *ClusterExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemas, err := d.exportSchemas(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Exporting Pinot Schemas", "Could not read schemas", err)
+		return
+	}
+	tables, err := d.exportTables(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Exporting Pinot Tables", "Could not read tables", err)
+		return
+	}
+	users, err := d.client.ListUsers(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Exporting Pinot Users", "Could not list users", err)
+		return
+	}
+	for _, u := range users {
+		delete(u, "password")
+	}
+
+	exportJSON, err := canonicalJSON(map[string]interface{}{
+		"schemas": schemas,
+		"tables":  tables,
+		"users":   users,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error Marshaling Cluster Export", "Could not marshal the export to JSON: "+err.Error())
+		return
+	}
+
+	data.ID = types.StringValue("cluster_export")
+	data.JSON = jsontypes.NewNormalizedValue(exportJSON)
+	counts, diags := types.MapValueFrom(ctx, types.Int64Type, map[string]int64{
+		"schemas": int64(len(schemas)),
+		"tables":  int64(len(tables)),
+		"users":   int64(len(users)),
+	})
+	resp.Diagnostics.Append(diags...)
+	data.Counts = counts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// exportSchemas returns every schema keyed by name. Schemas deleted while the export runs are skipped.
+func (d *ClusterExportDataSource) exportSchemas(ctx context.Context) (map[string]interface{}, error) {
+	names, err := d.client.ListSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		s, err := d.client.GetSchema(ctx, name)
+		if client.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		out[name] = s
+	}
+	return out, nil
+}
+
+// exportTables returns every table config keyed by `<logical>_<TYPE>`, without SASL JAAS credentials and with
+// the credentials in its stream and batch config maps redacted. Tables deleted while the export runs are skipped.
+func (d *ClusterExportDataSource) exportTables(ctx context.Context) (map[string]interface{}, error) {
+	names, err := d.client.ListTableNames(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		cfg, err := d.client.GetTable(ctx, name)
+		if client.IsNotFound(err) || (err == nil && len(cfg) == 0) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+		cfg = cleanTableConfigForState(cfg, nil, false)
+		redactConfigMapCredentials(cfg)
+		out[name] = cfg
+	}
+	return out, nil
+}
+
+// redactConfigMapCredentials replaces the values of credential keys in every stream and batch config map of cfg.
+func redactConfigMapCredentials(cfg TableConfig) {
+	maps := streamConfigMapsOf(cfg)
+	ingestion, _ := cfg["ingestionConfig"].(map[string]interface{})
+	batchIngestion, _ := ingestion["batchIngestionConfig"].(map[string]interface{})
+	batchMaps, _ := batchIngestion["batchConfigMaps"].([]interface{})
+	for _, el := range batchMaps {
+		if m, ok := el.(map[string]interface{}); ok {
+			maps = append(maps, m)
+		}
+	}
+	for _, m := range maps {
+		for k := range m {
+			if isConfigMapCredentialKey(k) {
+				m[k] = redactedValue
+			}
+		}
+	}
+}
+
+// isConfigMapCredentialKey reports whether a stream or batch config key holds a credential: any `*.password`,
+// `basic.auth.user.info` of the schema registry, and the `sasl.*` settings.
+func isConfigMapCredentialKey(key string) bool {
+	k := strings.ToLower(key)
+	return strings.HasSuffix(k, ".password") || strings.HasSuffix(k, "basic.auth.user.info") || strings.HasPrefix(k, "sasl.")
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactConfigMapCredentials(t *testing.T) {
+	cfg := mustJSONMap(t, `{
+		"ingestionConfig": {
+			"streamIngestionConfig": {"streamConfigMaps": [{
+				"stream.kafka.topic.name": "events",
+				"sasl.mechanism": "SCRAM-SHA-512",
+				"stream.kafka.decoder.prop.schema.registry.basic.auth.user.info": "user:secret",
+				"ssl.truststore.password": "secret"
+			}]},
+			"batchIngestionConfig": {"batchConfigMaps": [{"inputDirURI": "s3://events", "jdbc.password": "secret"}]}
+		},
+		"tableIndexConfig": {"streamConfigs": {"sasl.jaas.config": "secret", "streamType": "kafka"}}
+	}`)
+	redactConfigMapCredentials(cfg)
+
+	want := mustJSONMap(t, `{
+		"ingestionConfig": {
+			"streamIngestionConfig": {"streamConfigMaps": [{
+				"stream.kafka.topic.name": "events",
+				"sasl.mechanism": "<redacted>",
+				"stream.kafka.decoder.prop.schema.registry.basic.auth.user.info": "<redacted>",
+				"ssl.truststore.password": "<redacted>"
+			}]},
+			"batchIngestionConfig": {"batchConfigMaps": [{"inputDirURI": "s3://events", "jdbc.password": "<redacted>"}]}
+		},
+		"tableIndexConfig": {"streamConfigs": {"sasl.jaas.config": "<redacted>", "streamType": "kafka"}}
+	}`)
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("redacted config = %v, want %v", cfg, want)
+	}
+}
@@ -0,0 +1,155 @@
+// internal/provider/controller_jobs_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &ControllerJobsDataSource{}
+
+type ControllerJobsDataSource struct {
+	client *client.PinotClient
+}
+
+type ControllerJobsDataSourceModel struct {
+	ID        types.String         `tfsdk:"id"`
+	TableName types.String         `tfsdk:"table_name"`
+	JobType   types.String         `tfsdk:"job_type"`
+	Jobs      []ControllerJobModel `tfsdk:"jobs"`
+}
+
+// ControllerJobModel is an asynchronous controller job on a table.
+type ControllerJobModel struct {
+	JobID            types.String `tfsdk:"job_id"`
+	JobType          types.String `tfsdk:"job_type"`
+	SubmissionTimeMs types.Int64  `tfsdk:"submission_time_ms"`
+	State            types.String `tfsdk:"state"`
+	Metadata         types.Map    `tfsdk:"metadata"` // map[string]string
+}
+
+func NewControllerJobsDataSource() datasource.DataSource {
+	return &ControllerJobsDataSource{}
+}
+
+func (d *ControllerJobsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_controller_jobs"
+}
+
+func (d *ControllerJobsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the asynchronous jobs (reloads, rebalances) the controller tracks for a Pinot table, in progress and completed, so pipelines can wait for them to finish.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier: `<table_name>` or `<table_name>/<job_type>`.",
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table name as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+			},
+			"job_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return jobs of this type, e.g. `RELOAD_ALL_SEGMENTS` or `TABLE_REBALANCE`.",
+			},
+			"jobs": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Jobs on the table, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"job_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Job ID.",
+						},
+						"job_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Job type, e.g. `RELOAD_ALL_SEGMENTS`, `RELOAD_SEGMENT` or `TABLE_REBALANCE`.",
+						},
+						"submission_time_ms": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "When the job was submitted, in epoch milliseconds.",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "`IN_PROGRESS` or `COMPLETED` for reloads; the rebalance status (e.g. `IN_PROGRESS`, `DONE`, `FAILED`) for rebalances; null when the controller reports none.",
+						},
+						"metadata": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "The job's metadata as stored by the controller.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ControllerJobsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ControllerJobsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ControllerJobsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tableName := data.TableName.ValueString()
+	if _, typ := splitTableID(tableName); typ == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_name"),
+			"Invalid Table Name",
+			"table_name must be in the form <logical>_OFFLINE or <logical>_REALTIME.",
+		)
+		return
+	}
+
+	jobs, err := d.client.GetControllerJobs(ctx, tableName)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Controller Jobs", "Could not read the controller jobs of table "+tableName, err)
+		return
+	}
+
+	data.ID = types.StringValue(tableName)
+	if !data.JobType.IsNull() {
+		data.ID = types.StringValue(tableName + "/" + data.JobType.ValueString())
+	}
+	data.Jobs = []ControllerJobModel{}
+	for _, j := range jobs {
+		if !data.JobType.IsNull() && j.JobType != data.JobType.ValueString() {
+			continue
+		}
+		metadata, diags := types.MapValueFrom(ctx, types.StringType, j.Metadata)
+		resp.Diagnostics.Append(diags...)
+		data.Jobs = append(data.Jobs, ControllerJobModel{
+			JobID:            types.StringValue(j.JobID),
+			JobType:          stringOrNull(j.JobType),
+			SubmissionTimeMs: types.Int64Value(j.SubmissionTimeMs),
+			State:            stringOrNull(j.State),
+			Metadata:         metadata,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
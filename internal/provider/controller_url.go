@@ -0,0 +1,40 @@
+// internal/provider/controller_url.go
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+// controllerURLDescription documents the per-resource controller override; it is shared by every resource schema.
+const controllerURLDescription = "URL of the Pinot Controller to manage this resource on, overriding the provider's `controller_url`. " +
+	"The provider's credentials (token or username/password) are sent to this controller too, so both clusters must accept them. " +
+	"Changing it forces a new resource."
+
+// controllerURLAttribute is the optional per-resource controller override.
+func controllerURLAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: controllerURLDescription,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// clientFor returns the provider client, or a client for controllerURL when the resource overrides it.
+// Resources rebind their client with it at the start of each CRUD method.
+func clientFor(base *client.PinotClient, controllerURL types.String) *client.PinotClient {
+	if base == nil || controllerURL.IsNull() || controllerURL.IsUnknown() {
+		return base
+	}
+	return base.WithControllerURL(controllerURL.ValueString())
+}
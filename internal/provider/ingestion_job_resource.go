@@ -0,0 +1,211 @@
+// internal/provider/ingestion_job_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &IngestionJobResource{}
+var _ resource.ResourceWithValidateConfig = &IngestionJobResource{}
+
+type IngestionJobResource struct {
+	client *client.PinotClient
+}
+
+type IngestionJobResourceModel struct {
+	ID            types.String         `tfsdk:"id"`
+	TableName     types.String         `tfsdk:"table_name"`
+	JobSpec       jsontypes.Normalized `tfsdk:"job_spec"`
+	FilePath      types.String         `tfsdk:"file_path"`
+	SourceURI     types.String         `tfsdk:"source_uri"`
+	Triggers      types.Map            `tfsdk:"triggers"`
+	Status        types.String         `tfsdk:"status"`
+	ControllerURL types.String         `tfsdk:"controller_url"`
+}
+
+func NewIngestionJobResource() resource.Resource {
+	return &IngestionJobResource{}
+}
+
+func (r *IngestionJobResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ingestion_job"
+}
+
+func (r *IngestionJobResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Ingests a file into a table through the controller's batch ingestion API (`/ingestFromFile` or `/ingestFromURI`), " +
+			"which builds and uploads a segment before it answers. Meant for small files; use a standalone ingestion job for large ones. " +
+			"The ingestion runs on create and whenever any argument changes; destroying the resource does nothing and leaves the segment in place.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the ingestion.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table to ingest into as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"job_spec": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "Batch config of the ingestion as a JSON object of strings, sent as `batchConfigMapStr` " +
+					"(e.g. `{\"inputFormat\": \"csv\", \"recordReader.prop.delimiter\": \"|\"}`).",
+				CustomType: jsontypes.NormalizedType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Local file to upload and ingest. Exactly one of `file_path` and `source_uri` must be set. Changes to the file's content are not detected; use `triggers` with `filesha256()` to re-ingest it.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source_uri")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_uri": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URI of a file the controller reads itself (e.g., `s3://bucket/events.csv`); the controller needs the matching file system configured.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that trigger a new ingestion when changed (e.g. a hash of the file).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status message the controller returned for the ingestion.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IngestionJobResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IngestionJobResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data IngestionJobResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.TableName.IsUnknown() {
+		if _, typ := splitTableID(data.TableName.ValueString()); typ == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("table_name"),
+				"Invalid Table Name",
+				"table_name must be in the form <logical>_OFFLINE or <logical>_REALTIME.",
+			)
+		}
+	}
+
+	if !data.JobSpec.IsUnknown() && !data.JobSpec.IsNull() {
+		var batchConfig map[string]string
+		if diags := data.JobSpec.Unmarshal(&batchConfig); diags.HasError() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("job_spec"),
+				"Invalid Job Spec",
+				"job_spec must be a JSON object whose values are all strings.",
+			)
+		}
+	}
+}
+
+func (r *IngestionJobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IngestionJobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &IngestionJobResource{client: clientFor(r.client, data.ControllerURL)}
+
+	var batchConfig map[string]string
+	resp.Diagnostics.Append(data.JobSpec.Unmarshal(&batchConfig)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := r.client.IngestFromFile(ctx, data.TableName.ValueString(), client.IngestionJobSpec{
+		BatchConfig: batchConfig,
+		FilePath:    data.FilePath.ValueString(),
+		SourceURI:   data.SourceURI.ValueString(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Ingesting Into Pinot Table", "Could not ingest into table "+data.TableName.ValueString(), err)
+		return
+	}
+
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	data.Status = types.StringValue(status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps state as-is; the controller does not track ingestions after they finish.
+func (r *IngestionJobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only runs when nothing but computed values could change, since every argument forces a new ingestion.
+func (r *IngestionJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state IngestionJobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	plan.Status = state.Status
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *IngestionJobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,260 @@
+// internal/provider/ingestion_pause_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &IngestionPauseResource{}
+
+// Per-table outcomes recorded in results.
+const (
+	ingestionPaused  = "paused"
+	ingestionResumed = "resumed"
+)
+
+type IngestionPauseResource struct {
+	client *client.PinotClient
+}
+
+type IngestionPauseResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Paused         types.Bool   `tfsdk:"paused"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	Tables         types.List   `tfsdk:"tables"`  // []string
+	Results        types.Map    `tfsdk:"results"` // map[string]string
+	ControllerURL  types.String `tfsdk:"controller_url"`
+}
+
+func NewIngestionPauseResource() resource.Resource {
+	return &IngestionPauseResource{}
+}
+
+func (r *IngestionPauseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ingestion_pause"
+}
+
+func (r *IngestionPauseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Pauses or resumes stream consumption of every REALTIME table in the cluster, e.g. for cluster-wide maintenance. " +
+			"The tables are listed and paused or resumed on create and whenever `paused` or `triggers` change. " +
+			"A table that fails does not stop the others; failures are reported as a warning and in `results`. " +
+			"Destroying the resource while `paused` is `true` resumes the tables it paused.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the last pause or resume.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"paused": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "`true` pauses consumption of every REALTIME table; set it back to `false` after the maintenance to resume them from where they stopped.",
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of tables paused or resumed at once. Defaults to `%d`.", defaultReloadConcurrency),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that pause or resume the tables again when changed, e.g. to pick up tables created since or retry failed ones.",
+			},
+			"tables": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "REALTIME tables found on the last pause or resume, as `<logical>_REALTIME`, sorted.",
+			},
+			"results": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Outcome per table of the last pause or resume: `paused`, `resumed`, or the error the controller returned.",
+			},
+		},
+	}
+}
+
+func (r *IngestionPauseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IngestionPauseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IngestionPauseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &IngestionPauseResource{client: clientFor(r.client, data.ControllerURL)}
+
+	r.apply(ctx, &resp.Diagnostics, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps state as-is; the outcome of the last pause or resume is not refreshed.
+func (r *IngestionPauseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *IngestionPauseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state IngestionPauseResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &IngestionPauseResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	if plan.Paused.Equal(state.Paused) && plan.Triggers.Equal(state.Triggers) {
+		plan.ID, plan.Tables, plan.Results = state.ID, state.Tables, state.Results
+	} else {
+		r.apply(ctx, &resp.Diagnostics, &plan)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *IngestionPauseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IngestionPauseResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.Paused.ValueBool() {
+		return
+	}
+	r = &IngestionPauseResource{client: clientFor(r.client, data.ControllerURL)}
+
+	tables := toStringSlice(ctx, &resp.Diagnostics, data.Tables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	results := r.setPaused(ctx, tables, false, data.MaxConcurrency)
+	if failed := failedTables(results); len(failed) > 0 {
+		resp.Diagnostics.AddError(
+			"Error Resuming Pinot Ingestion",
+			"Could not resume consumption of "+strings.Join(failed, "; ")+". Resume them with the controller API, or apply again to retry.",
+		)
+	}
+}
+
+// apply lists the REALTIME tables and pauses or resumes all of them as data.Paused says, recording the outcome
+// in data. Failures of single tables are added to diags as a warning.
+func (r *IngestionPauseResource) apply(ctx context.Context, diags *diag.Diagnostics, data *IngestionPauseResourceModel) {
+	if !r.client.SupportsPauseConsumption() {
+		diags.AddError(
+			"Unsupported Pinot Version",
+			fmt.Sprintf("Pausing and resuming consumption needs Pinot %s or later, but the controller runs %s.",
+				client.MinVersionPauseConsumption, r.client.ControllerVersion()),
+		)
+		return
+	}
+	tables, err := r.client.ListTableNames(ctx, "REALTIME")
+	if err != nil {
+		addAPIError(diags, "Error Listing Pinot Tables", "Could not list REALTIME tables", err)
+		return
+	}
+	results := r.setPaused(ctx, tables, data.Paused.ValueBool(), data.MaxConcurrency)
+
+	action := "resume"
+	if data.Paused.ValueBool() {
+		action = "pause"
+	}
+	if failed := failedTables(results); len(failed) > 0 {
+		diags.AddWarning(
+			"Some Pinot Tables Were Not Updated",
+			fmt.Sprintf("Could not %s consumption of %d of %d REALTIME tables: %s. Change triggers to retry.",
+				action, len(failed), len(tables), strings.Join(failed, "; ")),
+		)
+	}
+
+	var d diag.Diagnostics
+	data.Tables, d = types.ListValueFrom(ctx, types.StringType, tables)
+	diags.Append(d...)
+	data.Results, d = types.MapValueFrom(ctx, types.StringType, results)
+	diags.Append(d...)
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+}
+
+// setPaused pauses or resumes the given `<logical>_REALTIME` tables with bounded concurrency and returns the
+// outcome per table. It carries on past failures.
+func (r *IngestionPauseResource) setPaused(ctx context.Context, tables []string, paused bool, maxConcurrency types.Int64) map[string]string {
+	concurrency := defaultReloadConcurrency
+	if !maxConcurrency.IsNull() && !maxConcurrency.IsUnknown() {
+		concurrency = int(maxConcurrency.ValueInt64())
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]string, len(tables))
+		sem     = make(chan struct{}, concurrency)
+	)
+	for _, id := range tables {
+		logical, _ := splitTableID(id)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id, logical string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			outcome, op := ingestionResumed, r.client.ResumeConsumption
+			if paused {
+				outcome, op = ingestionPaused, r.client.PauseConsumption
+			}
+			if err := op(ctx, logical); err != nil {
+				outcome = err.Error()
+			}
+			mu.Lock()
+			results[id] = outcome
+			mu.Unlock()
+		}(id, logical)
+	}
+	wg.Wait()
+	return results
+}
+
+// failedTables returns "<table>: <error>" for every table whose outcome is an error, sorted.
+func failedTables(results map[string]string) []string {
+	var failed []string
+	for id, outcome := range results {
+		if outcome != ingestionPaused && outcome != ingestionResumed {
+			failed = append(failed, id+": "+outcome)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestIngestionPauseContinuesOnError(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"tables":["clicks","events","views"]}`))
+			return
+		}
+		mu.Lock()
+		calls = append(calls, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/tables/events/pauseConsumption" {
+			http.Error(w, `{"code":500,"error":"Failed to pause events"}`, http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &IngestionPauseResource{client: c}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(t.Context(), IngestionPauseResourceModel{
+		ID:             types.StringUnknown(),
+		Paused:         types.BoolValue(true),
+		MaxConcurrency: types.Int64Value(2),
+		Triggers:       types.MapNull(types.StringType),
+		Tables:         types.ListUnknown(types.StringType),
+		Results:        types.MapUnknown(types.StringType),
+		ControllerURL:  types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("Plan.Set: %v", diags)
+	}
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "events_REALTIME") {
+		t.Errorf("warnings = %v, want one naming events_REALTIME", resp.Diagnostics.Warnings())
+	}
+	if len(calls) != 3 {
+		t.Errorf("calls = %v, want a pause for every table", calls)
+	}
+	var got IngestionPauseResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &got)...)
+	results := map[string]string{}
+	resp.Diagnostics.Append(got.Results.ElementsAs(t.Context(), &results, false)...)
+	if results["clicks_REALTIME"] != ingestionPaused || results["views_REALTIME"] != ingestionPaused ||
+		!strings.Contains(results["events_REALTIME"], "Failed to pause events") {
+		t.Errorf("results = %v", results)
+	}
+
+	calls = nil
+	delResp := fwresource.DeleteResponse{State: resp.State}
+	r.Delete(t.Context(), fwresource.DeleteRequest{State: resp.State}, &delResp)
+	if delResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", delResp.Diagnostics)
+	}
+	sort.Strings(calls)
+	want := []string{"/tables/clicks/resumeConsumption", "/tables/events/resumeConsumption", "/tables/views/resumeConsumption"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("delete calls = %v, want %v", calls, want)
+	}
+}
+
+func TestIngestionPauseUnsupportedVersion(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"tables":["events"]}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &IngestionPauseResource{client: c.WithControllerVersion("0.10.0")}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(t.Context(), IngestionPauseResourceModel{
+		ID:             types.StringUnknown(),
+		Paused:         types.BoolValue(true),
+		MaxConcurrency: types.Int64Null(),
+		Triggers:       types.MapNull(types.StringType),
+		Tables:         types.ListUnknown(types.StringType),
+		Results:        types.MapUnknown(types.StringType),
+		ControllerURL:  types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("Plan.Set: %v", diags)
+	}
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "controller runs 0.10.0") {
+		t.Errorf("diags = %v, want an unsupported version error", resp.Diagnostics)
+	}
+	if requests != 0 {
+		t.Errorf("controller got %d requests, want none", requests)
+	}
+}
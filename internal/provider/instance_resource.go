@@ -0,0 +1,179 @@
+// internal/provider/instance_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &InstanceResource{}
+var _ resource.ResourceWithImportState = &InstanceResource{}
+
+type InstanceResource struct {
+	client *client.PinotClient
+}
+
+type InstanceResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	InstanceName  types.String `tfsdk:"instance_name"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	ControllerURL types.String `tfsdk:"controller_url"`
+}
+
+func NewInstanceResource() resource.Resource {
+	return &InstanceResource{}
+}
+
+func (r *InstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the admin state of an existing Pinot instance. The instance must already be registered with the controller; destroying this resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Instance identifier (same as `instance_name`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Instance name (e.g., `Server_pinot-server-0_8098`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the instance is enabled. Set to `false` to drain the instance before maintenance. Defaults to the current controller state.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *InstanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *InstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data InstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &InstanceResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.applyAdminState(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Setting Pinot Instance State",
+			"Could not set instance state, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.InstanceName.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InstanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &InstanceResource{client: clientFor(r.client, data.ControllerURL)}
+
+	enabled, err := r.client.GetInstanceState(ctx, data.InstanceName.ValueString())
+	if err != nil {
+		// If the instance has been dropped from the cluster, drop state.
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Pinot Instance",
+			"Could not read instance "+data.InstanceName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.InstanceName.ValueString())
+	data.Enabled = types.BoolValue(enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data InstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &InstanceResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.applyAdminState(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Setting Pinot Instance State",
+			"Could not set instance state, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the instance; it is never dropped from the cluster.
+func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("instance_name"), req, resp)
+}
+
+// applyAdminState pushes the configured enabled flag (if any) and refreshes it from the controller.
+func (r *InstanceResource) applyAdminState(ctx context.Context, data *InstanceResourceModel) error {
+	name := data.InstanceName.ValueString()
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
+		if err := r.client.SetInstanceAdminState(ctx, name, data.Enabled.ValueBool()); err != nil {
+			return err
+		}
+	}
+
+	enabled, err := r.client.GetInstanceState(ctx, name)
+	if err != nil {
+		return err
+	}
+	data.Enabled = types.BoolValue(enabled)
+	return nil
+}
@@ -0,0 +1,72 @@
+// internal/provider/json_diff.go
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// diffJSON returns the key-level differences between two JSON documents, one line per changed key, sorted by path.
+// Lines read `+ path: value` for added keys, `- path: value` for removed keys and `~ path: old => new` for changed ones.
+// Objects are compared key by key; arrays and scalars are compared as whole values.
+// Paths use the same syntax as injected_secrets, so keys containing dots are written as `['key']`.
+func diffJSON(before, after map[string]interface{}) []string {
+	var lines []string
+	diffJSONObjects("", before, after, func(_, line string) { lines = append(lines, line) })
+	sort.Slice(lines, func(i, j int) bool { return lines[i][2:] < lines[j][2:] })
+	return lines
+}
+
+// changedJSONPaths returns the paths of the keys diffJSON reports, unsorted.
+func changedJSONPaths(before, after map[string]interface{}) []string {
+	var paths []string
+	diffJSONObjects("", before, after, func(p, _ string) { paths = append(paths, p) })
+	return paths
+}
+
+// diffJSONObjects calls emit with the path and diff line of every changed key.
+func diffJSONObjects(prefix string, before, after map[string]interface{}, emit func(path, line string)) {
+	for k, b := range before {
+		p := jsonDiffPath(prefix, k)
+		a, ok := after[k]
+		if !ok {
+			emit(p, fmt.Sprintf("- %s: %s", p, jsonDiffValue(b)))
+			continue
+		}
+		bm, bIsObj := b.(map[string]interface{})
+		am, aIsObj := a.(map[string]interface{})
+		switch {
+		case bIsObj && aIsObj:
+			diffJSONObjects(p, bm, am, emit)
+		case !reflect.DeepEqual(b, a):
+			emit(p, fmt.Sprintf("~ %s: %s => %s", p, jsonDiffValue(b), jsonDiffValue(a)))
+		}
+	}
+	for k, a := range after {
+		if _, ok := before[k]; !ok {
+			p := jsonDiffPath(prefix, k)
+			emit(p, fmt.Sprintf("+ %s: %s", p, jsonDiffValue(a)))
+		}
+	}
+}
+
+func jsonDiffPath(prefix, key string) string {
+	if strings.ContainsAny(key, ".[]'") {
+		return prefix + "['" + key + "']"
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func jsonDiffValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
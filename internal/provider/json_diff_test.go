@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	before := mustJSONMap(t, `{
+		"segmentsConfig": {"replication": "2", "timeColumnName": "ts"},
+		"metadata": {"customConfigs": {"owner": "team-a"}},
+		"ingestionConfig": {"streamIngestionConfig": {"streamConfigMaps": [{"stream.kafka.topic.name": "events"}]}},
+		"tenants": {}
+	}`)
+	after := mustJSONMap(t, `{
+		"segmentsConfig": {"replication": "3", "timeColumnName": "ts"},
+		"metadata": {"customConfigs": {}},
+		"ingestionConfig": {"streamIngestionConfig": {"streamConfigMaps": [{"stream.kafka.topic.name": "events_v2"}]}},
+		"tenants": {"broker": "DefaultTenant"},
+		"tableIndexConfig": {"bloomFilterColumns": ["userId"]}
+	}`)
+
+	want := []string{
+		`~ ingestionConfig.streamIngestionConfig.streamConfigMaps: [{"stream.kafka.topic.name":"events"}] => [{"stream.kafka.topic.name":"events_v2"}]`,
+		`- metadata.customConfigs.owner: "team-a"`,
+		`~ segmentsConfig.replication: "2" => "3"`,
+		`+ tableIndexConfig: {"bloomFilterColumns":["userId"]}`,
+		`+ tenants.broker: "DefaultTenant"`,
+	}
+	if got := diffJSON(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+
+	if got := diffJSON(before, before); len(got) != 0 {
+		t.Errorf("expected no diff for identical documents, got %q", got)
+	}
+
+	if got := diffJSON(map[string]interface{}{}, mustJSONMap(t, `{"a": {"sasl.jaas.config": "x"}}`)); !reflect.DeepEqual(got, []string{`+ a: {"sasl.jaas.config":"x"}`}) {
+		t.Errorf("unexpected diff %q", got)
+	}
+	if got := diffJSON(mustJSONMap(t, `{"a": {"sasl.jaas.config": "x"}}`), mustJSONMap(t, `{"a": {"sasl.jaas.config": "y"}}`)); !reflect.DeepEqual(got, []string{`~ a['sasl.jaas.config']: "x" => "y"`}) {
+		t.Errorf("unexpected diff %q", got)
+	}
+}
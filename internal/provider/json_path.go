@@ -0,0 +1,248 @@
+// internal/provider/json_path.go
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is one step of a parsed JSON path: an object key, an array index, or the `[*]` wildcard.
+type jsonPathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONPath parses a small JSONPath subset:
+//
+//	$.ingestionConfig.streamIngestionConfig.streamConfigMaps[0]['sasl.jaas.config']
+//
+// The leading `$` is optional. Keys containing dots must use the bracket form (`['key']` or `["key"]`).
+// Paths must start and end with an object key so that injected values can always be stripped again.
+func parseJSONPath(p string) ([]jsonPathSegment, error) {
+	s := strings.TrimSpace(p)
+	s = strings.TrimPrefix(s, "$")
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return nil, fmt.Errorf("json path %q is empty", p)
+	}
+
+	var segs []jsonPathSegment
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '.':
+			i++
+			if i >= len(s) || s[i] == '.' || s[i] == '[' {
+				return nil, fmt.Errorf("json path %q has an empty key at offset %d", p, i)
+			}
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("json path %q has an unterminated bracket at offset %d", p, i)
+			}
+			inner := s[i+1 : i+end]
+			if q := s[i+1]; q == '\'' || q == '"' {
+				// Quoted keys may contain ']' so look for the closing quote first.
+				closeQuote := strings.IndexByte(s[i+2:], q)
+				if closeQuote < 0 || i+2+closeQuote+1 >= len(s) || s[i+2+closeQuote+1] != ']' {
+					return nil, fmt.Errorf("json path %q has an unterminated quoted key at offset %d", p, i)
+				}
+				key := s[i+2 : i+2+closeQuote]
+				if key == "" {
+					return nil, fmt.Errorf("json path %q has an empty key at offset %d", p, i)
+				}
+				segs = append(segs, jsonPathSegment{key: key})
+				i += 2 + closeQuote + 2
+				continue
+			}
+			switch {
+			case inner == "*":
+				segs = append(segs, jsonPathSegment{wildcard: true})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("json path %q has an invalid array index %q", p, inner)
+				}
+				segs = append(segs, jsonPathSegment{index: n, isIndex: true})
+			}
+			i += end + 1
+		default:
+			end := strings.IndexAny(s[i:], ".[")
+			if end < 0 {
+				end = len(s) - i
+			}
+			segs = append(segs, jsonPathSegment{key: s[i : i+end]})
+			i += end
+		}
+	}
+
+	if segs[0].isIndex || segs[0].wildcard {
+		return nil, fmt.Errorf("json path %q must start with an object key", p)
+	}
+	if last := segs[len(segs)-1]; last.isIndex || last.wildcard {
+		return nil, fmt.Errorf("json path %q must end with an object key", p)
+	}
+	return segs, nil
+}
+
+// setJSONPath sets value at path inside doc, creating intermediate objects and arrays as needed.
+// An index equal to the array length appends; `[*]` sets the value on every existing element.
+func setJSONPath(doc map[string]interface{}, p string, value interface{}) error {
+	segs, err := parseJSONPath(p)
+	if err != nil {
+		return err
+	}
+	_, err = setAtPath(doc, segs, value)
+	if err != nil {
+		return fmt.Errorf("json path %q: %w", p, err)
+	}
+	return nil
+}
+
+func setAtPath(node interface{}, segs []jsonPathSegment, value interface{}) (interface{}, error) {
+	if len(segs) == 0 {
+		return value, nil
+	}
+
+	seg := segs[0]
+	switch {
+	case seg.wildcard:
+		list, ok := node.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("[*] requires an existing array")
+		}
+		for i := range list {
+			v, err := setAtPath(list[i], segs[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+	case seg.isIndex:
+		list, _ := node.([]interface{})
+		if seg.index > len(list) {
+			return nil, fmt.Errorf("array index %d out of range (length %d)", seg.index, len(list))
+		}
+		if seg.index == len(list) {
+			list = append(list, nil)
+		}
+		v, err := setAtPath(list[seg.index], segs[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		list[seg.index] = v
+		return list, nil
+	default:
+		m, _ := node.(map[string]interface{})
+		if m == nil {
+			m = map[string]interface{}{}
+		}
+		v, err := setAtPath(m[seg.key], segs[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		m[seg.key] = v
+		return m, nil
+	}
+}
+
+// removeJSONPaths returns a deep copy of doc with the value at each path removed.
+// Paths that are invalid or do not match the document shape are ignored.
+func removeJSONPaths(doc map[string]interface{}, paths ...string) map[string]interface{} {
+	if doc == nil {
+		return nil
+	}
+
+	out, _ := deepCopyJSON(doc).(map[string]interface{})
+	for _, p := range paths {
+		segs, err := parseJSONPath(p)
+		if err != nil {
+			continue
+		}
+		removeAtPath(out, segs)
+	}
+	return out
+}
+
+func removeAtPath(node interface{}, segs []jsonPathSegment) {
+	if len(segs) == 0 {
+		return
+	}
+
+	seg := segs[0]
+	switch {
+	case seg.wildcard:
+		list, _ := node.([]interface{})
+		for _, el := range list {
+			removeAtPath(el, segs[1:])
+		}
+	case seg.isIndex:
+		list, _ := node.([]interface{})
+		if seg.index < len(list) {
+			removeAtPath(list[seg.index], segs[1:])
+		}
+	default:
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if len(segs) == 1 {
+			delete(m, seg.key)
+			return
+		}
+		removeAtPath(m[seg.key], segs[1:])
+	}
+}
+
+// deepCopyJSON copies the maps and slices produced by encoding/json so the result can be mutated freely.
+func deepCopyJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, el := range t {
+			out[k] = deepCopyJSON(el)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, el := range t {
+			out[i] = deepCopyJSON(el)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// lookupJSONPath returns the value at path inside doc and whether it exists. `[*]` is not supported here.
+func lookupJSONPath(doc map[string]interface{}, p string) (interface{}, bool) {
+	segs, err := parseJSONPath(p)
+	if err != nil {
+		return nil, false
+	}
+
+	var node interface{} = doc
+	for _, seg := range segs {
+		switch {
+		case seg.wildcard:
+			return nil, false
+		case seg.isIndex:
+			list, _ := node.([]interface{})
+			if seg.index >= len(list) {
+				return nil, false
+			}
+			node = list[seg.index]
+		default:
+			m, _ := node.(map[string]interface{})
+			v, ok := m[seg.key]
+			if !ok {
+				return nil, false
+			}
+			node = v
+		}
+	}
+	return node, true
+}
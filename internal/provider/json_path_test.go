@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustJSONMap(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	return m
+}
+
+func TestParseJSONPath(t *testing.T) {
+	cases := []struct {
+		path    string
+		want    []jsonPathSegment
+		wantErr bool
+	}{
+		{path: "a.b", want: []jsonPathSegment{{key: "a"}, {key: "b"}}},
+		{path: "$.a[0]['sasl.jaas.config']", want: []jsonPathSegment{{key: "a"}, {index: 0, isIndex: true}, {key: "sasl.jaas.config"}}},
+		{path: `a[*]["x]y"]`, want: []jsonPathSegment{{key: "a"}, {wildcard: true}, {key: "x]y"}}},
+		{path: "", wantErr: true},
+		{path: "a..b", wantErr: true},
+		{path: "a[0]", wantErr: true},
+		{path: "[0].a", wantErr: true},
+		{path: "a[x].b", wantErr: true},
+		{path: "a['b'", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := parseJSONPath(tc.path)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseJSONPath(%q): expected error, got %+v", tc.path, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseJSONPath(%q): unexpected error: %v", tc.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseJSONPath(%q) = %+v, want %+v", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestSetAndRemoveJSONPath(t *testing.T) {
+	doc := mustJSONMap(t, `{"ingestionConfig":{"batchIngestionConfig":{"batchConfigMaps":[{"inputDirURI":"s3://x"}]}}}`)
+	p := "ingestionConfig.batchIngestionConfig.batchConfigMaps[0]['jdbc.password']"
+
+	if err := setJSONPath(doc, p, "secret"); err != nil {
+		t.Fatalf("setJSONPath: %v", err)
+	}
+	want := mustJSONMap(t, `{"ingestionConfig":{"batchIngestionConfig":{"batchConfigMaps":[{"inputDirURI":"s3://x","jdbc.password":"secret"}]}}}`)
+	if !reflect.DeepEqual(doc, want) {
+		t.Fatalf("after set = %v, want %v", doc, want)
+	}
+
+	cleaned := removeJSONPaths(doc, p)
+	want = mustJSONMap(t, `{"ingestionConfig":{"batchIngestionConfig":{"batchConfigMaps":[{"inputDirURI":"s3://x"}]}}}`)
+	if !reflect.DeepEqual(cleaned, want) {
+		t.Fatalf("after remove = %v, want %v", cleaned, want)
+	}
+	if _, ok := doc["ingestionConfig"].(map[string]interface{})["batchIngestionConfig"].(map[string]interface{})["batchConfigMaps"].([]interface{})[0].(map[string]interface{})["jdbc.password"]; !ok {
+		t.Fatalf("removeJSONPaths mutated its input")
+	}
+
+	if err := setJSONPath(doc, "a[2].b", "x"); err == nil {
+		t.Fatalf("expected out-of-range index error")
+	}
+}
+
+func TestKafkaSaslRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		in   string
+		want string
+	}{
+		"map shape": {
+			in:   `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":{"streamType":"kafka"}}}}`,
+			want: `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":{"streamType":"kafka","sasl.jaas.config":"jaas"}}}}`,
+		},
+		"list shape": {
+			in:   `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"},{"streamType":"kafka"}]}}}`,
+			want: `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka","sasl.jaas.config":"jaas"},{"streamType":"kafka"}]}}}`,
+		},
+		"missing": {
+			in:   `{"tableName":"t_REALTIME"}`,
+			want: `{"tableName":"t_REALTIME","ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"sasl.jaas.config":"jaas"}]}}}`,
+		},
+	}
+
+	for name, tc := range cases {
+		cfg := mustJSONMap(t, tc.in)
+		injectKafkaSasl(&cfg, "jaas")
+		if want := mustJSONMap(t, tc.want); !reflect.DeepEqual(cfg, want) {
+			t.Errorf("%s: inject = %v, want %v", name, cfg, want)
+		}
+
+		cleaned := removeSaslJaasFromTableConfig(cfg)
+		b, err := json.Marshal(cleaned)
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", name, err)
+		}
+		if strings.Contains(string(b), "sasl.jaas.config") {
+			t.Errorf("%s: sasl.jaas.config not removed: %s", name, b)
+		}
+	}
+}
@@ -3,14 +3,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-pinot/internal/client"
 )
 
@@ -22,11 +29,33 @@ type PinotProvider struct {
 
 type PinotProviderModel struct {
 	ControllerURL types.String `tfsdk:"controller_url"`
+	Controllers   types.List   `tfsdk:"controller_urls"` // []string
+	APIPathPrefix types.String `tfsdk:"api_path_prefix"`
+	CheckVersion  types.Bool   `tfsdk:"check_controller_version"`
+	DialTimeout   types.String `tfsdk:"dial_timeout"`
+	Compress      types.Bool   `tfsdk:"compress_requests"`
+	TLSTimeout    types.String `tfsdk:"tls_handshake_timeout"`
+	ManagedByTag  types.String `tfsdk:"managed_by_tag"`
+	ManagedOwner  types.String `tfsdk:"managed_by_owner"`
+	MaxRetries    types.Int64  `tfsdk:"max_retries"`
+	ProxyURL      types.String `tfsdk:"proxy_url"`
+	ProtectTables types.Bool   `tfsdk:"protect_tables"`
+	ReadToken     types.String `tfsdk:"read_token"`
+	Timeout       types.String `tfsdk:"request_timeout"`
+	RetryWait     types.String `tfsdk:"retry_wait"`
+	WriteToken    types.String `tfsdk:"write_token"`
+	PinnedCerts   types.List   `tfsdk:"pinned_cert_sha256"` // []string
 	Username      types.String `tfsdk:"username"`
 	Password      types.String `tfsdk:"password"`
 	Token         types.String `tfsdk:"token"`
+	TokenFile     types.String `tfsdk:"token_file"`
+	ReloadToken   types.Bool   `tfsdk:"token_file_reload"`
+	TraceHeaders  types.List   `tfsdk:"trace_headers"` // []string
 }
 
+// defaultMaxRetries is the number of retries of a failed controller request when max_retries is not set.
+const defaultMaxRetries = 3
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &PinotProvider{
@@ -42,26 +71,126 @@ func (p *PinotProvider) Metadata(ctx context.Context, req provider.MetadataReque
 
 func (p *PinotProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Terraform provider for managing Apache Pinot resources",
+		Description: "Terraform provider for managing Apache Pinot resources. When PINOT_DATABASE is set, every controller request selects that database with the Database header.",
 		Attributes: map[string]schema.Attribute{
 			"controller_url": schema.StringAttribute{
 				Description: "URL of the Pinot Controller (e.g., http://localhost:9000)",
 				Optional:    true,
 			},
+			"controller_urls": schema.ListAttribute{
+				Description: "Other controllers of the same cluster, for HA setups where writes must go to the lead controller. " +
+					"When set, the provider detects the lead controller among these and `controller_url` and sends every write to it, " +
+					"detecting it again after a write fails; reads still go to `controller_url`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"api_path_prefix": schema.StringAttribute{
+				Description: "Path prefix prepended to every controller API path, for controllers exposed below the root of a gateway (e.g., /pinot turns /tables into /pinot/tables). Overrides PINOT_API_PATH_PREFIX. Defaults to no prefix.",
+				Optional:    true,
+			},
+			"managed_by_tag": schema.StringAttribute{
+				Description: "When set (e.g. `terraform`), tables written by the provider record it as `metadata.customConfigs[\"managed-by\"]` so audits can tell Terraform-managed tables apart. The key is kept in Pinot but not shown in `table_config`, unless `table_config` sets it itself, which takes precedence.",
+				Optional:    true,
+			},
+			"protect_tables": schema.BoolAttribute{
+				Description: "Refuse to delete tables, including deletes for replacement, unless the environment variable PINOT_ALLOW_DESTROY is `true` " +
+					"when Terraform runs. A guard against destroying production tables by accident that, unlike `lifecycle.prevent_destroy`, " +
+					"cannot be removed by editing a single resource. Defaults to `false`.",
+				Optional: true,
+			},
+			"managed_by_owner": schema.StringAttribute{
+				Description: "When set, tables written by the provider record it as `metadata.customConfigs[\"owner\"]`, in the same way as `managed_by_tag`.",
+				Optional:    true,
+			},
 			"username": schema.StringAttribute{
-				Description: "Username for Pinot authentication",
+				Description: "Username for Pinot authentication. Overrides PINOT_USERNAME.",
+				Optional:    true,
+			},
+			"check_controller_version": schema.BoolAttribute{
+				Description: "Check at configuration time that the controller is reachable and read its Pinot version (`GET /version`). " +
+					"Resources then report features the version lacks (e.g. pausing consumption before 0.11.0) as errors before calling the controller. " +
+					"The provider fails to configure when the controller cannot be reached. Defaults to `false`.",
+				Optional: true,
+			},
+			"compress_requests": schema.BoolAttribute{
+				Description: "Gzip JSON request bodies larger than 32 KiB, such as big table configs, and send them with Content-Encoding: gzip. " +
+					"If the controller answers 415 Unsupported Media Type, the request is sent again uncompressed and compression stays off for the rest of the run. Defaults to `false`.",
+				Optional: true,
+			},
+			"dial_timeout": schema.StringAttribute{
+				Description: "How long to wait for a TCP connection to the controller, as a Go duration (e.g., 5s). Separate from the timeout of a whole request. Defaults to 30s.",
 				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Password for Pinot authentication",
+				Description: "Password for Pinot authentication. Overrides PINOT_PASSWORD.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"pinned_cert_sha256": schema.ListAttribute{
+				Description: "SHA-256 fingerprints (hex, colons optional) of the controller's TLS certificate, e.g. from `openssl x509 -noout -fingerprint -sha256`. " +
+					"When set, the connection is trusted if the server certificate matches one of them, instead of verifying its chain and host name; " +
+					"useful for self-signed certificates on dev clusters. List the old and new fingerprints while a certificate rotates.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an HTTP, HTTPS or SOCKS5 proxy to send all controller requests through (e.g., http://proxy.corp:3128). Overrides HTTP_PROXY, HTTPS_PROXY and NO_PROXY, which are honored when it is not set.",
+				Optional:    true,
+			},
+			"read_token": schema.StringAttribute{
+				Description: "Authentication token for GET requests only, for least-privilege setups where reads and writes use different principals. " +
+					"Formatted like `token`. Overrides PINOT_READ_TOKEN; when neither is set, reads use `token` or basic auth.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("How often to retry a controller request that failed with 502, 503 or 504, e.g. while a load balancer rolls the controllers, or with a network error. "+
+					"GET, PUT and DELETE requests are retried; POST requests only when the connection could not be established, so they are never sent twice. "+
+					"0 disables retries. Defaults to %d.", defaultMaxRetries),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "How long a whole controller request may take, including reading the response, as a Go duration (e.g., 60s). " +
+					"Raise it for large table creations on a loaded controller. Overrides PINOT_REQUEST_TIMEOUT. Defaults to 30s.",
+				Optional: true,
+			},
+			"retry_wait": schema.StringAttribute{
+				Description: "Wait before the first retry of a failed request, as a Go duration (e.g., 2s). It doubles for every further retry, up to 30s, with random jitter. Defaults to 1s.",
+				Optional:    true,
+			},
+			"tls_handshake_timeout": schema.StringAttribute{
+				Description: "How long to wait for the TLS handshake with the controller once connected, as a Go duration (e.g., 5s). Defaults to 10s.",
+				Optional:    true,
+			},
 			"token": schema.StringAttribute{
-				Description: "Authentication token for Pinot",
+				Description: "Authentication token for Pinot. Overrides PINOT_TOKEN and takes precedence over basic auth; a PINOT_TOKEN from the environment is ignored when username or password is set in the configuration.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token_file": schema.StringAttribute{
+				Description: "Path of a file holding the authentication token, e.g. one a sidecar keeps rotated. Surrounding whitespace is ignored. Overrides PINOT_TOKEN_FILE; `token` takes precedence over it, and it takes precedence over PINOT_TOKEN and basic auth like `token` does.",
+				Optional:    true,
+			},
+			"token_file_reload": schema.BoolAttribute{
+				Description: "Re-read `token_file` before every request to pick up rotated tokens. Defaults to `false` (the file is read once when the provider is configured).",
+				Optional:    true,
+			},
+			"write_token": schema.StringAttribute{
+				Description: "Authentication token for every request other than GET (POST, PUT, PATCH, DELETE). " +
+					"Formatted like `token`. Overrides PINOT_WRITE_TOKEN; when neither is set, writes use `token` or basic auth.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"trace_headers": schema.ListAttribute{
+				Description: "Headers to propagate from the environment on every controller request, for distributed tracing (e.g., [\"traceparent\", \"tracestate\"]). " +
+					"Each value is read at request time from the header name upper-cased with dashes replaced by underscores (traceparent reads TRACEPARENT); unset variables are skipped. " +
+					"Headers the provider sets itself (Content-Type, Accept, Authorization, If-Match) take precedence. Overrides PINOT_TRACE_HEADERS, a comma-separated list.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -85,40 +214,236 @@ func (p *PinotProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
-	username := os.Getenv("PINOT_USERNAME")
-	password := os.Getenv("PINOT_PASSWORD")
-	token := os.Getenv("PINOT_TOKEN")
-	if !config.Username.IsNull() && config.Username.ValueString() != "" {
-		username = config.Username.ValueString()
+	apiPathPrefix := os.Getenv("PINOT_API_PATH_PREFIX")
+	if !config.APIPathPrefix.IsNull() {
+		apiPathPrefix = config.APIPathPrefix.ValueString()
 	}
-	if !config.Password.IsNull() && config.Password.ValueString() != "" {
-		password = config.Password.ValueString()
+
+	traceHeaders := strings.Split(os.Getenv("PINOT_TRACE_HEADERS"), ",")
+	if !config.TraceHeaders.IsNull() {
+		traceHeaders = toStringSlice(ctx, &resp.Diagnostics, config.TraceHeaders)
 	}
-	if !config.Token.IsNull() && config.Token.ValueString() != "" {
-		token = config.Token.ValueString()
+
+	creds, warnings := resolveCredentials(
+		credentials{
+			username:  config.Username.ValueString(),
+			password:  config.Password.ValueString(),
+			token:     config.Token.ValueString(),
+			tokenFile: config.TokenFile.ValueString(),
+		},
+		credentials{
+			username:  os.Getenv("PINOT_USERNAME"),
+			password:  os.Getenv("PINOT_PASSWORD"),
+			token:     os.Getenv("PINOT_TOKEN"),
+			tokenFile: os.Getenv("PINOT_TOKEN_FILE"),
+		},
+	)
+	for _, w := range warnings {
+		resp.Diagnostics.AddWarning("Conflicting Pinot Credentials", w)
 	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Always use token-aware constructor; token wins if present
-	c, err := client.NewPinotClientWithToken(controllerURL, username, password, token)
+	clientConfig := client.DefaultClientConfig()
+	clientConfig.PinnedCertSHA256 = toStringSlice(ctx, &resp.Diagnostics, config.PinnedCerts)
+	clientConfig.CompressRequests = config.Compress.ValueBool()
+	clientConfig.Retry.MaxRetries = defaultMaxRetries
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		clientConfig.Retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if d, ok := parseDurationAttribute(&resp.Diagnostics, "retry_wait", config.RetryWait); ok {
+		clientConfig.Retry.InitialBackoff = d
+	}
+	if d, ok := durationAttributeOrEnv(&resp.Diagnostics, "request_timeout", config.Timeout, "PINOT_REQUEST_TIMEOUT"); ok {
+		clientConfig.Timeout = d
+	}
+	if d, ok := parseDurationAttribute(&resp.Diagnostics, "dial_timeout", config.DialTimeout); ok {
+		clientConfig.DialTimeout = d
+	}
+	if d, ok := parseDurationAttribute(&resp.Diagnostics, "tls_handshake_timeout", config.TLSTimeout); ok {
+		clientConfig.TLSHandshakeTimeout = d
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c, err := client.NewPinotClientWithToken(controllerURL, creds.username, creds.password, creds.token, clientConfig)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Create Pinot Client", err.Error())
 		return
 	}
+	c = c.WithAPIPathPrefix(apiPathPrefix).WithManagedByTags(map[string]string{
+		managedByConfigKey: config.ManagedByTag.ValueString(),
+		ownerConfigKey:     config.ManagedOwner.ValueString(),
+	}).WithTableProtection(config.ProtectTables.ValueBool()).WithEnvHeaders(traceHeaders).WithControllers(toStringSlice(ctx, &resp.Diagnostics, config.Controllers))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.ProxyURL.IsNull() && config.ProxyURL.ValueString() != "" {
+		c, err = c.WithProxyURL(config.ProxyURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL", err.Error())
+			return
+		}
+	}
+	if creds.tokenFile != "" {
+		c, err = c.WithTokenFile(creds.tokenFile, config.ReloadToken.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_file"), "Unable to Read Pinot Token File", err.Error())
+			return
+		}
+	}
+	c = c.WithReadWriteTokens(
+		stringAttributeOrEnv(config.ReadToken, "PINOT_READ_TOKEN"),
+		stringAttributeOrEnv(config.WriteToken, "PINOT_WRITE_TOKEN"),
+	)
+	if config.CheckVersion.ValueBool() {
+		version, err := c.GetVersion(ctx)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Pinot Controller Check Failed", "Could not read the version of the controller at "+controllerURL, err)
+			return
+		}
+		tflog.Info(ctx, "Pinot controller version", map[string]interface{}{"version": version})
+		c = c.WithControllerVersion(version)
+	}
 	resp.DataSourceData = c
 	resp.ResourceData = c
 }
 
+// durationAttributeOrEnv is parseDurationAttribute for an attribute that falls back to the environment variable
+// env when it is not set. An invalid value from the environment is reported on the attribute and names env.
+func durationAttributeOrEnv(diags *diag.Diagnostics, attr string, v types.String, env string) (time.Duration, bool) {
+	if !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+		return parseDurationAttribute(diags, attr, v)
+	}
+	var envDiags diag.Diagnostics
+	d, ok := parseDurationAttribute(&envDiags, attr, types.StringValue(os.Getenv(env)))
+	for _, e := range envDiags.Errors() {
+		diags.AddAttributeError(path.Root(attr), e.Summary(), env+": "+e.Detail())
+	}
+	return d, ok
+}
+
+// stringAttributeOrEnv returns the value of a provider attribute, or of the environment variable env when the
+// attribute is not set.
+func stringAttributeOrEnv(v types.String, env string) string {
+	if !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+		return v.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// parseDurationAttribute parses the positive Go duration in the provider attribute attr. It reports false when
+// the attribute is not set or is invalid, in which case an error is added to diags.
+func parseDurationAttribute(diags *diag.Diagnostics, attr string, v types.String) (time.Duration, bool) {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(v.ValueString())
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("must be positive, got %s", v.ValueString())
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root(attr), "Invalid Duration", err.Error())
+		return 0, false
+	}
+	return d, true
+}
+
 func (p *PinotProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSchemaResource,
+		NewSchemaDirResource,
 		NewTableResource,
+		NewTableWithSchemaResource,
 		NewUserResource,
+		NewTenantResource,
+		NewInstanceResource,
+		NewTableReloadResource,
+		NewIngestionPauseResource,
+		NewTimeBoundaryResource,
+		NewTableInstanceAssignmentResource,
+		NewQueryDefaultsResource,
+		NewSegmentDeletionResource,
+		NewIngestionJobResource,
+		NewSegmentUploadResource,
+		NewTaskScheduleResource,
 	}
 }
 
 func (p *PinotProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAppConfigsDataSource,
+		NewSegmentsDataSource,
+		NewSegmentHealthDataSource,
+		NewTableTasksDataSource,
+		NewControllerJobsDataSource,
+		NewTablesDataSource,
+		NewTableDataSource,
+		NewClusterCapacityDataSource,
+		NewClusterExportDataSource,
+	}
+}
+
+// credentials holds one source of authentication settings (provider configuration or environment).
+type credentials struct {
+	username  string
+	password  string
+	token     string
+	tokenFile string
+}
+
+func (c credentials) hasBasicAuth() bool { return c.username != "" || c.password != "" }
+
+func (c credentials) hasToken() bool { return c.token != "" || c.tokenFile != "" }
+
+// tokenOnly returns just the token of c, preferring an inline token over a token file.
+func (c credentials) tokenOnly() credentials {
+	if c.token != "" {
+		return credentials{token: c.token}
+	}
+	return credentials{tokenFile: c.tokenFile}
+}
+
+// resolveCredentials picks the credentials to use. Each value set in the provider configuration beats the
+// matching PINOT_* environment variable, and a token beats basic auth, except that a token from the environment
+// never overrides basic auth set in the configuration. A token file counts as a token but loses to an inline
+// token from the same source. A warning is returned for every credential that is ignored.
+func resolveCredentials(config, env credentials) (credentials, []string) {
+	var warnings []string
+
+	if config.hasToken() {
+		if config.token != "" && config.tokenFile != "" {
+			warnings = append(warnings, "Both token and token_file are set; token is used and token_file is ignored.")
+		}
+		if config.hasBasicAuth() || env.hasBasicAuth() {
+			warnings = append(warnings, "Both token and username/password are set; the token from the provider configuration is used and basic auth is ignored.")
+		}
+		return config.tokenOnly(), warnings
+	}
+
+	basic := credentials{username: env.username, password: env.password}
+	if config.username != "" {
+		basic.username = config.username
+	}
+	if config.password != "" {
+		basic.password = config.password
+	}
+
+	if env.hasToken() {
+		if config.hasBasicAuth() {
+			warnings = append(warnings, "PINOT_TOKEN or PINOT_TOKEN_FILE is set but username/password are set in the provider configuration; "+
+				"the configured basic auth is used and the token is ignored.")
+			return basic, warnings
+		}
+		if env.token != "" && env.tokenFile != "" {
+			warnings = append(warnings, "Both PINOT_TOKEN and PINOT_TOKEN_FILE are set; PINOT_TOKEN is used and PINOT_TOKEN_FILE is ignored.")
+		}
+		if env.hasBasicAuth() {
+			warnings = append(warnings, "Both a token and PINOT_USERNAME/PINOT_PASSWORD are set in the environment; the token is used and basic auth is ignored.")
+		}
+		return env.tokenOnly(), warnings
+	}
+
+	return basic, nil
 }
@@ -4,8 +4,11 @@ package provider
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -20,8 +23,105 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("PINOT_CONTROLLER_URL must be set for acceptance tests")
 	}
 	if os.Getenv("PINOT_USERNAME") == "" || os.Getenv("PINOT_PASSWORD") == "" {
-		if os.Getenv("PINOT_TOKEN") == "" {
-			t.Skip("set PINOT_USERNAME and PINOT_PASSWORD or PINOT_TOKEN for acceptance tests")
+		if os.Getenv("PINOT_TOKEN") == "" && os.Getenv("PINOT_TOKEN_FILE") == "" {
+			t.Skip("set PINOT_USERNAME and PINOT_PASSWORD, PINOT_TOKEN or PINOT_TOKEN_FILE for acceptance tests")
 		}
 	}
 }
+
+func TestResolveCredentials(t *testing.T) {
+	cases := map[string]struct {
+		config, env  credentials
+		want         credentials
+		wantWarnings int
+	}{
+		"config basic auth only": {
+			config: credentials{username: "admin", password: "pw"},
+			want:   credentials{username: "admin", password: "pw"},
+		},
+		"config beats env per value": {
+			config: credentials{username: "admin"},
+			env:    credentials{username: "other", password: "envpw"},
+			want:   credentials{username: "admin", password: "envpw"},
+		},
+		"env token does not override config basic auth": {
+			config:       credentials{password: "pw"},
+			env:          credentials{username: "admin", token: "tok"},
+			want:         credentials{username: "admin", password: "pw"},
+			wantWarnings: 1,
+		},
+		"config token beats basic auth": {
+			config:       credentials{token: "tok"},
+			env:          credentials{username: "admin", password: "pw"},
+			want:         credentials{token: "tok"},
+			wantWarnings: 1,
+		},
+		"env token beats env basic auth": {
+			env:          credentials{username: "admin", password: "pw", token: "tok"},
+			want:         credentials{token: "tok"},
+			wantWarnings: 1,
+		},
+		"env token only": {
+			env:  credentials{token: "tok"},
+			want: credentials{token: "tok"},
+		},
+		"config token beats config token file": {
+			config:       credentials{token: "tok", tokenFile: "/run/token"},
+			want:         credentials{token: "tok"},
+			wantWarnings: 1,
+		},
+		"config token file beats env token": {
+			config: credentials{tokenFile: "/run/token"},
+			env:    credentials{token: "tok"},
+			want:   credentials{tokenFile: "/run/token"},
+		},
+		"env token file does not override config basic auth": {
+			config:       credentials{username: "admin", password: "pw"},
+			env:          credentials{tokenFile: "/run/token"},
+			want:         credentials{username: "admin", password: "pw"},
+			wantWarnings: 1,
+		},
+		"env token file only": {
+			env:  credentials{tokenFile: "/run/token"},
+			want: credentials{tokenFile: "/run/token"},
+		},
+	}
+
+	for name, tc := range cases {
+		got, warnings := resolveCredentials(tc.config, tc.env)
+		if got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", name, got, tc.want)
+		}
+		if len(warnings) != tc.wantWarnings {
+			t.Errorf("%s: got %d warnings (%v), want %d", name, len(warnings), warnings, tc.wantWarnings)
+		}
+	}
+}
+
+func TestDurationAttributeOrEnv(t *testing.T) {
+	const env = "PINOT_REQUEST_TIMEOUT"
+	cases := map[string]struct {
+		attr    types.String
+		env     string
+		want    time.Duration
+		wantOK  bool
+		wantErr bool
+	}{
+		"unset":                 {attr: types.StringNull()},
+		"attribute":             {attr: types.StringValue("60s"), env: "5s", want: time.Minute, wantOK: true},
+		"environment":           {attr: types.StringNull(), env: "5s", want: 5 * time.Second, wantOK: true},
+		"invalid attribute":     {attr: types.StringValue("soon"), wantErr: true},
+		"invalid environment":   {attr: types.StringNull(), env: "-1s", wantErr: true},
+		"attribute beats error": {attr: types.StringValue("2m"), env: "soon", want: 2 * time.Minute, wantOK: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(env, tc.env)
+			var diags diag.Diagnostics
+			got, ok := durationAttributeOrEnv(&diags, "request_timeout", tc.attr, env)
+			if got != tc.want || ok != tc.wantOK || diags.HasError() != tc.wantErr {
+				t.Errorf("got (%v, %v, %v), want (%v, %v, error %v)", got, ok, diags, tc.want, tc.wantOK, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// internal/provider/query_defaults_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &QueryDefaultsResource{}
+var _ resource.ResourceWithImportState = &QueryDefaultsResource{}
+
+// Cluster config keys the brokers read their default query options from.
+const (
+	queryTimeoutConfigKey    = "pinot.broker.timeoutMs"
+	queryMultistageConfigKey = "pinot.broker.use.multistage.engine"
+)
+
+// queryDefaultsID is the fixed id of the singleton pinot_query_defaults resource.
+const queryDefaultsID = "query_defaults"
+
+type QueryDefaultsResource struct {
+	client *client.PinotClient
+}
+
+type QueryDefaultsResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	TimeoutMs           types.Int64  `tfsdk:"timeout_ms"`
+	UseMultistageEngine types.Bool   `tfsdk:"use_multistage_engine"`
+	ControllerURL       types.String `tfsdk:"controller_url"`
+}
+
+func NewQueryDefaultsResource() resource.Resource {
+	return &QueryDefaultsResource{}
+}
+
+func (r *QueryDefaultsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_defaults"
+}
+
+func (r *QueryDefaultsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the cluster-wide default query options brokers apply when a query does not set them, " +
+			"stored as cluster configs (`/cluster/configs`). Only the attributes that are set are managed; " +
+			"removing an attribute or destroying the resource deletes its cluster config. Declare at most one per cluster.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("Always `%s`.", queryDefaultsID),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeout_ms": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Default query timeout in milliseconds (`%s`).", queryTimeoutConfigKey),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"use_multistage_engine": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Run queries on the multi-stage engine unless they opt out (`%s`).", queryMultistageConfigKey),
+			},
+		},
+	}
+}
+
+func (r *QueryDefaultsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *QueryDefaultsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data QueryDefaultsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &QueryDefaultsResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.apply(ctx, data, QueryDefaultsResourceModel{}); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Setting Pinot Query Defaults", "Could not update cluster configs", err)
+		return
+	}
+
+	data.ID = types.StringValue(queryDefaultsID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QueryDefaultsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data QueryDefaultsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &QueryDefaultsResource{client: clientFor(r.client, data.ControllerURL)}
+
+	configs, err := r.client.GetClusterConfigs(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Query Defaults", "Could not read cluster configs", err)
+		return
+	}
+
+	data.ID = types.StringValue(queryDefaultsID)
+	data.TimeoutMs = types.Int64Null()
+	if v, ok := configs[queryTimeoutConfigKey]; ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("timeout_ms"), "Invalid Pinot Query Default",
+				fmt.Sprintf("Cluster config %s has a non-integer value %q.", queryTimeoutConfigKey, v))
+			return
+		}
+		data.TimeoutMs = types.Int64Value(n)
+	}
+	data.UseMultistageEngine = types.BoolNull()
+	if v, ok := configs[queryMultistageConfigKey]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("use_multistage_engine"), "Invalid Pinot Query Default",
+				fmt.Sprintf("Cluster config %s has a non-boolean value %q.", queryMultistageConfigKey, v))
+			return
+		}
+		data.UseMultistageEngine = types.BoolValue(b)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QueryDefaultsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, prior QueryDefaultsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &QueryDefaultsResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	if err := r.apply(ctx, plan, prior); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Setting Pinot Query Defaults", "Could not update cluster configs", err)
+		return
+	}
+
+	plan.ID = types.StringValue(queryDefaultsID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *QueryDefaultsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data QueryDefaultsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &QueryDefaultsResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.apply(ctx, QueryDefaultsResourceModel{}, data); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Query Defaults", "Could not delete cluster configs", err)
+		return
+	}
+}
+
+func (r *QueryDefaultsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply sets the cluster configs for every attribute set in plan and deletes those that were set in prior only.
+func (r *QueryDefaultsResource) apply(ctx context.Context, plan, prior QueryDefaultsResourceModel) error {
+	set, remove := queryDefaultsConfigs(plan, prior)
+	if len(set) > 0 {
+		if err := r.client.UpdateClusterConfigs(ctx, set); err != nil {
+			return err
+		}
+	}
+	for _, key := range remove {
+		if err := r.client.DeleteClusterConfig(ctx, key); err != nil && !client.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryDefaultsConfigs returns the cluster configs to set for plan and the keys to delete because they
+// were set in prior but are no longer configured.
+func queryDefaultsConfigs(plan, prior QueryDefaultsResourceModel) (map[string]string, []string) {
+	set := map[string]string{}
+	var remove []string
+
+	if !plan.TimeoutMs.IsNull() {
+		set[queryTimeoutConfigKey] = strconv.FormatInt(plan.TimeoutMs.ValueInt64(), 10)
+	} else if !prior.TimeoutMs.IsNull() {
+		remove = append(remove, queryTimeoutConfigKey)
+	}
+	if !plan.UseMultistageEngine.IsNull() {
+		set[queryMultistageConfigKey] = strconv.FormatBool(plan.UseMultistageEngine.ValueBool())
+	} else if !prior.UseMultistageEngine.IsNull() {
+		remove = append(remove, queryMultistageConfigKey)
+	}
+	return set, remove
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestQueryDefaultsConfigs(t *testing.T) {
+	plan := QueryDefaultsResourceModel{
+		TimeoutMs:           types.Int64Value(15000),
+		UseMultistageEngine: types.BoolNull(),
+	}
+	prior := QueryDefaultsResourceModel{
+		TimeoutMs:           types.Int64Value(10000),
+		UseMultistageEngine: types.BoolValue(true),
+	}
+
+	set, remove := queryDefaultsConfigs(plan, prior)
+	if want := map[string]string{queryTimeoutConfigKey: "15000"}; !reflect.DeepEqual(set, want) {
+		t.Errorf("set = %v, want %v", set, want)
+	}
+	if want := []string{queryMultistageConfigKey}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("remove = %v, want %v", remove, want)
+	}
+}
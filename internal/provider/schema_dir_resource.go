@@ -0,0 +1,364 @@
+// internal/provider/schema_dir_resource.go
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &SchemaDirResource{}
+var _ resource.ResourceWithValidateConfig = &SchemaDirResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaDirResource{}
+
+type SchemaDirResource struct {
+	client *client.PinotClient
+}
+
+type SchemaDirResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Path          types.String `tfsdk:"path"`
+	Schemas       types.Map    `tfsdk:"schemas"` // map[string]string
+	ControllerURL types.String `tfsdk:"controller_url"`
+}
+
+func NewSchemaDirResource() resource.Resource {
+	return &SchemaDirResource{}
+}
+
+func (r *SchemaDirResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema_dir"
+}
+
+func (r *SchemaDirResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages every Pinot schema defined by the `*.json` files of a directory, one schema per file. " +
+			"Schemas of new files are created (or, when they already exist in Pinot, updated), changed files update their schema, " +
+			"and the schemas of removed files are deleted. Schemas not defined in the directory are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The directory path.",
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory holding the schema files. Only `*.json` files directly in it are read; each must hold one schema with a unique `schemaName`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"schemas": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "JSON of every managed schema, keyed by `schemaName`, as read from the files at plan time and refreshed from the controller.",
+			},
+		},
+	}
+}
+
+func (r *SchemaDirResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig reports every invalid schema file, each in its own diagnostic.
+func (r *SchemaDirResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SchemaDirResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Path.IsUnknown() || data.Path.IsNull() {
+		return
+	}
+
+	if _, errs := readSchemaDir(data.Path.ValueString()); len(errs) > 0 {
+		for _, err := range errs {
+			resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid Schema File", err.Error())
+		}
+	}
+}
+
+// ModifyPlan plans schemas from the directory's files so that a changed file shows up as a diff.
+func (r *SchemaDirResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan SchemaDirResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Path.IsUnknown() {
+		plan.ID = types.StringUnknown()
+		plan.Schemas = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	schemas, errs := readSchemaDir(plan.Path.ValueString())
+	for _, err := range errs {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid Schema File", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = plan.Path
+	plan.Schemas = schemaMapValue(schemas)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *SchemaDirResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SchemaDirResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &SchemaDirResource{client: clientFor(r.client, data.ControllerURL)}
+
+	r.apply(ctx, map[string]string{}, &data, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SchemaDirResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SchemaDirResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &SchemaDirResource{client: clientFor(r.client, data.ControllerURL)}
+
+	prior := schemaMapFromModel(ctx, &resp.Diagnostics, data.Schemas)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	current := map[string]string{}
+	for name, priorJSON := range prior {
+		remote, err := r.client.GetSchema(ctx, name)
+		if client.IsNotFound(err) {
+			// Deleted outside Terraform; dropping it from state makes the next apply create it again.
+			continue
+		}
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Reading Pinot Schema", "Could not read schema "+name, err)
+			return
+		}
+		var priorSchema SchemaConfig
+		if err := json.Unmarshal([]byte(priorJSON), &priorSchema); err != nil {
+			priorSchema = nil
+		}
+		remote = normalizeDefaultNullValues(remote, priorSchema)
+		remote = stripServerDefaultMaxLength(remote, priorSchema)
+		schemaJSON, err := canonicalJSON(remote)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Marshaling Schema", "Could not marshal schema "+name+" to JSON: "+err.Error())
+			return
+		}
+		current[name] = schemaJSON
+	}
+	data.Schemas = schemaMapValue(current)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SchemaDirResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SchemaDirResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &SchemaDirResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	prior := schemaMapFromModel(ctx, &resp.Diagnostics, state.Schemas)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, prior, &plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SchemaDirResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SchemaDirResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &SchemaDirResource{client: clientFor(r.client, data.ControllerURL)}
+
+	prior := schemaMapFromModel(ctx, &resp.Diagnostics, data.Schemas)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, name := range sortedKeys(prior) {
+		if err := r.client.DeleteSchema(ctx, name); err != nil && !client.IsNotFound(err) {
+			addAPIError(&resp.Diagnostics, "Error Deleting Pinot Schema", "Could not delete schema "+name, err)
+		}
+	}
+}
+
+// apply reconciles the controller with the planned schemas: it creates or updates every planned schema that
+// differs from prior and deletes the schemas of prior that are no longer planned. Schemas already in Pinot
+// but not in prior are updated rather than created. data.Schemas is set to what was applied, so a partly
+// failed apply records the schemas that did change; each failure gets its own diagnostic.
+func (r *SchemaDirResource) apply(ctx context.Context, prior map[string]string, data *SchemaDirResourceModel, diags *diag.Diagnostics) {
+	desired := schemaMapFromModel(ctx, diags, data.Schemas)
+	if diags.HasError() {
+		return
+	}
+	data.ID = data.Path
+
+	existing, err := r.client.ListSchemas(ctx)
+	if err != nil {
+		addAPIError(diags, "Error Listing Pinot Schemas", "Could not list schemas", err)
+		data.Schemas = schemaMapValue(prior)
+		return
+	}
+
+	applied := map[string]string{}
+	for name, schemaJSON := range prior {
+		applied[name] = schemaJSON
+	}
+	for _, name := range sortedKeys(desired) {
+		if prior[name] == desired[name] {
+			continue
+		}
+		var schemaConfig SchemaConfig
+		if err := json.Unmarshal([]byte(desired[name]), &schemaConfig); err != nil {
+			diags.AddError("Invalid Schema", fmt.Sprintf("Schema %s is not valid JSON: %s", name, err))
+			continue
+		}
+		if _, ok := prior[name]; ok || containsString(existing, name) {
+			err = r.client.UpdateSchema(ctx, schemaConfig)
+		} else {
+			err = r.client.CreateSchema(ctx, schemaConfig)
+		}
+		if err != nil {
+			addAPIError(diags, "Error Applying Pinot Schema", "Could not apply schema "+name, err)
+			continue
+		}
+		applied[name] = desired[name]
+	}
+	for _, name := range sortedKeys(prior) {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		if err := r.client.DeleteSchema(ctx, name); err != nil && !client.IsNotFound(err) {
+			addAPIError(diags, "Error Deleting Pinot Schema", "Could not delete schema "+name, err)
+			continue
+		}
+		delete(applied, name)
+	}
+	data.Schemas = schemaMapValue(applied)
+}
+
+// readSchemaDir reads every *.json file directly in dir and returns the canonical JSON of each schema keyed
+// by schemaName, along with one error per invalid file.
+func readSchemaDir(dir string) (map[string]string, []error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err == nil && len(files) == 0 {
+		if info, statErr := os.Stat(dir); statErr != nil {
+			err = statErr
+		} else if !info.IsDir() {
+			err = fmt.Errorf("%s is not a directory", dir)
+		}
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+	sort.Strings(files)
+
+	schemas := map[string]string{}
+	fileOf := map[string]string{}
+	var errs []error
+	for _, file := range files {
+		name, schemaJSON, err := readSchemaFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		if other, ok := fileOf[name]; ok {
+			errs = append(errs, fmt.Errorf("%s: schema %q is already defined in %s", file, name, other))
+			continue
+		}
+		fileOf[name] = file
+		schemas[name] = schemaJSON
+	}
+	return schemas, errs
+}
+
+// readSchemaFile parses and checks one schema file and returns its schemaName and canonical JSON.
+func readSchemaFile(file string) (string, string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", "", err
+	}
+	var schemaConfig SchemaConfig
+	if err := json.Unmarshal(b, &schemaConfig); err != nil {
+		return "", "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	name, _ := schemaConfig["schemaName"].(string)
+	if name == "" {
+		return "", "", errors.New("schemaName is missing")
+	}
+	if dups := duplicateColumnNames(schemaConfig); len(dups) > 0 {
+		return "", "", fmt.Errorf("column %q is defined more than once (in %s)", dups[0].name, strings.Join(dups[0].sections, ", "))
+	}
+	schemaJSON, err := canonicalJSON(schemaConfig)
+	if err != nil {
+		return "", "", err
+	}
+	return name, schemaJSON, nil
+}
+
+// schemaMapFromModel decodes the schemas map; an unknown or null map yields no schemas.
+func schemaMapFromModel(ctx context.Context, diags *diag.Diagnostics, m types.Map) map[string]string {
+	out := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return out
+	}
+	diags.Append(m.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
+func schemaMapValue(schemas map[string]string) types.Map {
+	elems := make(map[string]attr.Value, len(schemas))
+	for name, schemaJSON := range schemas {
+		elems[name] = types.StringValue(schemaJSON)
+	}
+	return types.MapValueMust(types.StringType, elems)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
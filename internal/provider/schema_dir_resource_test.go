@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadSchemaDir(t *testing.T) {
+	dir := writeSchemaFiles(t, map[string]string{
+		"events.json": `{"schemaName": "events", "dimensionFieldSpecs": [{"name": "id", "dataType": "STRING"}]}`,
+		"orders.json": `{"schemaName":"orders"}`,
+		"README.md":   `not a schema`,
+	})
+	schemas, errs := readSchemaDir(dir)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := map[string]string{
+		"events": `{"dimensionFieldSpecs":[{"dataType":"STRING","name":"id"}],"schemaName":"events"}`,
+		"orders": `{"schemaName":"orders"}`,
+	}
+	if !reflect.DeepEqual(schemas, want) {
+		t.Errorf("schemas = %v, want %v", schemas, want)
+	}
+
+	dir = writeSchemaFiles(t, map[string]string{
+		"a.json":        `{"schemaName":"events"}`,
+		"b.json":        `{"schemaName":"events"}`,
+		"broken.json":   `{"schemaName":`,
+		"nameless.json": `{"dimensionFieldSpecs":[]}`,
+		"dup.json":      `{"schemaName":"dup","dimensionFieldSpecs":[{"name":"x"}],"metricFieldSpecs":[{"name":"x"}]}`,
+	})
+	schemas, errs = readSchemaDir(dir)
+	if len(errs) != 4 {
+		t.Fatalf("errors = %v, want one per invalid file", errs)
+	}
+	for _, file := range []string{"b.json", "broken.json", "nameless.json", "dup.json"} {
+		found := false
+		for _, err := range errs {
+			found = found || strings.Contains(err.Error(), file)
+		}
+		if !found {
+			t.Errorf("no error names %s: %v", file, errs)
+		}
+	}
+	if _, ok := schemas["events"]; !ok || len(schemas) != 1 {
+		t.Errorf("valid schemas = %v", schemas)
+	}
+
+	if _, errs := readSchemaDir(filepath.Join(dir, "missing")); len(errs) != 1 {
+		t.Errorf("missing directory: errors = %v", errs)
+	}
+}
+
+func TestSchemaDirApply(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodGet && r.URL.Path == "/schemas" {
+			_, _ = w.Write([]byte(`["orders","stale","unmanaged"]`))
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &SchemaDirResource{client: c}
+
+	prior := map[string]string{
+		"stale":   `{"schemaName":"stale"}`,
+		"same":    `{"schemaName":"same"}`,
+		"changed": `{"schemaName":"changed"}`,
+	}
+	data := SchemaDirResourceModel{
+		Path: types.StringValue("schemas"),
+		Schemas: schemaMapValue(map[string]string{
+			"same":    `{"schemaName":"same"}`,
+			"changed": `{"metricFieldSpecs":[],"schemaName":"changed"}`,
+			"new":     `{"schemaName":"new"}`,
+			"orders":  `{"schemaName":"orders"}`,
+		}),
+	}
+	var diags diag.Diagnostics
+	r.apply(t.Context(), prior, &data, &diags)
+	if diags.HasError() {
+		t.Fatalf("apply: %v", diags)
+	}
+	want := []string{"PUT /schemas/changed", "POST /schemas", "PUT /schemas/orders", "DELETE /schemas/stale"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if got := schemaMapFromModel(t.Context(), &diags, data.Schemas); len(got) != 4 || got["stale"] != "" {
+		t.Errorf("applied schemas = %v", got)
+	}
+	if data.ID.ValueString() != "schemas" {
+		t.Errorf("id = %v", data.ID)
+	}
+}
@@ -3,8 +3,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -18,18 +20,25 @@ import (
 
 var _ resource.Resource = &SchemaResource{}
 var _ resource.ResourceWithImportState = &SchemaResource{}
+var _ resource.ResourceWithValidateConfig = &SchemaResource{}
+var _ resource.ResourceWithModifyPlan = &SchemaResource{}
 
 type SchemaResource struct {
 	client *client.PinotClient
 }
 
 type SchemaResourceModel struct {
-	ID         types.String         `tfsdk:"id"`
-	SchemaName types.String         `tfsdk:"schema_name"`
-	Schema     jsontypes.Normalized `tfsdk:"schema"`
+	ID            types.String         `tfsdk:"id"`
+	SchemaName    types.String         `tfsdk:"schema_name"`
+	Schema        jsontypes.Normalized `tfsdk:"schema"`
+	ControllerURL types.String         `tfsdk:"controller_url"`
+	AllowIncompat types.Bool           `tfsdk:"allow_incompatible_schema_changes"`
 }
 
-// Pinot schema JSON structure.
+// Treat the schema as a passthrough JSON object so fields the provider doesn't model are not dropped.
+type SchemaConfig = map[string]interface{}
+
+// Pinot schema JSON structure, used for validation only.
 type PinotSchema struct {
 	SchemaName            string         `json:"schemaName"`
 	EnableColumnBasedNull bool           `json:"enableColumnBasedNullHandling,omitempty"`
@@ -64,6 +73,7 @@ func (r *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a Pinot schema configuration",
 		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Schema identifier",
@@ -83,6 +93,10 @@ func (r *SchemaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "JSON configuration of the Pinot schema",
 				CustomType:          jsontypes.NormalizedType{},
 			},
+			"allow_incompatible_schema_changes": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, allow updates that change the `dataType` of an existing column to an incompatible type (or switch it between single- and multi-value), which breaks existing segments. Defaults to `false`.",
+			},
 		},
 	}
 }
@@ -104,6 +118,85 @@ func (r *SchemaResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+func (r *SchemaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SchemaResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The schema may be unknown until apply (e.g. templated from other resources).
+	if data.Schema.IsNull() || data.Schema.IsUnknown() {
+		return
+	}
+
+	var schemaConfig SchemaConfig
+	resp.Diagnostics.Append(data.Schema.Unmarshal(&schemaConfig)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, dup := range duplicateColumnNames(schemaConfig) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Duplicate Schema Column",
+			fmt.Sprintf("Column %q is defined more than once (in %s); each column must appear in exactly one field spec.",
+				dup.name, strings.Join(dup.sections, ", ")),
+		)
+	}
+
+	specs, _ := schemaConfig["dateTimeFieldSpecs"].([]interface{})
+	for _, el := range specs {
+		spec, ok := el.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := spec["name"].(string)
+		format, _ := spec["format"].(string)
+		if err := validateDateTimeFormat(format); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("schema"),
+				"Invalid Date-Time Format",
+				fmt.Sprintf("Date-time column %q has an invalid format %q: %s.", name, format, err),
+			)
+		}
+	}
+}
+
+// ModifyPlan rejects updates that change an existing column's type incompatibly, unless allow_incompatible_schema_changes is set.
+func (r *SchemaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, prior SchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.AllowIncompat.ValueBool() || plan.Schema.IsUnknown() || plan.Schema.IsNull() || prior.Schema.IsNull() {
+		return
+	}
+
+	var planned, current SchemaConfig
+	resp.Diagnostics.Append(plan.Schema.Unmarshal(&planned)...)
+	resp.Diagnostics.Append(prior.Schema.Unmarshal(&current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, c := range incompatibleColumnChanges(current, planned) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Incompatible Schema Change",
+			fmt.Sprintf("Column %q changes from %s to %s, which breaks existing segments. "+
+				"Add a new column instead, or set allow_incompatible_schema_changes = true to apply it anyway.",
+				c.name, c.from, c.to),
+		)
+	}
+}
+
 func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SchemaResourceModel
 
@@ -111,6 +204,7 @@ func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &SchemaResource{client: clientFor(r.client, data.ControllerURL)}
 
 	// Parse and validate the JSON schema
 	var pinotSchema PinotSchema
@@ -120,6 +214,13 @@ func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Send the user's JSON as-is.
+	var schemaConfig SchemaConfig
+	resp.Diagnostics.Append(data.Schema.Unmarshal(&schemaConfig)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Ensure schema name matches
 	if data.SchemaName.ValueString() != pinotSchema.SchemaName {
 		resp.Diagnostics.AddError(
@@ -131,12 +232,9 @@ func (r *SchemaResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create schema via API
-	err := r.client.CreateSchema(ctx, &pinotSchema)
+	err := r.client.CreateSchema(ctx, schemaConfig)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Pinot Schema",
-			"Could not create schema, unexpected error: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Creating Pinot Schema", "Could not create schema "+pinotSchema.SchemaName, err)
 		return
 	}
 
@@ -152,19 +250,33 @@ func (r *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &SchemaResource{client: clientFor(r.client, data.ControllerURL)}
 
 	// Get schema from API
 	schema, err := r.client.GetSchema(ctx, data.SchemaName.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Pinot Schema",
-			"Could not read schema ID "+data.ID.ValueString()+": "+err.Error(),
-		)
+		// The schema was deleted outside Terraform; drop it from state so it is recreated.
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Schema", "Could not read schema ID "+data.ID.ValueString(), err)
 		return
 	}
 
+	// Keep the default null value key the user wrote; Pinot may echo back the other spelling.
+	var priorSchema SchemaConfig
+	if !data.Schema.IsNull() && !data.Schema.IsUnknown() {
+		resp.Diagnostics.Append(data.Schema.Unmarshal(&priorSchema)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	schema = normalizeDefaultNullValues(schema, priorSchema)
+	schema = stripServerDefaultMaxLength(schema, priorSchema)
+
 	// Update the schema JSON
-	schemaJSON, err := json.Marshal(schema)
+	schemaJSON, err := canonicalJSON(schema)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Marshaling Schema",
@@ -173,7 +285,7 @@ func (r *SchemaResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	data.Schema = jsontypes.NewNormalizedValue(string(schemaJSON))
+	data.Schema = jsontypes.NewNormalizedValue(schemaJSON)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -185,22 +297,20 @@ func (r *SchemaResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &SchemaResource{client: clientFor(r.client, data.ControllerURL)}
 
 	// Parse the updated schema
-	var pinotSchema PinotSchema
-	diags := data.Schema.Unmarshal(&pinotSchema)
+	var schemaConfig SchemaConfig
+	diags := data.Schema.Unmarshal(&schemaConfig)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Update schema via API
-	err := r.client.UpdateSchema(ctx, &pinotSchema)
+	err := r.client.UpdateSchema(ctx, schemaConfig)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Pinot Schema",
-			"Could not update schema, unexpected error: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Updating Pinot Schema", "Could not update schema "+data.SchemaName.ValueString(), err)
 		return
 	}
 
@@ -214,13 +324,11 @@ func (r *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &SchemaResource{client: clientFor(r.client, data.ControllerURL)}
 
 	err := r.client.DeleteSchema(ctx, data.SchemaName.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Pinot Schema",
-			"Could not delete schema, unexpected error: "+err.Error(),
-		)
+	if err != nil && !client.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Schema", "Could not delete schema "+data.SchemaName.ValueString(), err)
 		return
 	}
 }
@@ -228,3 +336,320 @@ func (r *SchemaResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *SchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("schema_name"), req, resp)
 }
+
+// ---- helpers ----
+
+// schemaFieldSpecKeys lists the schema sections that hold field specs.
+var schemaFieldSpecKeys = []string{
+	"dimensionFieldSpecs",
+	"metricFieldSpecs",
+	"dateTimeFieldSpecs",
+	"complexFieldSpecs",
+}
+
+// Pinot accepts the default null value under either key and may return only one of them.
+const (
+	defaultNullValueKey       = "defaultNullValue"
+	defaultNullValueStringKey = "defaultNullValueString"
+)
+
+// normalizeDefaultNullValues returns a copy of the remote schema where each field spec uses the same
+// default null value key as the matching (by name) field spec in the prior schema, when the values are equivalent.
+func normalizeDefaultNullValues(remote, prior SchemaConfig) SchemaConfig {
+	out, _ := deepCopyJSON(remote).(map[string]interface{})
+	priorSpecs := fieldSpecsByName(prior)
+
+	for _, section := range schemaFieldSpecKeys {
+		specs, _ := out[section].([]interface{})
+		for _, el := range specs {
+			spec, ok := el.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := spec["name"].(string)
+			priorSpec, ok := priorSpecs[name]
+			if !ok {
+				continue
+			}
+			for _, pair := range [][2]string{
+				{defaultNullValueKey, defaultNullValueStringKey},
+				{defaultNullValueStringKey, defaultNullValueKey},
+			} {
+				want, other := pair[0], pair[1]
+				pv, inPrior := priorSpec[want]
+				if !inPrior {
+					continue
+				}
+				if _, inPrior := priorSpec[other]; inPrior {
+					continue
+				}
+				rv, inRemote := spec[other]
+				if _, already := spec[want]; already || !inRemote {
+					continue
+				}
+				if fmt.Sprint(rv) == fmt.Sprint(pv) {
+					delete(spec, other)
+					spec[want] = pv
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Defaults Pinot adds to variable-length columns when the user leaves them out.
+const (
+	defaultMaxLength               = 512
+	defaultMaxLengthExceedStrategy = "TRIM_LENGTH"
+)
+
+// variableLengthDataTypes are the data types Pinot applies maxLength to.
+var variableLengthDataTypes = []string{"STRING", "BYTES", "JSON"}
+
+// stripServerDefaultMaxLength returns a copy of the remote schema without the maxLength and
+// maxLengthExceedStrategy values Pinot fills in on variable-length columns, unless the prior
+// field spec set them. Non-default values are kept so real drift still shows up.
+func stripServerDefaultMaxLength(remote, prior SchemaConfig) SchemaConfig {
+	out, _ := deepCopyJSON(remote).(map[string]interface{})
+	priorSpecs := fieldSpecsByName(prior)
+
+	for _, section := range schemaFieldSpecKeys {
+		specs, _ := out[section].([]interface{})
+		for _, el := range specs {
+			spec, ok := el.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			dataType, _ := spec["dataType"].(string)
+			if !containsString(variableLengthDataTypes, strings.ToUpper(dataType)) {
+				continue
+			}
+			name, _ := spec["name"].(string)
+			priorSpec := priorSpecs[name]
+
+			if _, set := priorSpec["maxLength"]; !set {
+				if n, ok := spec["maxLength"].(float64); ok && n == defaultMaxLength {
+					delete(spec, "maxLength")
+				}
+			}
+			if _, set := priorSpec["maxLengthExceedStrategy"]; !set {
+				if v, ok := spec["maxLengthExceedStrategy"].(string); ok && strings.EqualFold(v, defaultMaxLengthExceedStrategy) {
+					delete(spec, "maxLengthExceedStrategy")
+				}
+			}
+		}
+	}
+	return out
+}
+
+type duplicateColumn struct {
+	name     string
+	sections []string
+}
+
+// duplicateColumnNames returns every column name that appears more than once across the field spec sections,
+// in order of first appearance, with the sections it appears in.
+func duplicateColumnNames(schema SchemaConfig) []duplicateColumn {
+	var order []string
+	seen := map[string][]string{}
+	for _, section := range schemaFieldSpecKeys {
+		specs, _ := schema[section].([]interface{})
+		for _, el := range specs {
+			spec, ok := el.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := spec["name"].(string)
+			if name == "" {
+				continue
+			}
+			if _, ok := seen[name]; !ok {
+				order = append(order, name)
+			}
+			seen[name] = append(seen[name], section)
+		}
+	}
+
+	var out []duplicateColumn
+	for _, name := range order {
+		if sections := seen[name]; len(sections) > 1 {
+			out = append(out, duplicateColumn{name: name, sections: sections})
+		}
+	}
+	return out
+}
+
+// fieldSpecsByName indexes every field spec in the schema by column name.
+func fieldSpecsByName(schema SchemaConfig) map[string]map[string]interface{} {
+	out := map[string]map[string]interface{}{}
+	for _, section := range schemaFieldSpecKeys {
+		specs, _ := schema[section].([]interface{})
+		for _, el := range specs {
+			if spec, ok := el.(map[string]interface{}); ok {
+				if name, _ := spec["name"].(string); name != "" {
+					out[name] = spec
+				}
+			}
+		}
+	}
+	return out
+}
+
+type columnTypeChange struct {
+	name     string
+	from, to string
+}
+
+// compatibleTypeChanges lists the dataType changes that do not break existing segments (numeric widening).
+var compatibleTypeChanges = map[string][]string{
+	"INT":   {"LONG", "FLOAT", "DOUBLE"},
+	"LONG":  {"DOUBLE"},
+	"FLOAT": {"DOUBLE"},
+}
+
+// incompatibleColumnChanges returns the columns present in both schemas whose type changes incompatibly,
+// sorted by name. A switch between single- and multi-value also counts as a type change.
+func incompatibleColumnChanges(prior, planned SchemaConfig) []columnTypeChange {
+	before := fieldSpecsByName(prior)
+	var out []columnTypeChange
+	for name, spec := range fieldSpecsByName(planned) {
+		old, ok := before[name]
+		if !ok {
+			continue
+		}
+		from, to := columnType(old), columnType(spec)
+		if from == to {
+			continue
+		}
+		if singleValue(old) == singleValue(spec) && containsString(compatibleTypeChanges[dataType(old)], dataType(spec)) {
+			continue
+		}
+		out = append(out, columnTypeChange{name: name, from: from, to: to})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+func dataType(spec map[string]interface{}) string {
+	t, _ := spec["dataType"].(string)
+	return strings.ToUpper(t)
+}
+
+// singleValue reports whether spec is single-valued; Pinot defaults singleValueField to true.
+func singleValue(spec map[string]interface{}) bool {
+	sv, ok := spec["singleValueField"].(bool)
+	return !ok || sv
+}
+
+// columnType describes a column's type for diagnostics, e.g. `STRING` or `multi-value LONG`.
+func columnType(spec map[string]interface{}) string {
+	if singleValue(spec) {
+		return dataType(spec)
+	}
+	return "multi-value " + dataType(spec)
+}
+
+// timeUnits are the java.util.concurrent.TimeUnit names Pinot accepts in date-time formats.
+var timeUnits = []string{"NANOSECONDS", "MICROSECONDS", "MILLISECONDS", "SECONDS", "MINUTES", "HOURS", "DAYS"}
+
+// dateTimeFormatUnit returns the time unit of an epoch or timestamp date-time format (timestamps count
+// milliseconds), or false for simple date formats and formats it cannot parse.
+func dateTimeFormatUnit(format string) (string, bool) {
+	if validateDateTimeFormat(format) != nil {
+		return "", false
+	}
+	if strings.Contains(format, "|") || !strings.Contains(format, ":") {
+		parts := strings.Split(format, "|")
+		switch {
+		case parts[0] == "EPOCH" && len(parts) >= 2:
+			return parts[1], true
+		case parts[0] == "EPOCH" || parts[0] == "TIMESTAMP":
+			return "MILLISECONDS", true
+		}
+		return "", false
+	}
+	parts := strings.SplitN(format, ":", 4)
+	switch parts[2] {
+	case "EPOCH":
+		return parts[1], true
+	case "TIMESTAMP":
+		return "MILLISECONDS", true
+	}
+	return "", false
+}
+
+// validateDateTimeFormat checks a date-time field spec format against Pinot's grammar. Both the colon form
+// (`size:unit:type[:pattern]`, e.g. `1:MILLISECONDS:EPOCH`) and the pipe form (`EPOCH[|unit[|size]]`,
+// `SIMPLE_DATE_FORMAT[|pattern[|timeZone]]`, `TIMESTAMP`) are accepted.
+func validateDateTimeFormat(format string) error {
+	if strings.TrimSpace(format) == "" {
+		return fmt.Errorf("format is required")
+	}
+
+	if strings.Contains(format, "|") || !strings.Contains(format, ":") {
+		parts := strings.Split(format, "|")
+		switch parts[0] {
+		case "EPOCH":
+			// A bare EPOCH means milliseconds.
+			if len(parts) > 3 {
+				return fmt.Errorf("expected EPOCH[|<unit>[|<size>]]")
+			}
+			if len(parts) >= 2 && !containsString(timeUnits, parts[1]) {
+				return fmt.Errorf("unknown time unit %q; expected one of %s", parts[1], strings.Join(timeUnits, ", "))
+			}
+			if len(parts) == 3 {
+				if err := validateTimeSize(parts[2]); err != nil {
+					return err
+				}
+			}
+		case "SIMPLE_DATE_FORMAT":
+			// A bare SIMPLE_DATE_FORMAT means ISO-8601.
+			if len(parts) > 3 || (len(parts) >= 2 && strings.TrimSpace(parts[1]) == "") {
+				return fmt.Errorf("expected SIMPLE_DATE_FORMAT[|<pattern>[|<timeZone>]]")
+			}
+			if len(parts) == 3 && strings.TrimSpace(parts[2]) == "" {
+				return fmt.Errorf("time zone must not be empty")
+			}
+		case "TIMESTAMP":
+			if len(parts) != 1 {
+				return fmt.Errorf("TIMESTAMP takes no further parts")
+			}
+		default:
+			return fmt.Errorf("unknown time format type %q; expected EPOCH, SIMPLE_DATE_FORMAT or TIMESTAMP", parts[0])
+		}
+		return nil
+	}
+
+	// The pattern may itself contain colons (e.g. HH:mm:ss), so split into at most four parts.
+	parts := strings.SplitN(format, ":", 4)
+	if len(parts) < 3 {
+		return fmt.Errorf("expected <size>:<unit>:<type>[:<pattern>]")
+	}
+	if err := validateTimeSize(parts[0]); err != nil {
+		return err
+	}
+	if !containsString(timeUnits, parts[1]) {
+		return fmt.Errorf("unknown time unit %q; expected one of %s", parts[1], strings.Join(timeUnits, ", "))
+	}
+	switch parts[2] {
+	case "EPOCH", "TIMESTAMP":
+		if len(parts) == 4 {
+			return fmt.Errorf("%s formats take no pattern", parts[2])
+		}
+	case "SIMPLE_DATE_FORMAT":
+		if len(parts) != 4 || strings.TrimSpace(parts[3]) == "" {
+			return fmt.Errorf("SIMPLE_DATE_FORMAT requires a pattern (e.g. 1:DAYS:SIMPLE_DATE_FORMAT:yyyyMMdd)")
+		}
+	default:
+		return fmt.Errorf("unknown time format type %q; expected EPOCH, SIMPLE_DATE_FORMAT or TIMESTAMP", parts[2])
+	}
+	return nil
+}
+
+func validateTimeSize(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return fmt.Errorf("size %q must be a positive integer", s)
+	}
+	return nil
+}
@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccPinotSchema_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPinotSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPinotSchemaConfig(rName, rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPinotSchemaExists("pinot_schema.test", "userId", "count", "timestamp"),
+					resource.TestCheckResourceAttr("pinot_schema.test", "schema_name", rName),
+					resource.TestCheckResourceAttr("pinot_schema.test", "id", rName),
+				),
+			},
+			{
+				// Server-side defaults (e.g. singleValueField, maxLength) must not show up as a diff after refresh.
+				Config:   testAccPinotSchemaConfig(rName, rName, false),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "pinot_schema.test",
+				ImportState:       true,
+				ImportStateId:     rName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPinotSchema_update(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPinotSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPinotSchemaConfig(rName, rName, false),
+				Check:  testAccCheckPinotSchemaExists("pinot_schema.test", "userId"),
+			},
+			{
+				// Adding a column is a compatible in-place update.
+				Config: testAccPinotSchemaConfig(rName, rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPinotSchemaExists("pinot_schema.test", "userId", "country"),
+					resource.TestCheckResourceAttr("pinot_schema.test", "schema_name", rName),
+				),
+			},
+			{
+				ResourceName:      "pinot_schema.test",
+				ImportState:       true,
+				ImportStateId:     rName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPinotSchema_nameMismatch(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPinotSchemaDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPinotSchemaConfig(rName, rName+"other", false),
+				ExpectError: regexp.MustCompile(`Schema Name Mismatch`),
+			},
+		},
+	})
+}
+
+// testAccPinotSchemaConfig declares schema_name name with schemaName jsonName, optionally with an extra
+// country dimension.
+func testAccPinotSchemaConfig(name, jsonName string, withCountry bool) string {
+	country := ""
+	if withCountry {
+		country = `,
+      {
+        name     = "country"
+        dataType = "STRING"
+      }`
+	}
+	return fmt.Sprintf(pinotProviderBlock+`
+resource "pinot_schema" "test" {
+  schema_name = "%[1]s"
+
+  schema = jsonencode({
+    schemaName = "%[2]s"
+
+    dimensionFieldSpecs = [
+      {
+        name     = "userId"
+        dataType = "STRING"
+      }%[3]s
+    ]
+
+    metricFieldSpecs = [
+      {
+        name     = "count"
+        dataType = "LONG"
+      }
+    ]
+
+    dateTimeFieldSpecs = [
+      {
+        name        = "timestamp"
+        dataType    = "LONG"
+        format      = "1:MILLISECONDS:EPOCH"
+        granularity = "1:HOURS"
+      }
+    ]
+  })
+}
+`, name, jsonName, country)
+}
+
+// testAccCheckPinotSchemaExists checks that the controller has the schema with all the given columns.
+func testAccCheckPinotSchemaExists(resourceName string, columns ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Schema ID is set")
+		}
+		status, body, err := pinotGetSchemaRaw(rs.Primary.Attributes["schema_name"])
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("expected schema to exist, GET status %d (body: %s)", status, truncate(body))
+		}
+		var remote SchemaConfig
+		if err := json.Unmarshal([]byte(body), &remote); err != nil {
+			return fmt.Errorf("unexpected GET body: %w", err)
+		}
+		have := map[string]bool{}
+		for _, section := range []string{"dimensionFieldSpecs", "metricFieldSpecs", "dateTimeFieldSpecs"} {
+			specs, _ := remote[section].([]interface{})
+			for _, el := range specs {
+				if spec, ok := el.(map[string]interface{}); ok {
+					have[fmt.Sprint(spec["name"])] = true
+				}
+			}
+		}
+		for _, col := range columns {
+			if !have[col] {
+				return fmt.Errorf("schema has no column %q: %s", col, truncate(body))
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckPinotSchemaDestroy(s *terraform.State) error {
+	const (
+		waitTotal = 60 * time.Second
+		interval  = 3 * time.Second
+	)
+
+	deadline := time.Now().Add(waitTotal)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "pinot_schema" {
+			continue
+		}
+		name := rs.Primary.Attributes["schema_name"]
+
+		for {
+			status, body, err := pinotGetSchemaRaw(name)
+			if err != nil {
+				return err
+			}
+			if status == http.StatusNotFound {
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("schema still exists after destroy wait: %s (last status %d; body: %s)", name, status, truncate(body))
+			}
+			time.Sleep(interval)
+		}
+	}
+	return nil
+}
+
+func pinotGetSchemaRaw(name string) (int, string, error) {
+	base := strings.TrimRight(os.Getenv("PINOT_CONTROLLER_URL"), "/")
+	if base == "" {
+		return 0, "", fmt.Errorf("PINOT_CONTROLLER_URL not set")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/schemas/%s", base, urlPath(name)), nil)
+	if err != nil {
+		return 0, "", err
+	}
+
+	// Optional headers: DB + auth
+	if db := strings.TrimSpace(os.Getenv("PINOT_DATABASE")); db != "" {
+		req.Header.Set("Database", db)
+	}
+	if token := strings.TrimSpace(os.Getenv("PINOT_TOKEN")); token != "" {
+		req.Header.Set("Authorization", "Basic "+token)
+	} else if u, p := os.Getenv("PINOT_USERNAME"), os.Getenv("PINOT_PASSWORD"); u != "" || p != "" {
+		req.SetBasicAuth(u, p)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, resp.Body)
+	return resp.StatusCode, buf.String(), nil
+}
+
+func TestNormalizeDefaultNullValues(t *testing.T) {
+	cases := map[string]struct {
+		remote string
+		prior  string
+		want   string
+	}{
+		"remote returns defaultNullValue for defaultNullValueString": {
+			remote: `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValue":"unknown"}]}`,
+			prior:  `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValueString":"unknown"}]}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValueString":"unknown"}]}`,
+		},
+		"remote returns string form for numeric default": {
+			remote: `{"metricFieldSpecs":[{"name":"count","dataType":"LONG","defaultNullValueString":"0"}]}`,
+			prior:  `{"metricFieldSpecs":[{"name":"count","dataType":"LONG","defaultNullValue":0}]}`,
+			want:   `{"metricFieldSpecs":[{"name":"count","dataType":"LONG","defaultNullValue":0}]}`,
+		},
+		"changed value is kept": {
+			remote: `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValue":"n/a"}]}`,
+			prior:  `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValueString":"unknown"}]}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValue":"n/a"}]}`,
+		},
+		"no prior state (import)": {
+			remote: `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValue":"unknown"}]}`,
+			prior:  `{}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","defaultNullValue":"unknown"}]}`,
+		},
+	}
+
+	for name, tc := range cases {
+		var remote, prior, want SchemaConfig
+		if err := json.Unmarshal([]byte(tc.remote), &remote); err != nil {
+			t.Fatalf("%s: invalid remote JSON: %v", name, err)
+		}
+		if err := json.Unmarshal([]byte(tc.prior), &prior); err != nil {
+			t.Fatalf("%s: invalid prior JSON: %v", name, err)
+		}
+		if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+			t.Fatalf("%s: invalid want JSON: %v", name, err)
+		}
+
+		gotJSON, _ := json.Marshal(normalizeDefaultNullValues(remote, prior))
+		wantJSON, _ := json.Marshal(want)
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("%s:\n got  %s\n want %s", name, gotJSON, wantJSON)
+		}
+	}
+}
+
+func TestStripServerDefaultMaxLength(t *testing.T) {
+	cases := map[string]struct {
+		remote string
+		prior  string
+		want   string
+	}{
+		"STRING defaults added by server": {
+			remote: `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","maxLength":512,"maxLengthExceedStrategy":"TRIM_LENGTH"}]}`,
+			prior:  `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING"}]}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING"}]}`,
+		},
+		"BYTES defaults added by server": {
+			remote: `{"dimensionFieldSpecs":[{"name":"payload","dataType":"BYTES","maxLength":512}]}`,
+			prior:  `{"dimensionFieldSpecs":[{"name":"payload","dataType":"BYTES"}]}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"payload","dataType":"BYTES"}]}`,
+		},
+		"user-set defaults are kept": {
+			remote: `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","maxLength":512,"maxLengthExceedStrategy":"TRIM_LENGTH"}]}`,
+			prior:  `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","maxLength":512,"maxLengthExceedStrategy":"TRIM_LENGTH"}]}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"country","dataType":"STRING","maxLength":512,"maxLengthExceedStrategy":"TRIM_LENGTH"}]}`,
+		},
+		"non-default values are kept": {
+			remote: `{"dimensionFieldSpecs":[{"name":"payload","dataType":"BYTES","maxLength":1024,"maxLengthExceedStrategy":"ERROR"}]}`,
+			prior:  `{"dimensionFieldSpecs":[{"name":"payload","dataType":"BYTES"}]}`,
+			want:   `{"dimensionFieldSpecs":[{"name":"payload","dataType":"BYTES","maxLength":1024,"maxLengthExceedStrategy":"ERROR"}]}`,
+		},
+		"fixed-width columns untouched": {
+			remote: `{"metricFieldSpecs":[{"name":"count","dataType":"LONG","maxLength":512}]}`,
+			prior:  `{"metricFieldSpecs":[{"name":"count","dataType":"LONG"}]}`,
+			want:   `{"metricFieldSpecs":[{"name":"count","dataType":"LONG","maxLength":512}]}`,
+		},
+	}
+
+	for name, tc := range cases {
+		var remote, prior, want SchemaConfig
+		if err := json.Unmarshal([]byte(tc.remote), &remote); err != nil {
+			t.Fatalf("%s: invalid remote JSON: %v", name, err)
+		}
+		if err := json.Unmarshal([]byte(tc.prior), &prior); err != nil {
+			t.Fatalf("%s: invalid prior JSON: %v", name, err)
+		}
+		if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+			t.Fatalf("%s: invalid want JSON: %v", name, err)
+		}
+
+		gotJSON, _ := json.Marshal(stripServerDefaultMaxLength(remote, prior))
+		wantJSON, _ := json.Marshal(want)
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("%s:\n got  %s\n want %s", name, gotJSON, wantJSON)
+		}
+	}
+}
+
+func TestDuplicateColumnNames(t *testing.T) {
+	var schema SchemaConfig
+	if err := json.Unmarshal([]byte(`{
+		"dimensionFieldSpecs": [{"name": "userId"}, {"name": "country"}, {"name": "country"}],
+		"metricFieldSpecs": [{"name": "count"}, {"name": "userId"}],
+		"dateTimeFieldSpecs": [{"name": "ts"}]
+	}`), &schema); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+
+	got := duplicateColumnNames(schema)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 duplicates, got %+v", got)
+	}
+	if got[0].name != "userId" || strings.Join(got[0].sections, ",") != "dimensionFieldSpecs,metricFieldSpecs" {
+		t.Errorf("unexpected first duplicate: %+v", got[0])
+	}
+	if got[1].name != "country" || strings.Join(got[1].sections, ",") != "dimensionFieldSpecs,dimensionFieldSpecs" {
+		t.Errorf("unexpected second duplicate: %+v", got[1])
+	}
+}
+
+func TestValidateDateTimeFormat(t *testing.T) {
+	valid := []string{
+		"1:MILLISECONDS:EPOCH",
+		"5:MINUTES:EPOCH",
+		"1:MILLISECONDS:TIMESTAMP",
+		"1:DAYS:SIMPLE_DATE_FORMAT:yyyyMMdd",
+		"1:SECONDS:SIMPLE_DATE_FORMAT:yyyy-MM-dd HH:mm:ss",
+		"1:DAYS:SIMPLE_DATE_FORMAT:yyyyMMdd tz(America/Los_Angeles)",
+		"EPOCH",
+		"EPOCH|MILLISECONDS",
+		"SIMPLE_DATE_FORMAT",
+		"EPOCH|MINUTES|5",
+		"SIMPLE_DATE_FORMAT|yyyy-MM-dd|UTC",
+		"TIMESTAMP",
+	}
+	for _, f := range valid {
+		if err := validateDateTimeFormat(f); err != nil {
+			t.Errorf("validateDateTimeFormat(%q): unexpected error: %v", f, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"1:MILISECONDS:EPOCH",
+		"1:MILLISECONDS",
+		"0:MILLISECONDS:EPOCH",
+		"x:MILLISECONDS:EPOCH",
+		"1:MILLISECONDS:EPOC",
+		"1:DAYS:SIMPLE_DATE_FORMAT",
+		"1:MILLISECONDS:EPOCH:yyyy",
+		"EPOCH|MILLIS",
+		"EPOCH|MINUTES|0",
+		"SIMPLE_DATE_FORMAT|",
+		"TIMESTAMP|UTC",
+		"EPOCHS|MILLISECONDS",
+	}
+	for _, f := range invalid {
+		if err := validateDateTimeFormat(f); err == nil {
+			t.Errorf("validateDateTimeFormat(%q): expected error", f)
+		}
+	}
+}
+
+func TestDateTimeFormatUnit(t *testing.T) {
+	cases := map[string]string{
+		"1:MILLISECONDS:EPOCH":               "MILLISECONDS",
+		"5:MINUTES:EPOCH":                    "MINUTES",
+		"1:MILLISECONDS:TIMESTAMP":           "MILLISECONDS",
+		"EPOCH":                              "MILLISECONDS",
+		"EPOCH|SECONDS":                      "SECONDS",
+		"EPOCH|MINUTES|5":                    "MINUTES",
+		"TIMESTAMP":                          "MILLISECONDS",
+		"1:DAYS:SIMPLE_DATE_FORMAT:yyyyMMdd": "",
+		"SIMPLE_DATE_FORMAT|yyyy-MM-dd":      "",
+		"1:MILISECONDS:EPOCH":                "",
+	}
+	for format, want := range cases {
+		got, ok := dateTimeFormatUnit(format)
+		if got != want || ok != (want != "") {
+			t.Errorf("dateTimeFormatUnit(%q) = %q, %v; want %q", format, got, ok, want)
+		}
+	}
+}
+
+func TestIncompatibleColumnChanges(t *testing.T) {
+	prior := mustJSONMap(t, `{
+		"dimensionFieldSpecs": [
+			{"name": "userId", "dataType": "STRING"},
+			{"name": "tags", "dataType": "STRING", "singleValueField": false},
+			{"name": "age", "dataType": "INT"}
+		],
+		"metricFieldSpecs": [{"name": "count", "dataType": "INT"}]
+	}`)
+	planned := mustJSONMap(t, `{
+		"dimensionFieldSpecs": [
+			{"name": "userId", "dataType": "LONG"},
+			{"name": "tags", "dataType": "STRING"},
+			{"name": "age", "dataType": "INT"},
+			{"name": "country", "dataType": "STRING"}
+		],
+		"metricFieldSpecs": [{"name": "count", "dataType": "LONG"}]
+	}`)
+
+	got := incompatibleColumnChanges(prior, planned)
+	want := []columnTypeChange{
+		{name: "tags", from: "multi-value STRING", to: "STRING"},
+		{name: "userId", from: "STRING", to: "LONG"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+// internal/provider/segment_deletion_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &SegmentDeletionResource{}
+var _ resource.ResourceWithValidateConfig = &SegmentDeletionResource{}
+
+type SegmentDeletionResource struct {
+	client *client.PinotClient
+}
+
+type SegmentDeletionResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	TableName       types.String `tfsdk:"table_name"`
+	StartTime       types.String `tfsdk:"start_time"`
+	EndTime         types.String `tfsdk:"end_time"`
+	Confirm         types.Bool   `tfsdk:"confirm"`
+	DeletedSegments types.List   `tfsdk:"deleted_segments"` // []string
+	ControllerURL   types.String `tfsdk:"controller_url"`
+}
+
+func NewSegmentDeletionResource() resource.Resource {
+	return &SegmentDeletionResource{}
+}
+
+func (r *SegmentDeletionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_segment_deletion"
+}
+
+func (r *SegmentDeletionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes the segments of a table whose data lies entirely within a time range, e.g. for retention purges. " +
+			"The deletion runs on create and whenever `table_name`, `start_time` or `end_time` change; destroying the resource does nothing. " +
+			"Segments that only partly overlap the range are kept, and a range that matches every segment of the table is rejected.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the deletion.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table to delete segments from as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Start of the time range (inclusive) as an RFC 3339 timestamp, e.g. `2024-01-01T00:00:00Z`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"end_time": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "End of the time range (exclusive) as an RFC 3339 timestamp. Must be after `start_time`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirm": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Must be `true`; acknowledges that the matching segments are deleted permanently.",
+			},
+			"deleted_segments": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the segments that were deleted.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SegmentDeletionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SegmentDeletionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SegmentDeletionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Confirm.IsUnknown() && !data.Confirm.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("confirm"),
+			"Segment Deletion Not Confirmed",
+			"Set confirm = true to delete the segments in the time range; deleted segments cannot be recovered.",
+		)
+	}
+
+	if !data.TableName.IsUnknown() {
+		if _, typ := splitTableID(data.TableName.ValueString()); typ == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("table_name"),
+				"Invalid Table Name",
+				"table_name must be in the form <logical>_OFFLINE or <logical>_REALTIME.",
+			)
+		}
+	}
+
+	if data.StartTime.IsUnknown() || data.EndTime.IsUnknown() {
+		return
+	}
+	if _, _, err := segmentDeletionRange(data); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("end_time"), "Invalid Time Range", err.Error())
+	}
+}
+
+func (r *SegmentDeletionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SegmentDeletionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &SegmentDeletionResource{client: clientFor(r.client, data.ControllerURL)}
+
+	startMs, endMs, err := segmentDeletionRange(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Time Range", err.Error())
+		return
+	}
+
+	deleted, err := r.client.DeleteSegmentsByTimeRange(ctx, data.TableName.ValueString(), startMs, endMs)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Segments", "Could not delete segments of table "+data.TableName.ValueString(), err)
+		return
+	}
+
+	if deleted == nil {
+		deleted = []string{}
+	}
+	list, diags := types.ListValueFrom(ctx, types.StringType, deleted)
+	resp.Diagnostics.Append(diags...)
+	data.DeletedSegments = list
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps state as-is; a deletion has no remote object to refresh.
+func (r *SegmentDeletionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only records a changed confirm; every other change replaces the resource.
+func (r *SegmentDeletionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SegmentDeletionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.DeletedSegments = state.DeletedSegments
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SegmentDeletionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// segmentDeletionRange parses start_time and end_time into epoch milliseconds and checks that the range is not empty.
+func segmentDeletionRange(data SegmentDeletionResourceModel) (int64, int64, error) {
+	start, err := time.Parse(time.RFC3339, data.StartTime.ValueString())
+	if err != nil {
+		return 0, 0, fmt.Errorf("start_time %q is not an RFC 3339 timestamp", data.StartTime.ValueString())
+	}
+	end, err := time.Parse(time.RFC3339, data.EndTime.ValueString())
+	if err != nil {
+		return 0, 0, fmt.Errorf("end_time %q is not an RFC 3339 timestamp", data.EndTime.ValueString())
+	}
+	if start.Before(time.Unix(0, 0)) {
+		return 0, 0, fmt.Errorf("start_time %s is before the Unix epoch", data.StartTime.ValueString())
+	}
+	if !end.After(start) {
+		return 0, 0, fmt.Errorf("end_time %s must be after start_time %s", data.EndTime.ValueString(), data.StartTime.ValueString())
+	}
+	return start.UnixMilli(), end.UnixMilli(), nil
+}
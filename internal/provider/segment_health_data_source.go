@@ -0,0 +1,110 @@
+// internal/provider/segment_health_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &SegmentHealthDataSource{}
+
+type SegmentHealthDataSource struct {
+	client *client.PinotClient
+}
+
+type SegmentHealthDataSourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	TableName               types.String `tfsdk:"table_name"`
+	TotalSegments           types.Int64  `tfsdk:"total_segments"`
+	UnderReplicatedCount    types.Int64  `tfsdk:"under_replicated_count"`
+	UnderReplicatedSegments types.List   `tfsdk:"under_replicated_segments"` // []string
+}
+
+func NewSegmentHealthDataSource() datasource.DataSource {
+	return &SegmentHealthDataSource{}
+}
+
+func (d *SegmentHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_segment_health"
+}
+
+func (d *SegmentHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the replication health of a Pinot table's segments by comparing its ideal state with its external view. " +
+			"A segment is under-replicated when any replica assigned to be `ONLINE` or `CONSUMING` is not in that state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier (same as `table_name`).",
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table name: the logical name (both table types) or `<logical>_<TYPE>`.",
+			},
+			"total_segments": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of segments in the ideal state.",
+			},
+			"under_replicated_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of under-replicated segments.",
+			},
+			"under_replicated_segments": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the under-replicated segments, sorted.",
+			},
+		},
+	}
+}
+
+func (d *SegmentHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SegmentHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SegmentHealthDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := d.client.GetSegmentReplicationStatus(ctx, data.TableName.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Segment Health",
+			"Could not read segment replication of table "+data.TableName.ValueString(), err)
+		return
+	}
+	segments := status.UnderReplicated
+	if segments == nil {
+		segments = []string{}
+	}
+
+	data.ID = types.StringValue(data.TableName.ValueString())
+	data.TotalSegments = types.Int64Value(int64(status.TotalSegments))
+	data.UnderReplicatedCount = types.Int64Value(int64(len(segments)))
+
+	segmentsV, diags := types.ListValueFrom(ctx, types.StringType, segments)
+	resp.Diagnostics.Append(diags...)
+	data.UnderReplicatedSegments = segmentsV
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
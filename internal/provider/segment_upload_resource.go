@@ -0,0 +1,238 @@
+// internal/provider/segment_upload_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &SegmentUploadResource{}
+var _ resource.ResourceWithValidateConfig = &SegmentUploadResource{}
+
+// Values of upload_compression.
+const (
+	uploadCompressionNone = "none"
+	uploadCompressionGzip = "gzip"
+)
+
+type SegmentUploadResource struct {
+	client *client.PinotClient
+}
+
+type SegmentUploadResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	TableName         types.String `tfsdk:"table_name"`
+	FilePath          types.String `tfsdk:"file_path"`
+	URI               types.String `tfsdk:"uri"`
+	UploadCompression types.String `tfsdk:"upload_compression"`
+	SegmentFormat     types.String `tfsdk:"segment_format"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	ControllerURL     types.String `tfsdk:"controller_url"`
+}
+
+func NewSegmentUploadResource() resource.Resource {
+	return &SegmentUploadResource{}
+}
+
+func (r *SegmentUploadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_segment_upload"
+}
+
+func (r *SegmentUploadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a prebuilt segment tar to a table (`POST /segments`), either from the local file system or from a URI the controller downloads it from. " +
+			"The upload runs on create and whenever any argument changes; uploading a segment with the name of an existing one replaces it. " +
+			"Destroying the resource does nothing and leaves the segment in place.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the upload.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table to upload the segment to as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_path": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Local segment tar (usually a `.tar.gz`) to upload; it is streamed, so segments of any size can be uploaded. " +
+					"Exactly one of `file_path` and `uri` must be set. Changes to the file's content are not detected; use `triggers` with `filesha256()` to upload it again.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"uri": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URI the controller downloads the segment tar from (e.g., `s3://bucket/segments/events_0.tar.gz`); the controller needs the matching file system configured.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"upload_compression": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Compression of the upload request with `file_path`: `none` (default) or `gzip`, which gzips the body on the fly and sends it with `Content-Encoding: gzip`. " +
+					"Only useful for uncompressed `.tar` segments; it cannot be combined with `segment_format = \"tar.gz\"` or with `uri`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(uploadCompressionNone, uploadCompressionGzip),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"segment_format": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Format the file at `file_path` must have: `tar` or `tar.gz`. The upload fails when the file does not match. " +
+					"When unset, either is accepted. Cannot be combined with `uri`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.SegmentFormatTar, client.SegmentFormatTarGz),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that trigger a new upload when changed (e.g. a hash of the segment file).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SegmentUploadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SegmentUploadResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SegmentUploadResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.TableName.IsUnknown() {
+		if _, typ := splitTableID(data.TableName.ValueString()); typ == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("table_name"),
+				"Invalid Table Name",
+				"table_name must be in the form <logical>_OFFLINE or <logical>_REALTIME.",
+			)
+		}
+	}
+
+	if data.UploadCompression.ValueString() == uploadCompressionGzip && data.SegmentFormat.ValueString() == client.SegmentFormatTarGz {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("upload_compression"),
+			"Invalid Upload Compression",
+			"A tar.gz segment is already gzipped; set upload_compression = \"gzip\" only for tar segments.",
+		)
+	}
+
+	if data.FilePath.IsUnknown() || data.URI.IsUnknown() {
+		return
+	}
+	if data.FilePath.IsNull() == data.URI.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file_path"),
+			"Invalid Segment Source",
+			"Exactly one of file_path and uri must be set.",
+		)
+	}
+	if !data.URI.IsNull() {
+		for attr, v := range map[string]types.String{"upload_compression": data.UploadCompression, "segment_format": data.SegmentFormat} {
+			if !v.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attr),
+					"Invalid Segment Upload Option",
+					attr+" only applies to uploads from file_path; the controller downloads a uri itself.",
+				)
+			}
+		}
+	}
+}
+
+func (r *SegmentUploadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SegmentUploadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &SegmentUploadResource{client: clientFor(r.client, data.ControllerURL)}
+
+	tableName := data.TableName.ValueString()
+	var err error
+	if data.FilePath.IsNull() {
+		err = r.client.UploadSegmentURI(ctx, tableName, data.URI.ValueString())
+	} else {
+		err = r.client.UploadSegmentFile(ctx, tableName, data.FilePath.ValueString(), client.SegmentUploadOptions{
+			Format: data.SegmentFormat.ValueString(),
+			Gzip:   data.UploadCompression.ValueString() == uploadCompressionGzip,
+		})
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Uploading Pinot Segment", "Could not upload segment to table "+tableName, err)
+		return
+	}
+
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps state as-is; the segment is not tracked after the upload.
+func (r *SegmentUploadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only runs when nothing but computed values could change, since every argument forces a new upload.
+func (r *SegmentUploadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SegmentUploadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SegmentUploadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestSegmentUploadResource(t *testing.T) {
+	var downloadURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloadURI = r.Header.Get("DOWNLOAD_URI")
+		_, _ = w.Write([]byte(`{"status":"Successfully uploaded segment"}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &SegmentUploadResource{client: c}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+
+	plan := func(filePath, uri, compression, format types.String) tfsdk.Plan {
+		p := tfsdk.Plan{Schema: schemaResp.Schema}
+		if diags := p.Set(t.Context(), SegmentUploadResourceModel{
+			ID:                types.StringUnknown(),
+			TableName:         types.StringValue("events_OFFLINE"),
+			FilePath:          filePath,
+			URI:               uri,
+			UploadCompression: compression,
+			SegmentFormat:     format,
+			Triggers:          types.MapNull(types.StringType),
+			ControllerURL:     types.StringNull(),
+		}); diags.HasError() {
+			t.Fatalf("Plan.Set: %v", diags)
+		}
+		return p
+	}
+
+	const uri = "s3://segments/events_0.tar.gz"
+	file, null := types.StringValue("events_0.tar"), types.StringNull()
+	gzip := types.StringValue(uploadCompressionGzip)
+	for name, tc := range map[string]struct {
+		filePath, uri, compression, format types.String
+		valid                              bool
+	}{
+		"file":             {filePath: file, uri: null, compression: null, format: null, valid: true},
+		"uri":              {filePath: null, uri: types.StringValue(uri), compression: null, format: null, valid: true},
+		"both":             {filePath: file, uri: types.StringValue(uri), compression: null, format: null, valid: false},
+		"neither":          {filePath: null, uri: null, compression: null, format: null, valid: false},
+		"unknown":          {filePath: types.StringUnknown(), uri: null, compression: null, format: null, valid: true},
+		"gzipped tar":      {filePath: file, uri: null, compression: gzip, format: types.StringValue("tar"), valid: true},
+		"gzipped tar.gz":   {filePath: file, uri: null, compression: gzip, format: types.StringValue("tar.gz"), valid: false},
+		"uri with options": {filePath: null, uri: types.StringValue(uri), compression: gzip, format: null, valid: false},
+	} {
+		p := plan(tc.filePath, tc.uri, tc.compression, tc.format)
+		var resp fwresource.ValidateConfigResponse
+		r.ValidateConfig(t.Context(), fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: p.Schema, Raw: p.Raw}}, &resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: diags = %v, want valid = %v", name, resp.Diagnostics, tc.valid)
+		}
+	}
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: plan(null, types.StringValue(uri), null, null)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", resp.Diagnostics)
+	}
+	if downloadURI != uri {
+		t.Errorf("DOWNLOAD_URI = %q, want %q", downloadURI, uri)
+	}
+}
@@ -0,0 +1,165 @@
+// internal/provider/segments_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &SegmentsDataSource{}
+
+// segmentStates are the external view states the state filter accepts.
+var segmentStates = []string{"ONLINE", "OFFLINE", "CONSUMING", "ERROR"}
+
+type SegmentsDataSource struct {
+	client *client.PinotClient
+}
+
+type SegmentsDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	TableName types.String `tfsdk:"table_name"`
+	State     types.String `tfsdk:"state"`
+	Segments  types.List   `tfsdk:"segments"` // []string
+
+	IncludeMetadata types.Bool `tfsdk:"include_metadata"`
+	MetadataColumns types.List `tfsdk:"metadata_columns"` // []string
+	Metadata        types.Map  `tfsdk:"metadata"`         // segment name -> JSON
+}
+
+func NewSegmentsDataSource() datasource.DataSource {
+	return &SegmentsDataSource{}
+}
+
+func (d *SegmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_segments"
+}
+
+func (d *SegmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the segments of a Pinot table from its external view, optionally only those in a given state (e.g. `ERROR` for health checks).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier: `<table_name>` or `<table_name>/<state>`.",
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table name: the logical name (both table types) or `<logical>_<TYPE>`.",
+			},
+			"state": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return segments with at least one replica in this state: `ONLINE`, `OFFLINE`, `CONSUMING` or `ERROR`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(segmentStates...),
+				},
+			},
+			"segments": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Matching segment names, sorted.",
+			},
+			"include_metadata": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, fetch the metadata of all segments in one bulk request and return it in `metadata`. Defaults to `false`.",
+			},
+			"metadata_columns": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Columns whose per-column metadata is included in `metadata`. Omit to return segment-level metadata only.",
+			},
+			"metadata": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Metadata of each matching segment as JSON, keyed by segment name. Null unless `include_metadata` is `true`.",
+			},
+		},
+	}
+}
+
+func (d *SegmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SegmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SegmentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	segments, err := d.client.GetSegmentsByState(ctx, data.TableName.ValueString(), data.State.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Pinot Segments",
+			"Could not read segments of table "+data.TableName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if segments == nil {
+		segments = []string{}
+	}
+
+	id := data.TableName.ValueString()
+	if !data.State.IsNull() {
+		id += "/" + data.State.ValueString()
+	}
+	data.ID = types.StringValue(id)
+
+	segmentsV, diags := types.ListValueFrom(ctx, types.StringType, segments)
+	resp.Diagnostics.Append(diags...)
+	data.Segments = segmentsV
+
+	data.Metadata = types.MapNull(types.StringType)
+	if data.IncludeMetadata.ValueBool() {
+		columns := toStringSlice(ctx, &resp.Diagnostics, data.MetadataColumns)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		all, err := d.client.GetAllSegmentMetadata(ctx, data.TableName.ValueString(), columns)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Pinot Segment Metadata",
+				"Could not read segment metadata of table "+data.TableName.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		metadata := make(map[string]string, len(segments))
+		for _, segment := range segments {
+			m, ok := all[segment]
+			if !ok {
+				continue
+			}
+			js, err := canonicalJSON(m)
+			if err != nil {
+				resp.Diagnostics.AddError("Error Marshaling Segment Metadata", err.Error())
+				return
+			}
+			metadata[segment] = js
+		}
+		metadataV, diags := types.MapValueFrom(ctx, types.StringType, metadata)
+		resp.Diagnostics.Append(diags...)
+		data.Metadata = metadataV
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,114 @@
+// internal/provider/table_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &TableDataSource{}
+
+type TableDataSource struct {
+	client *client.PinotClient
+}
+
+type TableDataSourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	TableName   types.String         `tfsdk:"table_name"`
+	TableType   types.String         `tfsdk:"table_type"`
+	TableConfig jsontypes.Normalized `tfsdk:"table_config"`
+}
+
+func NewTableDataSource() datasource.DataSource {
+	return &TableDataSource{}
+}
+
+func (d *TableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+func (d *TableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the config of an existing table, e.g. one managed outside Terraform, without importing it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Table name with its type suffix, e.g. `events_OFFLINE`.",
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Logical table name, without the type suffix.",
+			},
+			"table_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table type: `OFFLINE` or `REALTIME`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("OFFLINE", "REALTIME"),
+				},
+			},
+			"table_config": schema.StringAttribute{
+				CustomType:          jsontypes.NormalizedType{},
+				Computed:            true,
+				MarkdownDescription: "Table config JSON as the controller returns it, with SASL JAAS credentials removed.",
+			},
+		},
+	}
+}
+
+func (d *TableDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TableDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
+	tableConfig, err := d.client.GetTable(ctx, fullTableName)
+	// Some controller versions answer a missing table with an empty object instead of a 404.
+	if client.IsNotFound(err) || (err == nil && len(tableConfig) == 0) {
+		resp.Diagnostics.AddError(
+			"Pinot Table Not Found",
+			fmt.Sprintf("Table %s does not exist on the controller. Check table_name and table_type.", fullTableName),
+		)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Table", "Could not read table "+fullTableName, err)
+		return
+	}
+
+	configJSON, err := canonicalJSON(cleanTableConfigForState(tableConfig, nil, false))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Marshaling Table Config", "Could not marshal table configuration to JSON: "+err.Error())
+		return
+	}
+	data.ID = types.StringValue(fullTableName)
+	data.TableConfig = jsontypes.NewNormalizedValue(configJSON)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,268 @@
+// internal/provider/table_instance_assignment_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &TableInstanceAssignmentResource{}
+var _ resource.ResourceWithImportState = &TableInstanceAssignmentResource{}
+
+type TableInstanceAssignmentResource struct {
+	client *client.PinotClient
+}
+
+type TableInstanceAssignmentResourceModel struct {
+	ID                 types.String         `tfsdk:"id"`
+	TableName          types.String         `tfsdk:"table_name"`
+	PartitionsType     types.String         `tfsdk:"partitions_type"`
+	InstancePartitions jsontypes.Normalized `tfsdk:"instance_partitions"`
+	Rebalance          types.Bool           `tfsdk:"rebalance"`
+	ControllerURL      types.String         `tfsdk:"controller_url"`
+}
+
+func NewTableInstanceAssignmentResource() resource.Resource {
+	return &TableInstanceAssignmentResource{}
+}
+
+func (r *TableInstanceAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_instance_assignment"
+}
+
+func (r *TableInstanceAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the instance partitions of a Pinot table via `/tables/{name}/instancePartitions`, separately from the table config. " +
+			"Destroying the resource deletes the stored partitions so the controller computes them from the table config again.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier: `<table_name>/<partitions_type>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Logical table name without suffix (e.g., `user_events`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"partitions_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Instance partitions type: `OFFLINE`, `CONSUMING` or `COMPLETED`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("OFFLINE", "CONSUMING", "COMPLETED"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_partitions": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "JSON instance partitions, including `instancePartitionsName` (e.g. `user_events_OFFLINE`) and `partitionToInstancesMap`. Prefer `jsonencode({...})`.",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"rebalance": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, rebalance the table after the instance partitions change (and after they are deleted) so segments move to the new instances. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func (r *TableInstanceAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TableInstanceAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TableInstanceAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableInstanceAssignmentResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.apply(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Error Applying Pinot Instance Partitions", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(data.TableName.ValueString() + "/" + data.PartitionsType.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TableInstanceAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TableInstanceAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableInstanceAssignmentResource{client: clientFor(r.client, data.ControllerURL)}
+
+	remote, err := r.client.GetInstancePartitions(ctx, data.TableName.ValueString(), data.PartitionsType.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Reading Pinot Instance Partitions",
+			"Could not read instance partitions for table "+data.TableName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if remote == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Keep only the keys the configuration manages; the controller may add its own.
+	var prior map[string]interface{}
+	if !data.InstancePartitions.IsNull() && !data.InstancePartitions.IsUnknown() {
+		resp.Diagnostics.Append(data.InstancePartitions.Unmarshal(&prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if len(prior) > 0 {
+		for k := range remote {
+			if _, ok := prior[k]; !ok {
+				delete(remote, k)
+			}
+		}
+	}
+
+	partitionsJSON, err := canonicalJSON(remote)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Marshaling Instance Partitions",
+			"Could not marshal instance partitions to JSON: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.TableName.ValueString() + "/" + data.PartitionsType.ValueString())
+	data.InstancePartitions = jsontypes.NewNormalizedValue(partitionsJSON)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TableInstanceAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TableInstanceAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableInstanceAssignmentResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	// Toggling rebalance alone does not touch the cluster.
+	if !plan.InstancePartitions.Equal(state.InstancePartitions) {
+		if err := r.apply(ctx, &plan); err != nil {
+			resp.Diagnostics.AddError("Error Applying Pinot Instance Partitions", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TableInstanceAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TableInstanceAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableInstanceAssignmentResource{client: clientFor(r.client, data.ControllerURL)}
+
+	err := r.client.DeleteInstancePartitions(ctx, data.TableName.ValueString(), data.PartitionsType.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Deleting Pinot Instance Partitions",
+			"Could not delete instance partitions, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if data.Rebalance.ValueBool() {
+		if _, err := r.client.RebalanceTable(ctx, data.TableName.ValueString(), tableTypeForPartitions(data.PartitionsType.ValueString()), false); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Pinot Table Rebalance Failed",
+				"Instance partitions were deleted but the rebalance failed; rebalance the table manually: "+err.Error(),
+			)
+		}
+	}
+}
+
+// ImportState accepts `<table_name>/<partitions_type>`.
+func (r *TableInstanceAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	table, typ, ok := strings.Cut(req.ID, "/")
+	if !ok || table == "" || typ == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected <table_name>/<partitions_type> (e.g. user_events/OFFLINE), got %q.", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"), table)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("partitions_type"), strings.ToUpper(typ))...)
+}
+
+// apply pushes the configured instance partitions and, when requested, rebalances the table onto them.
+func (r *TableInstanceAssignmentResource) apply(ctx context.Context, data *TableInstanceAssignmentResourceModel) error {
+	var partitions map[string]interface{}
+	if diags := data.InstancePartitions.Unmarshal(&partitions); diags.HasError() {
+		return fmt.Errorf("instance_partitions must be a JSON object")
+	}
+	if name, _ := partitions["instancePartitionsName"].(string); name == "" {
+		return fmt.Errorf("instance_partitions must set instancePartitionsName")
+	}
+
+	if err := r.client.UpdateInstancePartitions(ctx, data.TableName.ValueString(), partitions); err != nil {
+		return fmt.Errorf("could not update instance partitions: %w", err)
+	}
+
+	if data.Rebalance.ValueBool() {
+		if _, err := r.client.RebalanceTable(ctx, data.TableName.ValueString(), tableTypeForPartitions(data.PartitionsType.ValueString()), false); err != nil {
+			return fmt.Errorf("instance partitions were updated but the rebalance failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// tableTypeForPartitions maps an instance partitions type to the table type it belongs to.
+func tableTypeForPartitions(partitionsType string) string {
+	if strings.EqualFold(partitionsType, "OFFLINE") {
+		return "OFFLINE"
+	}
+	return "REALTIME"
+}
@@ -0,0 +1,111 @@
+// internal/provider/table_placement.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-pinot/internal/client"
+)
+
+// defaultTenant is the tenant Pinot places a table on when tenants.broker or tenants.server is not set.
+const defaultTenant = "DefaultTenant"
+
+// placementTags returns the server tags a table's segments may live on: its server tenant's tag for the table type,
+// plus any tags from tagOverrideConfig and instanceAssignmentConfigMap. Tags are `<tenant>_<TYPE>`.
+func placementTags(cfg TableConfig, tableType string) []string {
+	tenant := defaultTenant
+	if v, ok := lookupJSONPath(cfg, "tenants.server"); ok {
+		if s, ok := v.(string); ok && s != "" {
+			tenant = s
+		}
+	}
+	tags := []string{tenant + "_" + strings.ToUpper(tableType)}
+
+	for _, p := range []string{"tagOverrideConfig.realtimeConsuming", "tagOverrideConfig.realtimeCompleted"} {
+		if v, ok := lookupJSONPath(cfg, p); ok {
+			if s, ok := v.(string); ok && s != "" {
+				tags = append(tags, s)
+			}
+		}
+	}
+	if m, ok := cfg["instanceAssignmentConfigMap"].(map[string]interface{}); ok {
+		for _, assignment := range m {
+			a, _ := assignment.(map[string]interface{})
+			if v, ok := lookupJSONPath(a, "tagPoolConfig.tag"); ok {
+				if s, ok := v.(string); ok && s != "" {
+					tags = append(tags, s)
+				}
+			}
+		}
+	}
+
+	sort.Strings(tags)
+	out := tags[:0]
+	for i, t := range tags {
+		if i == 0 || t != tags[i-1] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// misplacedInstances returns, sorted, the instances in the external view that are not in allowed.
+func misplacedInstances(externalView client.SegmentAssignment, allowed map[string]bool) []string {
+	seen := map[string]bool{}
+	for _, segments := range externalView {
+		for _, replicas := range segments {
+			for instance := range replicas {
+				if !allowed[instance] {
+					seen[instance] = true
+				}
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for instance := range seen {
+		out = append(out, instance)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// warnTenantDrift adds a warning when segments of the table are served by instances outside the tenants its
+// config places it on, e.g. after a rebalance with a stale instance assignment. Lookup failures are only logged:
+// the check is advisory and must not block plans.
+func (r *TableResource) warnTenantDrift(ctx context.Context, diags *diag.Diagnostics, cfg TableConfig, tableName, tableType string) {
+	tags := placementTags(cfg, tableType)
+	allowed := map[string]bool{}
+	for _, tag := range tags {
+		i := strings.LastIndex(tag, "_")
+		if i <= 0 {
+			continue
+		}
+		servers, err := r.client.GetTenantServers(ctx, tag[:i], tag[i+1:])
+		if err != nil {
+			tflog.Debug(ctx, "Could not list Pinot tenant servers", map[string]interface{}{"tag": tag, "error": err.Error()})
+			return
+		}
+		for _, s := range servers {
+			allowed[s] = true
+		}
+	}
+
+	externalView, err := r.client.GetExternalView(ctx, tableName)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot external view", map[string]interface{}{"table": tableName, "error": err.Error()})
+		return
+	}
+
+	if misplaced := misplacedInstances(externalView, allowed); len(misplaced) > 0 {
+		diags.AddWarning(
+			"Pinot Table Placement Drift",
+			fmt.Sprintf("Segments of table %s are served by instances outside its configured placement (%s): %s. "+
+				"Rebalance the table to move them back.", tableName, strings.Join(tags, ", "), strings.Join(misplaced, ", ")),
+		)
+	}
+}
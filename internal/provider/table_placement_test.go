@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestPlacementTags(t *testing.T) {
+	cases := map[string]struct {
+		config    string
+		tableType string
+		want      []string
+	}{
+		"default tenant": {
+			config:    `{"tableName":"t_OFFLINE"}`,
+			tableType: "OFFLINE",
+			want:      []string{"DefaultTenant_OFFLINE"},
+		},
+		"tag overrides": {
+			config:    `{"tenants":{"server":"hot"},"tagOverrideConfig":{"realtimeCompleted":"cold_OFFLINE"}}`,
+			tableType: "REALTIME",
+			want:      []string{"cold_OFFLINE", "hot_REALTIME"},
+		},
+		"instance assignment": {
+			config: `{"tenants":{"server":"hot"},"instanceAssignmentConfigMap":{` +
+				`"CONSUMING":{"tagPoolConfig":{"tag":"hot_REALTIME"}},"COMPLETED":{"tagPoolConfig":{"tag":"warm_REALTIME"}}}}`,
+			tableType: "REALTIME",
+			want:      []string{"hot_REALTIME", "warm_REALTIME"},
+		},
+	}
+	for name, tc := range cases {
+		if got := placementTags(mustJSONMap(t, tc.config), tc.tableType); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: placementTags = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestMisplacedInstances(t *testing.T) {
+	externalView := client.SegmentAssignment{
+		"OFFLINE": {
+			"seg_0": {"Server_a": "ONLINE", "Server_b": "ONLINE"},
+			"seg_1": {"Server_c": "ONLINE", "Server_a": "OFFLINE"},
+		},
+	}
+	allowed := map[string]bool{"Server_a": true, "Server_b": true}
+	if got := misplacedInstances(externalView, allowed); !reflect.DeepEqual(got, []string{"Server_c"}) {
+		t.Errorf("misplacedInstances = %v, want [Server_c]", got)
+	}
+	allowed["Server_c"] = true
+	if got := misplacedInstances(externalView, allowed); len(got) != 0 {
+		t.Errorf("misplacedInstances = %v, want none", got)
+	}
+}
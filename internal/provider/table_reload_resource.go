@@ -0,0 +1,240 @@
+// internal/provider/table_reload_resource.go
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &TableReloadResource{}
+
+const defaultReloadConcurrency = 4
+
+// Reload modes: a full reload rebuilds segment indexes, a metadata refresh only reloads segment metadata.
+const (
+	reloadModeFull     = "full"
+	reloadModeMetadata = "metadata"
+)
+
+// reloadModeAttribute is the reload_mode attribute shared by pinot_table_reload and pinot_table.
+func reloadModeAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		MarkdownDescription: description + " `full` rebuilds segment indexes; `metadata` only refreshes segment metadata, " +
+			"which is much cheaper for large tables but does not apply index changes. Defaults to `full`.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(reloadModeFull, reloadModeMetadata),
+		},
+	}
+}
+
+// reloadTable reloads or refreshes one table's segments according to mode. forceDownload only applies to full reloads.
+func reloadTable(ctx context.Context, c *client.PinotClient, logical, typ string, mode types.String, forceDownload bool) error {
+	if mode.ValueString() == reloadModeMetadata {
+		return c.RefreshTable(ctx, logical, typ)
+	}
+	return c.ReloadTable(ctx, logical, typ, forceDownload)
+}
+
+type TableReloadResource struct {
+	client *client.PinotClient
+}
+
+type TableReloadResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Tables         types.List   `tfsdk:"tables"` // []string
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	ForceDownload  types.Bool   `tfsdk:"force_download"`
+	ReloadMode     types.String `tfsdk:"reload_mode"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	ControllerURL  types.String `tfsdk:"controller_url"`
+}
+
+func NewTableReloadResource() resource.Resource {
+	return &TableReloadResource{}
+}
+
+func (r *TableReloadResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_reload"
+}
+
+func (r *TableReloadResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reloads segments of a set of Pinot tables, e.g. after a cluster config change that affects index defaults. " +
+			"Reloads run on create and whenever `tables` or `triggers` change; destroying the resource does nothing.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the last reload.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tables": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Tables to reload as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`). Omit to reload every table in the cluster.",
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of reloads in flight at once. Defaults to `%d`.", defaultReloadConcurrency),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"force_download": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, servers re-download every segment from the deep store (`forceDownload=true`) instead of rebuilding indexes from their local copies, e.g. after segments were replaced in the deep store. Only applies to `full` reloads. Defaults to `false`.",
+			},
+			"reload_mode": reloadModeAttribute("How to reload the tables."),
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that trigger a new reload when changed (e.g. a hash of the cluster config).",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TableReloadResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TableReloadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TableReloadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableReloadResource{client: clientFor(r.client, data.ControllerURL)}
+
+	tables := toStringSlice(ctx, &resp.Diagnostics, data.Tables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reload(ctx, tables, &data); err != nil {
+		resp.Diagnostics.AddError("Error Reloading Pinot Tables", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps state as-is; a reload has no remote object to refresh.
+func (r *TableReloadResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *TableReloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TableReloadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableReloadResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	tables := toStringSlice(ctx, &resp.Diagnostics, plan.Tables)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Tables.Equal(state.Tables) {
+		plan.ID = state.ID
+	} else if err := r.reload(ctx, tables, &plan); err != nil {
+		resp.Diagnostics.AddError("Error Reloading Pinot Tables", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TableReloadResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// reload reloads the given tables (every table when data.Tables is null) with bounded concurrency,
+// collecting every failure.
+func (r *TableReloadResource) reload(ctx context.Context, tables []string, data *TableReloadResourceModel) error {
+	if data.Tables.IsNull() {
+		all, err := r.listAllTables(ctx)
+		if err != nil {
+			return err
+		}
+		tables = all
+	}
+
+	concurrency := defaultReloadConcurrency
+	if !data.MaxConcurrency.IsNull() && !data.MaxConcurrency.IsUnknown() {
+		concurrency = int(data.MaxConcurrency.ValueInt64())
+	}
+
+	for _, id := range tables {
+		if _, typ := splitTableID(id); typ == "" {
+			return fmt.Errorf("table %q must be in the form <logical>_OFFLINE or <logical>_REALTIME", id)
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+	for _, id := range tables {
+		logical, typ := splitTableID(id)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id, logical, typ string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := reloadTable(ctx, r.client, logical, typ, data.ReloadMode, data.ForceDownload.ValueBool()); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("reload %s: %w", id, err))
+				mu.Unlock()
+			}
+		}(id, logical, typ)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
+
+// listAllTables returns every OFFLINE and REALTIME table as `<logical>_<TYPE>`.
+func (r *TableReloadResource) listAllTables(ctx context.Context) ([]string, error) {
+	return r.client.ListTableNames(ctx, "")
+}
@@ -5,38 +5,120 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
-	"net/url"
+	"math"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-pinot/internal/client"
 )
 
 var _ resource.Resource = &TableResource{}
 var _ resource.ResourceWithImportState = &TableResource{}
+var _ resource.ResourceWithValidateConfig = &TableResource{}
+var _ resource.ResourceWithModifyPlan = &TableResource{}
 
 type TableResource struct {
 	client *client.PinotClient
 }
 
 type TableResourceModel struct {
-	ID             types.String         `tfsdk:"id"`
-	TableName      types.String         `tfsdk:"table_name"`
-	TableType      types.String         `tfsdk:"table_type"`
-	TableConfig    jsontypes.Normalized `tfsdk:"table_config"`
-	KafkaUsername  types.String         `tfsdk:"kafka_username"`
-	KafkaPassword  types.String         `tfsdk:"kafka_password"`
-	SaslJaasConfig types.String         `tfsdk:"sasl_jaas_config"`
+	ID                  types.String         `tfsdk:"id"`
+	TableName           types.String         `tfsdk:"table_name"`
+	TableType           types.String         `tfsdk:"table_type"`
+	TableConfig         jsontypes.Normalized `tfsdk:"table_config"`
+	KafkaUsername       types.String         `tfsdk:"kafka_username"`
+	KafkaPassword       types.String         `tfsdk:"kafka_password"`
+	SaslJaasConfig      types.String         `tfsdk:"sasl_jaas_config"`
+	FailOnReloadError   types.Bool           `tfsdk:"fail_on_reload_error"`
+	ReloadForceDL       types.Bool           `tfsdk:"reload_force_download"`
+	ReloadMode          types.String         `tfsdk:"reload_mode"`
+	ReloadPaths         types.List           `tfsdk:"reload_trigger_paths"` // []string
+	PurgeOnDestroy      types.Bool           `tfsdk:"purge_segments_on_destroy"`
+	InjectedSecrets     types.List           `tfsdk:"injected_secrets"` // []InjectedSecretModel
+	KafkaBootstrap      types.String         `tfsdk:"kafka_bootstrap_servers"`
+	StrictStreamCheck   types.Bool           `tfsdk:"fail_on_offline_stream_config"`
+	BrokerTenant        types.String         `tfsdk:"broker_tenant"`
+	ServerTenant        types.String         `tfsdk:"server_tenant"`
+	IDFormat            types.String         `tfsdk:"id_format"`
+	AutoSuffixName      types.Bool           `tfsdk:"auto_suffix_table_name"`
+	NullHandling        types.Bool           `tfsdk:"null_handling_enabled"`
+	AggregateMetrics    types.Bool           `tfsdk:"aggregate_metrics"`
+	ContinueOnError     types.Bool           `tfsdk:"continue_on_error"`
+	RowTimeCheck        types.Bool           `tfsdk:"row_time_value_check"`
+	MinimizeMovement    types.Bool           `tfsdk:"minimize_data_movement"`
+	MaxQPS              types.String         `tfsdk:"max_qps"`
+	QueryTimeoutMs      types.Int64          `tfsdk:"query_timeout_ms"`
+	PeerDownload        types.String         `tfsdk:"peer_segment_download_scheme"`
+	DeletedRetention    types.String         `tfsdk:"deleted_segments_retention_period"`
+	FlushRows           types.Int64          `tfsdk:"flush_threshold_rows"`
+	FlushSize           types.String         `tfsdk:"flush_threshold_segment_size"`
+	DecoderProps        types.Map            `tfsdk:"kafka_decoder_props"` // map[string]string
+	TaskConfigs         types.Map            `tfsdk:"task_configs"`        // map[string]object
+	ComplexDelimiter    types.String         `tfsdk:"complex_type_delimiter"`
+	FieldsToUnnest      types.List           `tfsdk:"complex_type_fields_to_unnest"` // []string
+	State               types.String         `tfsdk:"state"`
+	ActiveIndexes       types.Map            `tfsdk:"active_indexes"`
+	TuningConfig        jsontypes.Normalized `tfsdk:"tuning_config"`
+	CanonicalConfig     types.String         `tfsdk:"canonical_config"`
+	EffectiveAssignment types.Map            `tfsdk:"effective_segment_assignment"`
+	Rebalance           *TableRebalanceModel `tfsdk:"rebalance"`
+	RebalancePlan       jsontypes.Normalized `tfsdk:"rebalance_plan"`
+	RebalanceJobID      types.String         `tfsdk:"rebalance_job_id"`
+	ConfigDiff          types.String         `tfsdk:"config_diff"`
+	ConfigVersion       types.String         `tfsdk:"config_version"`
+	AutoCreateSchema    types.Bool           `tfsdk:"auto_create_schema"`
+	ValidateTenants     types.Bool           `tfsdk:"validate_tenants"`
+	Schema              jsontypes.Normalized `tfsdk:"schema"`
+	ControllerURL       types.String         `tfsdk:"controller_url"`
+}
+
+// InjectedSecretModel is a secret value set at a JSON path of the table config before it is sent to Pinot.
+type InjectedSecretModel struct {
+	JSONPath types.String `tfsdk:"json_path"`
+	Value    types.String `tfsdk:"value"`
+}
+
+// TableRebalanceModel controls the rebalance run after a table update.
+type TableRebalanceModel struct {
+	DryRun               types.Bool  `tfsdk:"dry_run"`
+	Bootstrap            types.Bool  `tfsdk:"bootstrap"`
+	LowDiskMode          types.Bool  `tfsdk:"low_disk_mode"`
+	BestEfforts          types.Bool  `tfsdk:"best_efforts"`
+	MinAvailableReplicas types.Int64 `tfsdk:"min_available_replicas"`
+}
+
+// options converts the rebalance block to the controller's rebalance options.
+func (m *TableRebalanceModel) options() client.RebalanceOptions {
+	opts := client.RebalanceOptions{
+		DryRun:      m.DryRun.ValueBool(),
+		Bootstrap:   m.Bootstrap.ValueBool(),
+		LowDiskMode: m.LowDiskMode.ValueBool(),
+		BestEfforts: m.BestEfforts.ValueBool(),
+	}
+	if !m.MinAvailableReplicas.IsNull() && !m.MinAvailableReplicas.IsUnknown() {
+		v := m.MinAvailableReplicas.ValueInt64()
+		opts.MinAvailableReplicas = &v
+	}
+	return opts
 }
 
 // Treat table config as a passthrough JSON object so we don't drop fields.
@@ -52,11 +134,13 @@ func (r *TableResource) Metadata(ctx context.Context, req resource.MetadataReque
 
 func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Pinot table (OFFLINE or REALTIME)",
+		MarkdownDescription: "Manages a Pinot table (OFFLINE or REALTIME). Refreshing the table warns when its segments are served by servers " +
+			"outside the tenants its config places it on (placement drift), which a rebalance fixes.",
 		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Table identifier `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+				MarkdownDescription: "Table identifier: `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`), or the logical name when `id_format = \"logical\"`.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -97,6 +181,299 @@ func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Sensitive:           true,
 				MarkdownDescription: "Computed sensitive value containing the injected sasl.jaas.config when kafka_username and kafka_password are provided.",
 			},
+			"fail_on_reload_error": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, a failed segment reload after an update fails the apply instead of emitting a warning. Defaults to `false`.",
+			},
+			"purge_segments_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("When `true`, destroying the table first deletes its segments in batches of %d, which keeps the table delete fast for large tables. Segments that fail to delete produce a warning and the table is deleted anyway. Defaults to `false`.", purgeBatchSize),
+			},
+			"reload_force_download": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, the segment reload after an update re-downloads every segment from the deep store (`forceDownload=true`) instead of rebuilding indexes from the servers' local copies. Only applies to `full` reloads. Defaults to `false`.",
+			},
+			"reload_mode": reloadModeAttribute("How to reload segments after an update."),
+			"reload_trigger_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				MarkdownDescription: "`table_config` paths whose change requires reloading segments after an update, e.g. `tableIndexConfig` or " +
+					"`ingestionConfig.transformConfigs`. A change at, below or above one of these paths triggers the reload; typed attributes count " +
+					"by the key they set. Updates that change nothing else (descriptions, metadata, quotas, credentials) skip the reload. " +
+					"Defaults to `" + strings.Join(defaultReloadTriggerPaths, "`, `") + "`; an empty list never reloads.",
+			},
+			"kafka_bootstrap_servers": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Optional Kafka bootstrap servers (e.g. `kafka-0:9092,kafka-1:9092`) to set as `stream.kafka.broker.list` and `bootstrap.servers` in every stream config map. When set, do not also set these keys in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`\S`), "must not be empty"),
+				},
+			},
+			"auto_create_schema": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, create the schema given in `schema` before the table if it does not exist yet. Only applies on create; the schema is not updated or deleted with the table. Defaults to `false`.",
+			},
+			"validate_tenants": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When `true`, check before creating the table that the tenants `table_config` names in `tenants.broker` and `tenants.server` exist, " +
+					"since the controller may accept a table on a missing tenant that then cannot be queried. Only applies on create. Defaults to `false`.",
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON schema to create when `auto_create_schema` is `true`. Its `schemaName` must match `segmentsConfig.schemaName` (or `table_name` when unset). For REALTIME tables, a warning is shown at plan time when ingestion transforms or the time column refer to columns it does not define; for any table, when `segmentsConfig.timeType` disagrees with the unit of the time column's format. When its `schemaName` is `table_name`, the controller validates the table config against it (`POST /tableConfigs/validate`) before every create and update.",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Current table state reported by the controller: `enabled` or `disabled`. Null when the controller does not support the table state endpoint.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"active_indexes": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+				MarkdownDescription: "Index types Pinot has built per column (e.g. `inverted_index`, `range_index`), aggregated over the table's segments. Null when the controller does not support the indexes endpoint.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"auto_suffix_table_name": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When `true`, `tableName` in `table_config` may be the logical name (`table_name`, as written by some config generators); " +
+					"it is sent to Pinot as `<table_name>_<TYPE>` and kept as written in state. Any other name is still rejected. Defaults to `false`, which requires the suffixed name.",
+			},
+			"canonical_config": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The table config as the controller holds it, including the values of typed attributes and server defaults, " +
+					"with keys sorted and no insignificant whitespace, so it can be output and compared across environments as-is. " +
+					"`sasl.jaas.config` and `injected_secrets` are stripped. Identical configs always render identically.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tuning_config": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Tuner configs (`tunerConfigs`) the controller applies to the table, as JSON, read from the combined `/tableConfigs` view. Null when the table has none or the controller does not serve `/tableConfigs`.",
+				CustomType:          jsontypes.NormalizedType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"effective_segment_assignment": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				MarkdownDescription: "Segment assignment strategy in effect per instance partitions type (`OFFLINE`, or `CONSUMING` and `COMPLETED`), " +
+					"read from the table's instance partitions, e.g. `ReplicaGroup (2 replica groups, 4 partitions)`, or `Balanced (default)` when none are stored. " +
+					"It can differ from `table_config` until the table is rebalanced. Null when the instance partitions can't be read.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Format of the computed `id`: `suffixed` (default, `<logical>_<TYPE>`) or `logical` (the plain table name, for cross-module references).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(idFormatSuffixed, idFormatLogical),
+				},
+			},
+			"broker_tenant": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Broker tenant to set as `tenants.broker` in the table config. The tenant must exist. When set, do not also set `tenants.broker` in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"server_tenant": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Server tenant to set as `tenants.server` in the table config. The tenant must exist. When set, do not also set `tenants.server` in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"aggregate_metrics": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `tableIndexConfig.aggregateMetrics`: consuming segments pre-aggregate metric columns of rows with equal dimensions. Only applies to REALTIME tables. When set, do not also set `aggregateMetrics` in `table_config`.",
+			},
+			"continue_on_error": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `ingestionConfig.continueOnError`: rows that fail transformation or validation are dropped instead of failing ingestion. When set, do not also set `continueOnError` in `table_config`.",
+			},
+			"row_time_value_check": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `ingestionConfig.rowTimeValueCheck`: the time column of every ingested row is checked and out-of-range values are nulled. When set, do not also set `rowTimeValueCheck` in `table_config`.",
+			},
+			"null_handling_enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `tableIndexConfig.nullHandlingEnabled`. When set, do not also set `nullHandlingEnabled` in `table_config`.",
+			},
+			"minimize_data_movement": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `segmentsConfig.minimizeDataMovement`, which makes rebalances move as few segments as possible. When set, do not also set `minimizeDataMovement` in `table_config`.",
+			},
+			"max_qps": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `quota.maxQueriesPerSecond`, the per-table query rate limit (a positive number, e.g. `\"100\"` or `\"12.5\"`). Changing only this attribute updates the table config without reloading segments. When set, do not also set `maxQueriesPerSecond` in `table_config`.",
+			},
+			"query_timeout_ms": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `query.timeoutMs`, the per-table query timeout in milliseconds. Changing only this attribute updates the table config without reloading segments. When set, do not also set `timeoutMs` in `table_config`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"peer_segment_download_scheme": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `segmentsConfig.peerSegmentDownloadScheme` (`http` or `https`), letting servers download segments from peers when the deep store is unavailable. When set, do not also set `peerSegmentDownloadScheme` in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("http", "https"),
+				},
+			},
+			"deleted_segments_retention_period": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `segmentsConfig.deletedSegmentsRetentionPeriod`, how long deleted segments are kept in the controller's deleted-segments area before being purged, as a period such as `7d` or `1d12h` (`0d` purges them immediately). When set, do not also set `deletedSegmentsRetentionPeriod` in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.RegexMatches(retentionPeriodPattern, "must be a period such as 7d, 12h or 1d12h"),
+				},
+			},
+			"flush_threshold_rows": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `" + flushThresholdRowsKey + "` in every stream config map: consuming segments are committed after this many rows. Conflicts with `flush_threshold_segment_size`. When set, do not also set a flush threshold in `table_config`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("flush_threshold_segment_size")),
+				},
+			},
+			"flush_threshold_segment_size": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `" + flushThresholdSizeKey + "` in every stream config map: consuming segments are committed when they reach about this size (e.g. `200M`). Conflicts with `flush_threshold_rows`. When set, do not also set a flush threshold in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dataSizePattern, "must be a data size such as 200M or 1.5G"),
+				},
+			},
+			"kafka_decoder_props": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Kafka message decoder properties, set as `" + kafkaDecoderPropPrefix + "<key>` in every stream config map (e.g. `{ \"schema.registry.rest.url\" = \"http://registry:8081\" }`). When set, this map owns every decoder property, so do not also set `" + kafkaDecoderPropPrefix + "*` keys in `table_config`.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"task_configs": schema.MapNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Common minion task settings keyed by task type (e.g. `RealtimeToOfflineSegmentsTask`), merged into `" + taskTypeConfigsPath + "`. " +
+					"Other keys and task types can still be set in `table_config`, but not the keys set here.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bucket_time_period": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Sets `bucketTimePeriod`, the time range of data each task run processes, as a period such as `1d` or `6h`.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.RegexMatches(retentionPeriodPattern, "must be a period such as 1d, 6h or 1d12h"),
+							},
+						},
+						"buffer_time_period": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Sets `bufferTimePeriod`, how old data must be before a task run picks it up, as a period such as `2d`.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.RegexMatches(retentionPeriodPattern, "must be a period such as 2d, 12h or 1d12h"),
+							},
+						},
+						"merge_type": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Sets `mergeType`: `concat`, `rollup` or `dedup`.",
+							Validators: []validator.String{
+								stringvalidator.OneOfCaseInsensitive("concat", "rollup", "dedup"),
+							},
+						},
+					},
+				},
+			},
+			"complex_type_delimiter": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sets `ingestionConfig.complexTypeConfig.delimiter`, the separator used when flattening nested JSON fields into column names (Pinot defaults to `.`). When set, do not also set `delimiter` in `table_config`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"complex_type_fields_to_unnest": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Sets `ingestionConfig.complexTypeConfig.fieldsToUnnest`, the nested array fields to unnest into one row per element. When set, do not also set `fieldsToUnnest` in `table_config`.",
+			},
+			"fail_on_offline_stream_config": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, an OFFLINE table whose `table_config` includes stream configuration is rejected instead of producing a warning. Defaults to `false`.",
+			},
+			"injected_secrets": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Secrets to set into the table config before it is sent to Pinot (e.g. HTTP stream basic-auth or JDBC passwords). Injected values are stripped from `table_config` in state.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"json_path": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Path of the value inside the table config, e.g. `ingestionConfig.batchIngestionConfig.batchConfigMaps[0]['jdbc.password']`. Keys containing dots must use the bracket form.",
+						},
+						"value": schema.StringAttribute{
+							Required:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Secret value to inject.",
+						},
+					},
+				},
+			},
+			"rebalance": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Rebalances the table after each update. With `dry_run = true` nothing is moved; instead the controller's proposed assignment for the current table config is shown in `rebalance_plan` during `terraform plan`.",
+				Attributes: map[string]schema.Attribute{
+					"dry_run": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Only compute the rebalance plan. Defaults to `false`.",
+					},
+					"bootstrap": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Reassign every segment from scratch instead of minimizing data movement (`bootstrap=true`). Defaults to `false`.",
+					},
+					"low_disk_mode": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Add new segment replicas only after the old ones are dropped, for servers short on disk (`lowDiskMode=true`). Defaults to `false`.",
+					},
+					"best_efforts": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Keep rebalancing when `min_available_replicas` cannot be maintained instead of failing (`bestEfforts=true`). Defaults to `false`.",
+					},
+					"min_available_replicas": schema.Int64Attribute{
+						Optional: true,
+						MarkdownDescription: "Replicas of each segment kept serving while it moves (`minAvailableReplicas`); a negative value is the number of replicas that may be unavailable instead. " +
+							"Must be less than the table's replication in absolute value. Defaults to the controller's default (`1`).",
+					},
+				},
+			},
+			"rebalance_job_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Job ID of the last rebalance started by an update, for use with the `pinot_controller_jobs` data source. Null until an update rebalances the table.",
+			},
+			"rebalance_plan": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Summary of the rebalance dry run (status, description and segment movement summary) when `rebalance.dry_run` is `true`; null otherwise.",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"config_diff": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Key-level difference between the current and the planned `table_config`, one line per changed key " +
+					"(`+` added, `-` removed, `~` changed), shown during `terraform plan` to ease reviews. Null when `table_config` does not change.",
+			},
+			"config_version": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Version (ETag) of the table config as last read from the controller. Updates are sent with `If-Match` " +
+					"and fail instead of overwriting the table when someone else changed it since. Null when the controller does not version table configs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -118,131 +495,301 @@ func (r *TableResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
-func (r *TableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *TableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data TableResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Do NOT decode into a struct — keep all fields.
+	// Values may be unknown until apply (e.g. interpolated from other resources); validate what we can.
+	if data.TableType.IsUnknown() || data.TableType.IsNull() || data.TableConfig.IsUnknown() || data.TableConfig.IsNull() {
+		return
+	}
+
 	var tableConfig TableConfig
-	diags := data.TableConfig.Unmarshal(&tableConfig)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.TableConfig.Unmarshal(&tableConfig)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
-
-	// Validate tableName and tableType in the provided JSON.
-	if tn, _ := tableConfig["tableName"].(string); tn != fullTableName {
-		resp.Diagnostics.AddError(
-			"Table Name Mismatch",
-			fmt.Sprintf("The table configuration name must be %s", fullTableName),
+	if strings.EqualFold(data.TableType.ValueString(), "REALTIME") && len(streamConfigMapsOf(tableConfig)) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_config"),
+			"Missing Stream Configuration",
+			"REALTIME tables require stream configuration in ingestionConfig.streamIngestionConfig.streamConfigMaps "+
+				"(or the legacy tableIndexConfig.streamConfigs).",
 		)
-		return
 	}
-	if tt, _ := tableConfig["tableType"].(string); !strings.EqualFold(tt, data.TableType.ValueString()) {
-		resp.Diagnostics.AddError(
-			"Table Type Mismatch",
-			fmt.Sprintf("The table configuration type must be %s", strings.ToUpper(data.TableType.ValueString())),
-		)
-		return
+
+	for _, m := range streamConfigMapsOf(tableConfig) {
+		if err := checkFlushThresholds(m); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Conflicting Flush Thresholds", err.Error())
+			break
+		}
+	}
+	if !data.FlushRows.IsNull() || !data.FlushSize.IsNull() {
+		attr := "flush_threshold_rows"
+		if data.FlushRows.IsNull() {
+			attr = "flush_threshold_segment_size"
+		}
+		if strings.EqualFold(data.TableType.ValueString(), "OFFLINE") {
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Invalid Flush Threshold", attr+" only applies to REALTIME tables.")
+		}
+		for _, m := range streamConfigMapsOf(tableConfig) {
+			if k := flushThresholdKeyOf(m); k != "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attr),
+					"Conflicting Table Configuration",
+					fmt.Sprintf("%s is set but table_config also sets %q; set the flush threshold in only one place.", attr, k),
+				)
+				break
+			}
+		}
 	}
 
-	saslValue, err := buildSaslIfProvided(&data)
-	if err != nil {
-		resp.Diagnostics.AddError("Kafka Credentials Incomplete", err.Error())
-		return
+	if !data.DecoderProps.IsNull() {
+		if strings.EqualFold(data.TableType.ValueString(), "OFFLINE") {
+			resp.Diagnostics.AddAttributeError(path.Root("kafka_decoder_props"), "Invalid Decoder Properties", "kafka_decoder_props only applies to REALTIME tables.")
+		}
+		if keys := kafkaDecoderPropKeys(tableConfig); len(keys) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("kafka_decoder_props"),
+				"Conflicting Table Configuration",
+				fmt.Sprintf("kafka_decoder_props is set but table_config also sets %q; set decoder properties in only one place.", keys[0]),
+			)
+		}
 	}
-	if saslValue != "" {
-		injectKafkaSasl(&tableConfig, saslValue)
+
+	if data.Rebalance != nil && !data.Rebalance.MinAvailableReplicas.IsNull() && !data.Rebalance.MinAvailableReplicas.IsUnknown() {
+		if replication, ok := tableReplication(tableConfig); ok {
+			if n := data.Rebalance.MinAvailableReplicas.ValueInt64(); n >= replication || -n >= replication {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("rebalance").AtName("min_available_replicas"),
+					"Invalid Rebalance Options",
+					fmt.Sprintf("min_available_replicas must be between %d and %d for a table with replication %d, got %d.", 1-replication, replication-1, replication, n),
+				)
+			}
+		}
 	}
 
-	// Create table via API (passthrough JSON).
-	if err := r.client.CreateTable(ctx, tableConfig); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Pinot Table",
-			"Could not create table, unexpected error: "+err.Error(),
+	if data.AggregateMetrics.ValueBool() && strings.EqualFold(data.TableType.ValueString(), "OFFLINE") {
+		resp.Diagnostics.AddAttributeError(path.Root("aggregate_metrics"), "Invalid Table Configuration", "aggregate_metrics only applies to REALTIME tables.")
+	}
+
+	if conflicts := taskConfigConflicts(tableConfig, data.TaskConfigs); len(conflicts) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("task_configs"),
+			"Conflicting Table Configuration",
+			fmt.Sprintf("task_configs is set but table_config also sets %s; set each task config key in only one place.", conflicts[0]),
 		)
-		return
 	}
 
-	// For state: remove any sasl.jaas.config from the table_config JSON (we store it in a top-level sensitive attr instead).
-	cleanForState := removeSaslJaasFromTableConfig(tableConfig)
-	configJSON, err := json.Marshal(cleanForState)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Marshaling Table Config",
-			"Could not marshal table configuration to JSON for state: "+err.Error(),
+	for _, o := range tableConfigOverrides(&data) {
+		if _, ok := lookupJSONPath(tableConfig, o.path); ok && o.active {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(o.attribute),
+				"Conflicting Table Configuration",
+				fmt.Sprintf("%s is also set in table_config (%s); set it in only one place.", o.attribute, o.path),
+			)
+		}
+	}
+
+	if !data.MaxQPS.IsNull() && !data.MaxQPS.IsUnknown() {
+		if qps, err := strconv.ParseFloat(strings.TrimSpace(data.MaxQPS.ValueString()), 64); err != nil || qps <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_qps"),
+				"Invalid Query Rate Limit",
+				fmt.Sprintf("max_qps must be a positive number, got %q.", data.MaxQPS.ValueString()),
+			)
+		}
+	}
+
+	for section, keys := range sectionBoolKeys {
+		for _, k := range keys {
+			if v, ok := lookupJSONPath(tableConfig, section+"."+k); ok {
+				if _, isBool := asBool(v); !isBool {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("table_config"),
+						"Invalid Table Configuration",
+						fmt.Sprintf("%s.%s must be a boolean, got %v.", section, k, v),
+					)
+				}
+			}
+		}
+	}
+
+	if v, ok := lookupJSONPath(tableConfig, "ingestionConfig.complexTypeConfig.fieldsToUnnest"); ok {
+		list, isList := v.([]interface{})
+		for _, el := range list {
+			if _, isString := el.(string); !isString {
+				isList = false
+			}
+		}
+		if !isList {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("table_config"),
+				"Invalid Table Configuration",
+				fmt.Sprintf("ingestionConfig.complexTypeConfig.fieldsToUnnest must be a list of field names, got %v.", v),
+			)
+		}
+	}
+
+	if data.AutoCreateSchema.ValueBool() && data.Schema.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema"),
+			"Missing Schema",
+			"auto_create_schema requires the schema JSON to be set inline.",
 		)
-		return
 	}
-	data.TableConfig = jsontypes.NewNormalizedValue(string(configJSON))
 
-	// Set ID and computed sensitive attribute if we built saslValue.
-	data.ID = types.StringValue(fullTableName)
-	if saslValue != "" {
-		data.SaslJaasConfig = types.StringValue(saslValue)
-	} else {
-		data.SaslJaasConfig = types.StringNull()
+	var schemaConfig SchemaConfig
+	if !data.Schema.IsNull() && !data.Schema.IsUnknown() {
+		if diags := data.Schema.Unmarshal(&schemaConfig); diags.HasError() {
+			schemaConfig = nil
+		}
+	}
+	if schemaConfig != nil && strings.EqualFold(data.TableType.ValueString(), "REALTIME") {
+		for _, ref := range missingSchemaColumns(tableConfig, schemaConfig) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("table_config"),
+				"Column Missing From Schema",
+				fmt.Sprintf("%s refers to column %q, which the schema does not define; ingestion will fail for this table.", ref.source, ref.column),
+			)
+		}
+	}
+	if schemaConfig != nil {
+		if column, timeType, unit, ok := timeTypeMismatch(tableConfig, schemaConfig); ok {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("table_config"),
+				"Time Type Does Not Match Schema",
+				fmt.Sprintf("segmentsConfig.timeType is %s but the schema's format for time column %q counts %s; "+
+					"time filters and retention will treat its values in the wrong unit.", timeType, column, unit),
+			)
+		}
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if strings.EqualFold(data.TableType.ValueString(), "OFFLINE") && len(streamConfigMapsOf(tableConfig)) > 0 {
+		summary := "Unexpected Stream Configuration"
+		detail := "OFFLINE tables do not consume from streams; the stream configuration in table_config is likely copied from a REALTIME template."
+		if data.StrictStreamCheck.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("table_config"), summary, detail)
+		} else {
+			resp.Diagnostics.AddAttributeWarning(path.Root("table_config"), summary, detail+" Set fail_on_offline_stream_config = true to reject it.")
+		}
+	}
 }
 
-func (r *TableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data TableResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
+// ModifyPlan computes id from table_name, table_type and id_format so that changing id_format is an in-place update.
+func (r *TableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	// Get table configuration from API by suffixed ID.
-	tableConfig, err := r.client.GetTable(ctx, data.ID.ValueString())
-	if err != nil {
-		// If the server returns 404, drop state.
-		if strings.Contains(err.Error(), "404") {
-			resp.State.RemoveResource(ctx)
-			return
-		}
-		resp.Diagnostics.AddError(
-			"Error Reading Pinot Table",
-			"Could not read table ID "+data.ID.ValueString()+": "+err.Error(),
-		)
+	var plan TableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &TableResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	// The values read back from the controller keep their prior state unless an update runs, which can change
+	// any of them.
+	if !req.State.Raw.IsNull() && !req.Plan.Raw.Equal(req.State.Raw) {
+		for name, v := range map[string]attr.Value{
+			"active_indexes":               types.MapUnknown(types.ListType{ElemType: types.StringType}),
+			"tuning_config":                jsontypes.NewNormalizedUnknown(),
+			"effective_segment_assignment": types.MapUnknown(types.StringType),
+			"canonical_config":             types.StringUnknown(),
+			"config_version":               types.StringUnknown(),
+		} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), v)...)
+		}
+	}
 
-	// Normalize and store the table configuration JSON.
-	// Remove sasl.jaas.config before placing into state so we don't store the secret inside table_config.
-	cleanForState := removeSaslJaasFromTableConfig(tableConfig)
-	configJSON, err := json.Marshal(cleanForState)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Marshaling Table Config",
-			"Could not marshal table configuration to JSON: "+err.Error(),
-		)
+	if plan.TableName.IsUnknown() || plan.TableType.IsUnknown() || plan.IDFormat.IsUnknown() {
 		return
 	}
 
-	data.TableConfig = jsontypes.NewNormalizedValue(string(configJSON))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), tableStateID(plan.TableName.ValueString(), plan.TableType.ValueString(), plan.IDFormat.ValueString()))...)
+
+	// Dry-run rebalances are computed at plan time so the proposal can be reviewed before apply.
+	// There is nothing to rebalance until the table exists.
+	rebalancePlan := jsontypes.NewNormalizedNull()
+	if plan.Rebalance != nil && plan.Rebalance.DryRun.ValueBool() && !req.State.Raw.IsNull() && r.client != nil {
+		summary, err := r.rebalanceSummary(ctx, plan.TableName.ValueString(), plan.TableType.ValueString(), plan.Rebalance.options())
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("rebalance_plan"),
+				"Pinot Rebalance Dry Run Failed",
+				"Could not compute the rebalance plan: "+err.Error(),
+			)
+		} else {
+			rebalancePlan = jsontypes.NewNormalizedValue(summary)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rebalance_plan"), rebalancePlan)...)
 
-	// Do NOT attempt to discover or populate password from remote API.
-	// We will set sasl_jaas_config to null unless the user provided it in plan/apply.
-	data.SaslJaasConfig = types.StringNull()
+	if plan.TableConfig.IsUnknown() {
+		return
+	}
+	configDiff := types.StringNull()
+	if !req.State.Raw.IsNull() {
+		var prior TableResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var before, after TableConfig
+		resp.Diagnostics.Append(prior.TableConfig.Unmarshal(&before)...)
+		resp.Diagnostics.Append(plan.TableConfig.Unmarshal(&after)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if lines := diffJSON(before, after); len(lines) > 0 {
+			configDiff = types.StringValue(strings.Join(lines, "\n"))
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("config_diff"), configDiff)...)
+}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// tableReplication returns segmentsConfig.replication, which Pinot accepts as a string or a number.
+func tableReplication(cfg TableConfig) (int64, bool) {
+	v, ok := lookupJSONPath(cfg, "segmentsConfig.replication")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(fmt.Sprint(v)), 10, 64)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
 }
 
-func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+// rebalanceSummary runs a rebalance dry run and returns the parts of the result worth reviewing as JSON.
+func (r *TableResource) rebalanceSummary(ctx context.Context, logical, typ string, opts client.RebalanceOptions) (string, error) {
+	result, err := r.client.RebalanceTableWithOptions(ctx, logical, typ, opts)
+	if err != nil {
+		return "", err
+	}
+	summary := map[string]interface{}{}
+	for _, k := range []string{"status", "description", "rebalanceSummaryResult"} {
+		if v, ok := result[k]; ok {
+			summary[k] = v
+		}
+	}
+	return canonicalJSON(summary)
+}
+
+func (r *TableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TableResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &TableResource{client: clientFor(r.client, data.ControllerURL)}
 
-	// Keep all fields from user JSON.
+	// Do NOT decode into a struct — keep all fields.
 	var tableConfig TableConfig
 	diags := data.TableConfig.Unmarshal(&tableConfig)
 	resp.Diagnostics.Append(diags...)
@@ -250,16 +797,18 @@ func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Optional sanity validation.
 	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
-	if tn, _ := tableConfig["tableName"].(string); tn != "" && tn != fullTableName {
+	bareName := data.AutoSuffixName.ValueBool() && suffixConfigTableName(tableConfig, data.TableName.ValueString(), fullTableName)
+
+	// Validate tableName and tableType in the provided JSON.
+	if tn, _ := tableConfig["tableName"].(string); tn != fullTableName {
 		resp.Diagnostics.AddError(
 			"Table Name Mismatch",
 			fmt.Sprintf("The table configuration name must be %s", fullTableName),
 		)
 		return
 	}
-	if tt, _ := tableConfig["tableType"].(string); tt != "" && !strings.EqualFold(tt, data.TableType.ValueString()) {
+	if tt, _ := tableConfig["tableType"].(string); !strings.EqualFold(tt, data.TableType.ValueString()) {
 		resp.Diagnostics.AddError(
 			"Table Type Mismatch",
 			fmt.Sprintf("The table configuration type must be %s", strings.ToUpper(data.TableType.ValueString())),
@@ -276,26 +825,365 @@ func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest,
 		injectKafkaSasl(&tableConfig, saslValue)
 	}
 
-	// Update via API (passthrough JSON).
-	if err := r.client.UpdateTable(ctx, tableConfig); err != nil {
+	secrets := injectedSecretsFromModel(ctx, &resp.Diagnostics, data.InjectedSecrets)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := applyInjectedSecrets(tableConfig, secrets); err != nil {
+		resp.Diagnostics.AddError("Invalid Injected Secret", err.Error())
+		return
+	}
+
+	bootstrapServers := !data.KafkaBootstrap.IsNull()
+	if bootstrapServers {
+		if err := injectKafkaBootstrapServers(&tableConfig, data.KafkaBootstrap.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("kafka_bootstrap_servers"), "Conflicting Kafka Bootstrap Servers", err.Error())
+			return
+		}
+	}
+	if err := injectFlushThresholds(tableConfig, data.FlushRows, data.FlushSize); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Conflicting Flush Thresholds", err.Error())
+		return
+	}
+	if err := injectKafkaDecoderProps(ctx, tableConfig, data.DecoderProps); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("kafka_decoder_props"), "Conflicting Decoder Properties", err.Error())
+		return
+	}
+
+	userConfig, _ := deepCopyJSON(tableConfig).(map[string]interface{})
+	if err := injectTaskConfigs(tableConfig, data.TaskConfigs); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("task_configs"), "Conflicting Task Configuration", err.Error())
+		return
+	}
+	overrides := append(tableConfigOverrides(&data), managedByOverrides(r.client.ManagedByTags(), userConfig)...)
+	if err := applyConfigOverrides(tableConfig, overrides); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Conflicting Table Configuration", err.Error())
+		return
+	}
+	if err := r.validateTenantsExist(ctx, data.BrokerTenant, data.ServerTenant); err != nil {
+		resp.Diagnostics.AddError("Unknown Pinot Tenant", err.Error())
+		return
+	}
+	if data.ValidateTenants.ValueBool() {
+		serverTenants, brokerTenants, err := r.client.ListTenants(ctx)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Validating Pinot Tenants", "Could not list tenants", err)
+			return
+		}
+		for _, msg := range missingTenants(tableConfig, serverTenants, brokerTenants) {
+			resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Unknown Pinot Tenant", msg)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if err := r.validateWithSchema(ctx, &data, tableConfig); err != nil {
+		addAPIError(&resp.Diagnostics, "Invalid Pinot Table Config", "Table "+fullTableName+" does not validate against its schema", err)
+		return
+	}
+
+	createdSchema, err := r.autoCreateSchema(ctx, &data, tableConfig)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("schema"), "Error Auto-Creating Pinot Schema", err.Error())
+		return
+	}
+
+	// Create table via API (passthrough JSON).
+	if err := r.client.CreateTable(ctx, tableConfig); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Pinot Table", "Could not create table "+fullTableName, err)
+		// Don't leave behind a schema that only existed for this table.
+		if createdSchema != "" {
+			if delErr := r.client.DeleteSchema(ctx, createdSchema); delErr != nil {
+				resp.Diagnostics.AddWarning(
+					"Pinot Schema Cleanup Failed",
+					fmt.Sprintf("Schema %s was auto-created for this table but could not be deleted: %v", createdSchema, delErr),
+				)
+			}
+		}
+		return
+	}
+
+	// For state: remove sasl.jaas.config and injected secrets from the table_config JSON (secrets stay in sensitive attrs instead).
+	cleanForState := cleanTableConfigForState(tableConfig, secrets, bootstrapServers)
+	cleanForState = stripFlushThresholds(cleanForState, data.FlushRows, data.FlushSize)
+	cleanForState = stripKafkaDecoderProps(cleanForState, data.DecoderProps)
+	cleanForState = stripTaskConfigs(cleanForState, userConfig, data.TaskConfigs)
+	cleanForState = stripConfigOverrides(cleanForState, userConfig, overrides)
+	if bareName {
+		cleanForState["tableName"] = data.TableName.ValueString()
+	}
+	configJSON, err := canonicalJSON(cleanForState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Marshaling Table Config",
+			"Could not marshal table configuration to JSON for state: "+err.Error(),
+		)
+		return
+	}
+	data.TableConfig = jsontypes.NewNormalizedValue(configJSON)
+
+	// Set ID and computed sensitive attribute if we built saslValue.
+	data.ID = types.StringValue(tableStateID(data.TableName.ValueString(), data.TableType.ValueString(), data.IDFormat.ValueString()))
+	if saslValue != "" {
+		data.SaslJaasConfig = types.StringValue(saslValue)
+	} else {
+		data.SaslJaasConfig = types.StringNull()
+	}
+	data.State = r.readTableState(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.ActiveIndexes = r.readActiveIndexes(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.EffectiveAssignment = r.readEffectiveSegmentAssignment(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.TuningConfig = r.readTuningConfig(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.CanonicalConfig = r.readCanonicalConfig(ctx, fullTableName, secrets)
+	// A new table has nothing to rebalance yet.
+	data.RebalancePlan = jsontypes.NewNormalizedNull()
+	data.RebalanceJobID = types.StringNull()
+	data.ConfigDiff = types.StringNull()
+	data.ConfigVersion = r.readConfigVersion(ctx, fullTableName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableResource{client: clientFor(r.client, data.ControllerURL)}
+
+	// Get table configuration from API by suffixed name; id may be the plain logical name.
+	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
+	tableConfig, version, err := r.client.GetTableWithVersion(ctx, fullTableName)
+	if err != nil {
+		// If the server returns 404, drop state.
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Table", "Could not read table "+fullTableName, err)
+		return
+	}
+
+	// Keep the user's representation of boolean toggles Pinot normalizes on write.
+	var priorConfig TableConfig
+	if !data.TableConfig.IsNull() && !data.TableConfig.IsUnknown() {
+		resp.Diagnostics.Append(data.TableConfig.Unmarshal(&priorConfig)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// Remove sasl.jaas.config and injected secrets before placing into state so we don't store them inside table_config.
+	secrets := injectedSecretsFromModel(ctx, &resp.Diagnostics, data.InjectedSecrets)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.CanonicalConfig = canonicalTableConfig(tableConfig, secrets)
+	tableConfig = normalizeStreamIngestionToggles(tableConfig, priorConfig)
+
+	// Normalize and store the table configuration JSON.
+	bootstrapServers := !data.KafkaBootstrap.IsNull()
+	if bootstrapServers {
+		if maps := streamConfigMapsOf(tableConfig); len(maps) > 0 {
+			if v, ok := maps[0][kafkaBrokerListKey].(string); ok {
+				data.KafkaBootstrap = types.StringValue(v)
+			}
+		}
+	}
+	refreshFlushThresholds(tableConfig, &data)
+	refreshKafkaDecoderProps(tableConfig, &data)
+	refreshTaskConfigs(tableConfig, &data)
+	overrides := append(tableConfigOverrides(&data), managedByOverrides(r.client.ManagedByTags(), priorConfig)...)
+	refreshConfigOverrides(tableConfig, overrides)
+	tableConfig = normalizeSectionToggles(tableConfig, priorConfig)
+
+	cleanForState := cleanTableConfigForState(tableConfig, secrets, bootstrapServers)
+	cleanForState = stripFlushThresholds(cleanForState, data.FlushRows, data.FlushSize)
+	cleanForState = stripKafkaDecoderProps(cleanForState, data.DecoderProps)
+	cleanForState = stripTaskConfigs(cleanForState, priorConfig, data.TaskConfigs)
+	cleanForState = stripConfigOverrides(cleanForState, priorConfig, overrides)
+	cleanForState = stripDefaultTenants(cleanForState, priorConfig)
+	cleanForState = restoreLogicalTableName(cleanForState, priorConfig, data.TableName.ValueString(), fullTableName)
+	configJSON, err := canonicalJSON(cleanForState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Marshaling Table Config",
+			"Could not marshal table configuration to JSON: "+err.Error(),
+		)
+		return
+	}
+
+	data.TableConfig = jsontypes.NewNormalizedValue(configJSON)
+
+	// Do NOT attempt to discover or populate password from remote API.
+	// We will set sasl_jaas_config to null unless the user provided it in plan/apply.
+	data.SaslJaasConfig = types.StringNull()
+	data.State = r.readTableState(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.ActiveIndexes = r.readActiveIndexes(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.EffectiveAssignment = r.readEffectiveSegmentAssignment(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.TuningConfig = r.readTuningConfig(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	r.warnTenantDrift(ctx, &resp.Diagnostics, tableConfig, fullTableName, data.TableType.ValueString())
+	// The diff only describes a pending change; once applied there is nothing left to show.
+	data.ConfigDiff = types.StringNull()
+	data.ConfigVersion = stringOrNull(version)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableResource{client: clientFor(r.client, data.ControllerURL)}
+
+	var prior TableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep all fields from user JSON.
+	var tableConfig TableConfig
+	diags := data.TableConfig.Unmarshal(&tableConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Optional sanity validation.
+	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
+	bareName := data.AutoSuffixName.ValueBool() && suffixConfigTableName(tableConfig, data.TableName.ValueString(), fullTableName)
+	if tn, _ := tableConfig["tableName"].(string); tn != "" && tn != fullTableName {
+		resp.Diagnostics.AddError(
+			"Table Name Mismatch",
+			fmt.Sprintf("The table configuration name must be %s", fullTableName),
+		)
+		return
+	}
+	if tt, _ := tableConfig["tableType"].(string); tt != "" && !strings.EqualFold(tt, data.TableType.ValueString()) {
 		resp.Diagnostics.AddError(
-			"Error Updating Pinot Table",
-			"Could not update table, unexpected error: "+err.Error(),
+			"Table Type Mismatch",
+			fmt.Sprintf("The table configuration type must be %s", strings.ToUpper(data.TableType.ValueString())),
 		)
 		return
 	}
 
-	// Always reload segments after a successful update.
-	if err := r.client.ReloadTable(ctx, data.TableName.ValueString(), data.TableType.ValueString()); err != nil {
+	saslValue, err := buildSaslIfProvided(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Kafka Credentials Incomplete", err.Error())
+		return
+	}
+	if saslValue != "" {
+		injectKafkaSasl(&tableConfig, saslValue)
+	}
+
+	secrets := injectedSecretsFromModel(ctx, &resp.Diagnostics, data.InjectedSecrets)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := applyInjectedSecrets(tableConfig, secrets); err != nil {
+		resp.Diagnostics.AddError("Invalid Injected Secret", err.Error())
+		return
+	}
+
+	bootstrapServers := !data.KafkaBootstrap.IsNull()
+	if bootstrapServers {
+		if err := injectKafkaBootstrapServers(&tableConfig, data.KafkaBootstrap.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("kafka_bootstrap_servers"), "Conflicting Kafka Bootstrap Servers", err.Error())
+			return
+		}
+	}
+	if err := injectFlushThresholds(tableConfig, data.FlushRows, data.FlushSize); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Conflicting Flush Thresholds", err.Error())
+		return
+	}
+	if err := injectKafkaDecoderProps(ctx, tableConfig, data.DecoderProps); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("kafka_decoder_props"), "Conflicting Decoder Properties", err.Error())
+		return
+	}
+
+	userConfig, _ := deepCopyJSON(tableConfig).(map[string]interface{})
+	if err := injectTaskConfigs(tableConfig, data.TaskConfigs); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("task_configs"), "Conflicting Task Configuration", err.Error())
+		return
+	}
+	overrides := append(tableConfigOverrides(&data), managedByOverrides(r.client.ManagedByTags(), userConfig)...)
+	if err := applyConfigOverrides(tableConfig, overrides); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Conflicting Table Configuration", err.Error())
+		return
+	}
+	if err := r.validateTenantsExist(ctx, data.BrokerTenant, data.ServerTenant); err != nil {
+		resp.Diagnostics.AddError("Unknown Pinot Tenant", err.Error())
+		return
+	}
+	if err := r.validateWithSchema(ctx, &data, tableConfig); err != nil {
+		addAPIError(&resp.Diagnostics, "Invalid Pinot Table Config", "Table "+fullTableName+" does not validate against its schema", err)
+		return
+	}
+
+	// Update via API (passthrough JSON), retrying while a conflicting operation finishes.
+	// With a known config version the update only applies if nobody changed the table since we last read it.
+	var version string
+	if err := client.RetryOnConflict(ctx, func() error {
+		var err error
+		version, err = r.client.UpdateTableIfMatch(ctx, tableConfig, prior.ConfigVersion.ValueString())
+		return err
+	}); err != nil {
+		if client.IsPreconditionFailed(err) {
+			resp.Diagnostics.AddError(
+				"Pinot Table Changed Concurrently",
+				fmt.Sprintf("Table %s was modified outside this Terraform run since it was last read (version %s), so the update was not applied. "+
+					"Run terraform plan again to review the current configuration before applying.", fullTableName, prior.ConfigVersion.ValueString()),
+			)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Updating Pinot Table", "Could not update table "+fullTableName, err)
+		return
+	}
+
+	// Reload segments after a successful update, but only when an index-affecting part of the config changed.
+	// The table itself is already updated, so state is saved even when a strict reload fails.
+	triggers, diags := reloadTriggerPaths(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var reloadErr error
+	if reloadRequired(&data, &prior, triggers) {
+		reloadErr = reloadTable(ctx, r.client, data.TableName.ValueString(), data.TableType.ValueString(), data.ReloadMode, data.ReloadForceDL.ValueBool())
+	}
+	if reloadErr != nil && !data.FailOnReloadError.ValueBool() {
 		resp.Diagnostics.AddWarning(
 			"Pinot Segment Reload Failed",
-			fmt.Sprintf("Updated table %s but segment reload failed: %v", joinTableID(data.TableName.ValueString(), data.TableType.ValueString()), err),
+			fmt.Sprintf("Updated table %s but segment reload failed: %v", joinTableID(data.TableName.ValueString(), data.TableType.ValueString()), reloadErr),
 		)
 	}
 
-	// For state: remove any sasl.jaas.config from the table_config JSON (we store it in a top-level sensitive attr instead).
-	cleanForState := removeSaslJaasFromTableConfig(tableConfig)
-	configJSON, err := json.Marshal(cleanForState)
+	data.RebalanceJobID = prior.RebalanceJobID
+	if data.Rebalance != nil && !data.Rebalance.DryRun.ValueBool() {
+		result, err := r.client.RebalanceTableWithOptions(ctx, data.TableName.ValueString(), data.TableType.ValueString(), data.Rebalance.options())
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Pinot Table Rebalance Failed",
+				fmt.Sprintf("Updated table %s but the rebalance failed: %v", joinTableID(data.TableName.ValueString(), data.TableType.ValueString()), err),
+			)
+		} else if jobID, ok := result["jobId"].(string); ok && jobID != "" {
+			data.RebalanceJobID = types.StringValue(jobID)
+		}
+	}
+
+	// For state: remove sasl.jaas.config and injected secrets from the table_config JSON (secrets stay in sensitive attrs instead).
+	cleanForState := cleanTableConfigForState(tableConfig, secrets, bootstrapServers)
+	cleanForState = stripFlushThresholds(cleanForState, data.FlushRows, data.FlushSize)
+	cleanForState = stripKafkaDecoderProps(cleanForState, data.DecoderProps)
+	cleanForState = stripTaskConfigs(cleanForState, userConfig, data.TaskConfigs)
+	cleanForState = stripConfigOverrides(cleanForState, userConfig, overrides)
+	if bareName {
+		cleanForState["tableName"] = data.TableName.ValueString()
+	}
+	configJSON, err := canonicalJSON(cleanForState)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Marshaling Table Config",
@@ -303,7 +1191,7 @@ func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
-	data.TableConfig = jsontypes.NewNormalizedValue(string(configJSON))
+	data.TableConfig = jsontypes.NewNormalizedValue(configJSON)
 
 	// Update computed sensitive attribute if we have a value.
 	if saslValue != "" {
@@ -312,8 +1200,46 @@ func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest,
 		// Set to null to avoid retaining stale sensitive value when credentials are not provided.
 		data.SaslJaasConfig = types.StringNull()
 	}
+	data.State = r.readTableState(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.ActiveIndexes = r.readActiveIndexes(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.EffectiveAssignment = r.readEffectiveSegmentAssignment(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.TuningConfig = r.readTuningConfig(ctx, data.TableName.ValueString(), data.TableType.ValueString())
+	data.CanonicalConfig = r.readCanonicalConfig(ctx, fullTableName, secrets)
+	if data.RebalancePlan.IsUnknown() {
+		data.RebalancePlan = jsontypes.NewNormalizedNull()
+	}
+	if data.ConfigDiff.IsUnknown() {
+		data.ConfigDiff = types.StringNull()
+	}
+	// Not every controller returns the new version from the update itself.
+	data.ConfigVersion = stringOrNull(version)
+	if version == "" && !prior.ConfigVersion.IsNull() {
+		data.ConfigVersion = r.readConfigVersion(ctx, fullTableName)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if reloadErr != nil && data.FailOnReloadError.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Pinot Segment Reload Failed",
+			fmt.Sprintf("Updated table %s but segment reload failed: %v", joinTableID(data.TableName.ValueString(), data.TableType.ValueString()), reloadErr),
+		)
+	}
+}
+
+// allowDestroyEnv is the environment variable that lifts the provider's protect_tables guard.
+const allowDestroyEnv = "PINOT_ALLOW_DESTROY"
+
+// checkTableDeleteAllowed returns an error when the provider's protect_tables guard forbids deleting table.
+func checkTableDeleteAllowed(c *client.PinotClient, table string) error {
+	if !c.TablesProtected() {
+		return nil
+	}
+	if allowed, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(allowDestroyEnv))); err == nil && allowed {
+		return nil
+	}
+	return fmt.Errorf("refusing to delete table %s because the provider sets protect_tables; "+
+		"set the environment variable %s=true to delete it", table, allowDestroyEnv)
 }
 
 func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -322,6 +1248,7 @@ func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &TableResource{client: clientFor(r.client, data.ControllerURL)}
 
 	// Prefer attributes; fall back to parsing ID if needed.
 	logical := strings.TrimSpace(data.TableName.ValueString())
@@ -343,29 +1270,45 @@ func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Primary path: DELETE /tables/{logical}?type=OFFLINE|REALTIME
-	if err := deleteTableByLogical(ctx, logical, typ); err != nil {
-		// Fallback: try legacy suffixed delete via client (if supported)
-		if fallbackErr := r.client.DeleteTable(ctx, joinTableID(logical, typ)); fallbackErr != nil {
-			resp.Diagnostics.AddError(
-				"Error Deleting Pinot Table",
-				fmt.Sprintf("logical delete failed: %v; fallback delete failed: %v", err, fallbackErr),
+	if err := checkTableDeleteAllowed(r.client, joinTableID(logical, typ)); err != nil {
+		resp.Diagnostics.AddError("Pinot Table Is Protected", err.Error())
+		return
+	}
+
+	if data.PurgeOnDestroy.ValueBool() {
+		fullTableName := joinTableID(logical, typ)
+		deleted, err := r.client.DeleteAllSegments(ctx, fullTableName, purgeBatchSize)
+		if err != nil && !client.IsNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"Pinot Segment Purge Incomplete",
+				fmt.Sprintf("Deleted %d segments of table %s before deleting the table, but some could not be deleted: %v", deleted, fullTableName, err),
 			)
-			return
 		}
 	}
+
+	if err := r.client.DeleteTableByType(ctx, logical, typ); err != nil && !client.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Table", "Could not delete table "+joinTableID(logical, typ), err)
+	}
 }
 
 func (r *TableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: "tableName_TYPE"
+	// Import ID format: "tableName_TYPE", or the plain logical name when only one type exists.
 	logical, typ := splitTableID(req.ID)
-	if logical == "" || typ == "" {
+	if logical == "" {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
 			"Import ID must be in format: tableName_TYPE (e.g., myTable_OFFLINE or myTable_REALTIME)",
 		)
 		return
 	}
+	if typ == "" {
+		detected, err := r.detectTableType(ctx, logical)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		typ = detected
+	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), joinTableID(logical, typ))...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"), logical)...)
@@ -374,86 +1317,376 @@ func (r *TableResource) ImportState(ctx context.Context, req resource.ImportStat
 
 // ---- helpers ----
 
-// splitTableID parses IDs like "mytable_OFFLINE" / "mytable_REALTIME".
-func splitTableID(id string) (logical, typ string) {
-	switch {
-	case strings.HasSuffix(id, "_OFFLINE"):
-		return strings.TrimSuffix(id, "_OFFLINE"), "OFFLINE"
-	case strings.HasSuffix(id, "_REALTIME"):
-		return strings.TrimSuffix(id, "_REALTIME"), "REALTIME"
+// detectTableType returns the type of a logical table, failing when the table is missing or hybrid.
+func (r *TableResource) detectTableType(ctx context.Context, logical string) (string, error) {
+	tableTypes, err := r.client.GetTableTypes(ctx, logical)
+	if err != nil {
+		return "", fmt.Errorf("could not look up table %s: %w", logical, err)
+	}
+	switch len(tableTypes) {
+	case 0:
+		return "", fmt.Errorf("table %s does not exist", logical)
+	case 1:
+		return tableTypes[0], nil
 	default:
-		return id, ""
+		return "", fmt.Errorf("table %s is hybrid; import each half by its suffixed name (%s or %s)",
+			logical, joinTableID(logical, "OFFLINE"), joinTableID(logical, "REALTIME"))
 	}
 }
 
-// joinTableID builds "logical_TYPE" for state ID.
-func joinTableID(logical, typ string) string {
-	logical = strings.TrimSpace(logical)
-	typ = strings.ToUpper(strings.TrimSpace(typ))
-	if logical == "" || typ == "" {
-		return logical
-	}
-	return fmt.Sprintf("%s_%s", logical, typ)
+// columnReference is a column the table config expects the schema to define, and where it is referenced.
+type columnReference struct {
+	source string
+	column string
 }
 
-// deleteTableByLogical performs:
-//
-//	DELETE {PINOT_CONTROLLER_URL}/tables/{logical}?type={typ}
-//
-// It honors optional env vars for Database header and auth.
-func deleteTableByLogical(ctx context.Context, logical, typ string) error {
-	base := strings.TrimRight(os.Getenv("PINOT_CONTROLLER_URL"), "/")
-	if base == "" {
-		return fmt.Errorf("PINOT_CONTROLLER_URL not set")
+// missingSchemaColumns returns the columns written by ingestion transforms, or used as the time column,
+// that schema does not define.
+func missingSchemaColumns(tableConfig TableConfig, schema SchemaConfig) []columnReference {
+	defined := map[string]bool{}
+	for _, section := range schemaFieldSpecKeys {
+		specs, _ := schema[section].([]interface{})
+		for _, el := range specs {
+			if spec, ok := el.(map[string]interface{}); ok {
+				if name, ok := spec["name"].(string); ok {
+					defined[name] = true
+				}
+			}
+		}
 	}
 
-	u, err := url.Parse(base + "/tables/" + url.PathEscape(logical))
-	if err != nil {
-		return err
+	var refs []columnReference
+	ingestion, _ := tableConfig["ingestionConfig"].(map[string]interface{})
+	transforms, _ := ingestion["transformConfigs"].([]interface{})
+	for i, el := range transforms {
+		if t, ok := el.(map[string]interface{}); ok {
+			if name, ok := t["columnName"].(string); ok && name != "" {
+				refs = append(refs, columnReference{source: fmt.Sprintf("ingestionConfig.transformConfigs[%d]", i), column: name})
+			}
+		}
 	}
-	q := u.Query()
-	q.Set("type", strings.ToUpper(typ))
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
-	if err != nil {
-		return err
+	segments, _ := tableConfig["segmentsConfig"].(map[string]interface{})
+	if name, ok := segments["timeColumnName"].(string); ok && name != "" {
+		refs = append(refs, columnReference{source: "segmentsConfig.timeColumnName", column: name})
 	}
 
-	// Optional multi-DB header
-	if db := strings.TrimSpace(os.Getenv("PINOT_DATABASE")); db != "" {
-		req.Header.Set("Database", db)
+	var missing []columnReference
+	for _, ref := range refs {
+		if !defined[ref.column] {
+			missing = append(missing, ref)
+		}
 	}
+	return missing
+}
 
-	// Optional auth: basic or bearer
-	if token := strings.TrimSpace(os.Getenv("PINOT_TOKEN")); token != "" {
-		req.Header.Set("Authorization", "Basic "+token)
-	} else if uName, p := os.Getenv("PINOT_USERNAME"), os.Getenv("PINOT_PASSWORD"); uName != "" || p != "" {
-		req.SetBasicAuth(uName, p)
+// timeTypeMismatch reports the time column, segmentsConfig.timeType and the unit of the column's date-time
+// format in schema when timeType and the unit disagree. Formats without a unit are not compared.
+func timeTypeMismatch(tableConfig TableConfig, schema SchemaConfig) (column, timeType, unit string, mismatch bool) {
+	segments, _ := tableConfig["segmentsConfig"].(map[string]interface{})
+	column, _ = segments["timeColumnName"].(string)
+	timeType, _ = segments["timeType"].(string)
+	if column == "" || timeType == "" {
+		return "", "", "", false
+	}
+	specs, _ := schema["dateTimeFieldSpecs"].([]interface{})
+	for _, el := range specs {
+		spec, ok := el.(map[string]interface{})
+		if !ok || spec["name"] != column {
+			continue
+		}
+		format, _ := spec["format"].(string)
+		if unit, ok = dateTimeFormatUnit(format); ok && !strings.EqualFold(unit, timeType) {
+			return column, timeType, unit, true
+		}
+		return "", "", "", false
 	}
+	return "", "", "", false
+}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return err
+// autoCreateSchema creates the inline schema when auto_create_schema is enabled and the schema referenced
+// by the table does not exist yet. It returns the name of the schema it created, if any.
+func (r *TableResource) autoCreateSchema(ctx context.Context, data *TableResourceModel, tableConfig TableConfig) (string, error) {
+	if !data.AutoCreateSchema.ValueBool() || data.Schema.IsNull() || data.Schema.IsUnknown() {
+		return "", nil
 	}
-	defer resp.Body.Close()
 
-	// 200/202/204 => deleted; 404 => already gone.
-	if resp.StatusCode == http.StatusOK ||
-		resp.StatusCode == http.StatusAccepted ||
-		resp.StatusCode == http.StatusNoContent ||
-		resp.StatusCode == http.StatusNotFound {
-		return nil
+	var schemaConfig SchemaConfig
+	if diags := data.Schema.Unmarshal(&schemaConfig); diags.HasError() {
+		return "", fmt.Errorf("invalid schema JSON")
 	}
 
-	return fmt.Errorf("unexpected status deleting table %q type %q: %d", logical, typ, resp.StatusCode)
-}
+	schemaName := data.TableName.ValueString()
+	segmentsConfig, _ := tableConfig["segmentsConfig"].(map[string]interface{})
+	if sn, _ := segmentsConfig["schemaName"].(string); sn != "" {
+		schemaName = sn
+	}
+	if sn, _ := schemaConfig["schemaName"].(string); sn != schemaName {
+		return "", fmt.Errorf("schema JSON has schemaName %q but the table references schema %q", sn, schemaName)
+	}
+
+	if _, err := r.client.GetSchema(ctx, schemaName); err == nil {
+		return "", nil
+	} else if !client.IsNotFound(err) {
+		return "", fmt.Errorf("could not check whether schema %s exists: %w", schemaName, err)
+	}
+
+	if err := r.client.CreateSchema(ctx, schemaConfig); err != nil {
+		return "", fmt.Errorf("could not create schema %s: %w", schemaName, err)
+	}
+	return schemaName, nil
+}
+
+// validateWithSchema has the controller check tableConfig against the inline schema managed with it
+// (auto_create_schema) through POST /tableConfigs/validate, which catches e.g. a time column the schema lacks
+// before anything is created. Pinot only validates a schema named after the logical table, so tables using
+// another schema are not checked; neither are controllers without the endpoint.
+func (r *TableResource) validateWithSchema(ctx context.Context, data *TableResourceModel, tableConfig TableConfig) error {
+	if !data.AutoCreateSchema.ValueBool() || data.Schema.IsNull() || data.Schema.IsUnknown() {
+		return nil
+	}
+	var schemaConfig SchemaConfig
+	if diags := data.Schema.Unmarshal(&schemaConfig); diags.HasError() {
+		return fmt.Errorf("invalid schema JSON")
+	}
+	logical := data.TableName.ValueString()
+	if sn, _ := schemaConfig["schemaName"].(string); sn != logical {
+		return nil
+	}
+
+	return validateTableConfigs(ctx, r.client, logical, data.TableType.ValueString(), schemaConfig, tableConfig)
+}
+
+// validateTableConfigs checks tableConfig of the given type against schemaConfig with POST /tableConfigs/validate
+// and returns the controller's rejection. Controllers without the endpoint are not asked.
+func validateTableConfigs(ctx context.Context, c *client.PinotClient, logical, typ string, schemaConfig SchemaConfig, tableConfig TableConfig) error {
+	configs := client.TableConfigs{TableName: logical, Schema: schemaConfig}
+	if strings.EqualFold(typ, "REALTIME") {
+		configs.Realtime = tableConfig
+	} else {
+		configs.Offline = tableConfig
+	}
+	if err := c.ValidateTableConfigs(ctx, configs); err != nil && !client.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// purgeBatchSize is the number of segments deleted per request when purge_segments_on_destroy is set.
+const purgeBatchSize = 100
+
+// defaultReloadTriggerPaths are the table_config sections that change how existing segments are indexed or
+// which derived columns they hold, and so need a reload to take effect.
+var defaultReloadTriggerPaths = []string{"tableIndexConfig", "fieldConfigList", "ingestionConfig.transformConfigs"}
+
+// reloadRequired reports whether the update from prior to plan changes a table_config path listed in triggers,
+// either in table_config itself or through a typed attribute. A table_config that can't be parsed counts as changed.
+func reloadRequired(plan, prior *TableResourceModel, triggers []string) bool {
+	var before, after TableConfig
+	if prior.TableConfig.Unmarshal(&before).HasError() || plan.TableConfig.Unmarshal(&after).HasError() {
+		return true
+	}
+	changed := changedJSONPaths(before, after)
+
+	planOverrides, priorOverrides := tableConfigOverrides(plan), tableConfigOverrides(prior)
+	for i, o := range planOverrides {
+		if o.active != priorOverrides[i].active || !reflect.DeepEqual(o.value, priorOverrides[i].value) {
+			changed = append(changed, o.path)
+		}
+	}
+
+	for _, p := range changed {
+		for _, t := range triggers {
+			if jsonPathOverlaps(p, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonPathOverlaps reports whether a and b are the same path or one lies below the other.
+func jsonPathOverlaps(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return b == a || strings.HasPrefix(b, a+".") || strings.HasPrefix(b, a+"[")
+}
+
+// reloadTriggerPaths returns the configured reload_trigger_paths, or the defaults when unset.
+func reloadTriggerPaths(ctx context.Context, data *TableResourceModel) ([]string, diag.Diagnostics) {
+	if data.ReloadPaths.IsNull() || data.ReloadPaths.IsUnknown() {
+		return defaultReloadTriggerPaths, nil
+	}
+	paths := []string{}
+	diags := data.ReloadPaths.ElementsAs(ctx, &paths, false)
+	return paths, diags
+}
+
+// readTableState returns the table's enabled/disabled state, or null when the controller can't report it
+// (older controllers lack the endpoint); that is not worth failing a plan over.
+func (r *TableResource) readTableState(ctx context.Context, logical, typ string) types.String {
+	state, err := r.client.GetTableState(ctx, logical, typ)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot table state", map[string]interface{}{
+			"table": joinTableID(logical, typ),
+			"error": err.Error(),
+		})
+		return types.StringNull()
+	}
+	return types.StringValue(state)
+}
+
+// readConfigVersion returns the table config's version, or null when the controller does not version configs
+// or can't be read.
+func (r *TableResource) readConfigVersion(ctx context.Context, tableName string) types.String {
+	_, version, err := r.client.GetTableWithVersion(ctx, tableName)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot table config version", map[string]interface{}{
+			"table": tableName,
+			"error": err.Error(),
+		})
+		return types.StringNull()
+	}
+	return stringOrNull(version)
+}
+
+// stringOrNull maps an empty string (e.g. a missing ETag) to null.
+func stringOrNull(version string) types.String {
+	if version == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(version)
+}
+
+// readCanonicalConfig reads the table config back from the controller and renders it as canonicalTableConfig
+// does, or returns null when it can't be read.
+func (r *TableResource) readCanonicalConfig(ctx context.Context, tableName string, secrets []InjectedSecretModel) types.String {
+	tableConfig, err := r.client.GetTable(ctx, tableName)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot table config", map[string]interface{}{
+			"table": tableName,
+			"error": err.Error(),
+		})
+		return types.StringNull()
+	}
+	return canonicalTableConfig(tableConfig, secrets)
+}
+
+// canonicalTableConfig renders the table config as held by the controller for canonical_config: canonical JSON
+// with sasl.jaas.config and the injected secrets removed. Unlike table_config, it does not depend on prior state.
+func canonicalTableConfig(tableConfig TableConfig, secrets []InjectedSecretModel) types.String {
+	out, err := canonicalJSON(cleanTableConfigForState(tableConfig, secrets, false))
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(out)
+}
+
+// readActiveIndexes returns the index types built per column, or null when the controller can't report them
+// (the indexes endpoint is missing on older controllers).
+func (r *TableResource) readActiveIndexes(ctx context.Context, logical, typ string) types.Map {
+	indexes, err := r.client.GetTableIndexes(ctx, logical, typ)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot table indexes", map[string]interface{}{
+			"table": joinTableID(logical, typ),
+			"error": err.Error(),
+		})
+		return types.MapNull(types.ListType{ElemType: types.StringType})
+	}
+	v, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, indexes)
+	if diags.HasError() {
+		return types.MapNull(types.ListType{ElemType: types.StringType})
+	}
+	return v
+}
+
+// readEffectiveSegmentAssignment returns the segment assignment strategy in effect per instance partitions type,
+// or null when the controller can't report it.
+func (r *TableResource) readEffectiveSegmentAssignment(ctx context.Context, logical, typ string) types.Map {
+	strategies, err := r.client.GetEffectiveSegmentAssignment(ctx, logical, typ)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot instance partitions", map[string]interface{}{
+			"table": joinTableID(logical, typ),
+			"error": err.Error(),
+		})
+		return types.MapNull(types.StringType)
+	}
+	v, diags := types.MapValueFrom(ctx, types.StringType, strategies)
+	if diags.HasError() {
+		return types.MapNull(types.StringType)
+	}
+	return v
+}
+
+// readTuningConfig returns the table's tuner configs as JSON, or null when it has none or the controller can't
+// report them.
+func (r *TableResource) readTuningConfig(ctx context.Context, logical, typ string) jsontypes.Normalized {
+	tuners, err := r.client.GetTableTuningConfig(ctx, logical, typ)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Pinot table tuning config", map[string]interface{}{
+			"table": joinTableID(logical, typ),
+			"error": err.Error(),
+		})
+		return jsontypes.NewNormalizedNull()
+	}
+	if tuners == nil {
+		return jsontypes.NewNormalizedNull()
+	}
+	b, err := json.Marshal(tuners)
+	if err != nil {
+		return jsontypes.NewNormalizedNull()
+	}
+	return jsontypes.NewNormalizedValue(string(b))
+}
+
+// splitTableID parses IDs like "mytable_OFFLINE" / "mytable_REALTIME".
+func splitTableID(id string) (logical, typ string) {
+	switch {
+	case strings.HasSuffix(id, "_OFFLINE"):
+		return strings.TrimSuffix(id, "_OFFLINE"), "OFFLINE"
+	case strings.HasSuffix(id, "_REALTIME"):
+		return strings.TrimSuffix(id, "_REALTIME"), "REALTIME"
+	default:
+		return id, ""
+	}
+}
+
+// Values of id_format.
+const (
+	idFormatSuffixed = "suffixed"
+	idFormatLogical  = "logical"
+)
+
+// tableStateID builds the state ID according to id_format; the suffixed form is the default.
+func tableStateID(logical, typ, format string) string {
+	if format == idFormatLogical {
+		return strings.TrimSpace(logical)
+	}
+	return joinTableID(logical, typ)
+}
+
+// joinTableID builds "logical_TYPE" for state ID.
+func joinTableID(logical, typ string) string {
+	logical = strings.TrimSpace(logical)
+	typ = strings.ToUpper(strings.TrimSpace(typ))
+	if logical == "" || typ == "" {
+		return logical
+	}
+	return fmt.Sprintf("%s_%s", logical, typ)
+}
 
 // buildSaslJaas constructs the sasl.jaas.config string for Kafka SCRAM.
 func buildSaslJaas(username, password string) string {
 	return fmt.Sprintf(`org.apache.kafka.common.security.scram.ScramLoginModule required username="%s" password="%s";`, username, password)
 }
 
+// JSON paths of sasl.jaas.config for the two streamConfigMaps shapes Pinot accepts.
+const (
+	kafkaSaslMapPath   = "ingestionConfig.streamIngestionConfig.streamConfigMaps['sasl.jaas.config']"
+	kafkaSaslFirstPath = "ingestionConfig.streamIngestionConfig.streamConfigMaps[0]['sasl.jaas.config']"
+	kafkaSaslListPath  = "ingestionConfig.streamIngestionConfig.streamConfigMaps[*]['sasl.jaas.config']"
+)
+
 // injectKafkaSasl injects sasl.jaas.config into the provided tableConfig payload that will be sent to Pinot.
 // It supports both shapes: streamConfigMaps may be either a map[string]interface{} or []interface{} of maps.
 // When creating new value we prefer the list-of-maps shape.
@@ -461,114 +1694,846 @@ func injectKafkaSasl(tableConfig *TableConfig, sasl string) {
 	if tableConfig == nil {
 		return
 	}
+	if *tableConfig == nil {
+		*tableConfig = TableConfig{}
+	}
 
-	ingestion, _ := (*tableConfig)["ingestionConfig"].(map[string]interface{})
-	if ingestion == nil {
-		ingestion = map[string]interface{}{}
-		(*tableConfig)["ingestionConfig"] = ingestion
+	p := kafkaSaslFirstPath
+	if _, ok := streamIngestionConfigOf(*tableConfig)["streamConfigMaps"].(map[string]interface{}); ok {
+		p = kafkaSaslMapPath
 	}
+	// The path is a constant and every intermediate node is created on demand, so this cannot fail.
+	_ = setJSONPath(*tableConfig, p, sasl)
+}
 
-	streamIngestion, _ := ingestion["streamIngestionConfig"].(map[string]interface{})
-	if streamIngestion == nil {
-		streamIngestion = map[string]interface{}{}
-		ingestion["streamIngestionConfig"] = streamIngestion
+// removeSaslJaasFromTableConfig returns a copy of the table config with sasl.jaas.config removed entirely
+// from any streamConfigMaps shape. This prevents storing the secret inside table_config in state.
+func removeSaslJaasFromTableConfig(input TableConfig) TableConfig {
+	return removeJSONPaths(input, kafkaSaslMapPath, kafkaSaslListPath)
+}
+
+// injectedSecretsFromModel decodes the injected_secrets list; unknown or null lists yield no secrets.
+func injectedSecretsFromModel(ctx context.Context, diags *diag.Diagnostics, l types.List) []InjectedSecretModel {
+	if l.IsNull() || l.IsUnknown() {
+		return nil
 	}
+	var out []InjectedSecretModel
+	diags.Append(l.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
+// applyInjectedSecrets sets every injected secret into the payload that will be sent to Pinot.
+func applyInjectedSecrets(tableConfig TableConfig, secrets []InjectedSecretModel) error {
+	for _, s := range secrets {
+		if err := setJSONPath(tableConfig, s.JSONPath.ValueString(), s.Value.ValueString()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	switch v := streamIngestion["streamConfigMaps"].(type) {
+// cleanTableConfigForState strips sasl.jaas.config and every injected secret so no secret lands in table_config.
+// With bootstrapServers set it also strips the broker list injected from kafka_bootstrap_servers.
+func cleanTableConfigForState(tableConfig TableConfig, secrets []InjectedSecretModel, bootstrapServers bool) TableConfig {
+	paths := make([]string, 0, len(secrets)+2*len(streamConfigMapPaths))
+	for _, s := range secrets {
+		paths = append(paths, s.JSONPath.ValueString())
+	}
+	if bootstrapServers {
+		for _, p := range streamConfigMapPaths {
+			paths = append(paths, p+"['"+kafkaBrokerListKey+"']", p+"['"+kafkaBootstrapServersKey+"']")
+		}
+	}
+	return removeJSONPaths(removeSaslJaasFromTableConfig(tableConfig), paths...)
+}
+
+// Keys set from kafka_bootstrap_servers.
+const (
+	kafkaBrokerListKey       = "stream.kafka.broker.list"
+	kafkaBootstrapServersKey = "bootstrap.servers"
+)
+
+// streamConfigMapPaths lists every place a stream config map can live: streamConfigMaps in map or list shape,
+// and the legacy tableIndexConfig.streamConfigs.
+var streamConfigMapPaths = []string{
+	"ingestionConfig.streamIngestionConfig.streamConfigMaps",
+	"ingestionConfig.streamIngestionConfig.streamConfigMaps[*]",
+	"tableIndexConfig.streamConfigs",
+}
+
+// streamConfigMapsOf returns every stream config map present in the table config, in either shape.
+func streamConfigMapsOf(cfg TableConfig) []map[string]interface{} {
+	var out []map[string]interface{}
+	switch v := streamIngestionConfigOf(cfg)["streamConfigMaps"].(type) {
 	case map[string]interface{}:
-		v["sasl.jaas.config"] = sasl
-		streamIngestion["streamConfigMaps"] = v
+		out = append(out, v)
 	case []interface{}:
-		if len(v) == 0 {
-			m := map[string]interface{}{"sasl.jaas.config": sasl}
-			streamIngestion["streamConfigMaps"] = []interface{}{m}
-		} else {
-			firstMap, ok := v[0].(map[string]interface{})
-			if !ok {
-				m := map[string]interface{}{"sasl.jaas.config": sasl}
-				v[0] = m
-				streamIngestion["streamConfigMaps"] = v
-			} else {
-				firstMap["sasl.jaas.config"] = sasl
+		for _, el := range v {
+			if m, ok := el.(map[string]interface{}); ok {
+				out = append(out, m)
 			}
 		}
-	default:
-		m := map[string]interface{}{"sasl.jaas.config": sasl}
-		streamIngestion["streamConfigMaps"] = []interface{}{m}
 	}
+	indexConfig, _ := cfg["tableIndexConfig"].(map[string]interface{})
+	if m, ok := indexConfig["streamConfigs"].(map[string]interface{}); ok {
+		out = append(out, m)
+	}
+	return out
 }
 
-// removeSaslJaasFromTableConfig returns a copy of the table config with sasl.jaas.config removed entirely
-// from any streamConfigMaps shape. This prevents storing the secret inside table_config in state.
-func removeSaslJaasFromTableConfig(input TableConfig) TableConfig {
-	if input == nil {
+// injectKafkaBootstrapServers sets the broker list in every stream config map, creating a list-shaped
+// streamConfigMaps entry when the table has none. It refuses to overwrite values already set in table_config.
+func injectKafkaBootstrapServers(tableConfig *TableConfig, servers string) error {
+	if *tableConfig == nil {
+		*tableConfig = TableConfig{}
+	}
+
+	maps := streamConfigMapsOf(*tableConfig)
+	for _, m := range maps {
+		for _, k := range []string{kafkaBrokerListKey, kafkaBootstrapServersKey} {
+			if _, ok := m[k]; ok {
+				return fmt.Errorf("table_config already sets %q; remove it or unset kafka_bootstrap_servers", k)
+			}
+		}
+	}
+	if len(maps) == 0 {
+		// Same default shape as the SASL injection: a single list-of-maps entry.
+		_ = setJSONPath(*tableConfig, "ingestionConfig.streamIngestionConfig.streamConfigMaps[0]['"+kafkaBrokerListKey+"']", servers)
+		maps = streamConfigMapsOf(*tableConfig)
+	}
+	for _, m := range maps {
+		m[kafkaBrokerListKey] = servers
+		m[kafkaBootstrapServersKey] = servers
+	}
+	return nil
+}
+
+// Realtime flush threshold keys. The legacy `.size` key is a row count despite its name.
+const (
+	flushThresholdRowsKey       = "realtime.segment.flush.threshold.rows"
+	flushThresholdLegacyRowsKey = "realtime.segment.flush.threshold.size"
+	flushThresholdSizeKey       = "realtime.segment.flush.threshold.segment.size"
+)
+
+// dataSizePattern matches the data sizes Pinot accepts for flush_threshold_segment_size (e.g. `200M`, `1.5GB`).
+var dataSizePattern = regexp.MustCompile(`^(?i)\d+(\.\d+)?[KMGTP]?B?$`)
+
+// retentionPeriodPattern matches the periods Pinot accepts for deletedSegmentsRetentionPeriod (e.g. `7d`, `1d12h`).
+var retentionPeriodPattern = regexp.MustCompile(`^(\d+d)?(\d+h)?(\d+m)?(\d+s)?$`)
+
+// flushThresholdKeyOf returns the first flush threshold key set in a stream config map, or "".
+func flushThresholdKeyOf(m map[string]interface{}) string {
+	for _, k := range []string{flushThresholdRowsKey, flushThresholdLegacyRowsKey, flushThresholdSizeKey} {
+		if _, ok := m[k]; ok {
+			return k
+		}
+	}
+	return ""
+}
+
+// checkFlushThresholds rejects a stream config map that sets both a row and a size flush threshold.
+// A row threshold of 0 is how Pinot is told to flush by size, so it does not conflict.
+func checkFlushThresholds(m map[string]interface{}) error {
+	if _, ok := m[flushThresholdSizeKey]; !ok {
 		return nil
 	}
+	for _, k := range []string{flushThresholdRowsKey, flushThresholdLegacyRowsKey} {
+		if v, ok := m[k]; ok && fmt.Sprint(v) != "0" {
+			return fmt.Errorf("stream config sets both %q and %q; they are mutually exclusive", k, flushThresholdSizeKey)
+		}
+	}
+	return nil
+}
 
-	// shallow copy top-level
-	out := make(TableConfig)
-	for k, v := range input {
-		out[k] = v
+// injectFlushThresholds sets flush_threshold_rows or flush_threshold_segment_size in every stream config map.
+// It refuses to overwrite a flush threshold already set in table_config.
+func injectFlushThresholds(tableConfig TableConfig, rows types.Int64, size types.String) error {
+	if rows.IsNull() && size.IsNull() {
+		return nil
+	}
+	maps := streamConfigMapsOf(tableConfig)
+	if len(maps) == 0 {
+		return fmt.Errorf("flush thresholds require stream configuration in table_config")
+	}
+	for _, m := range maps {
+		if k := flushThresholdKeyOf(m); k != "" {
+			return fmt.Errorf("table_config already sets %q; remove it or unset the flush threshold attribute", k)
+		}
+	}
+	for _, m := range maps {
+		if !rows.IsNull() {
+			m[flushThresholdRowsKey] = strconv.FormatInt(rows.ValueInt64(), 10)
+		}
+		if !size.IsNull() {
+			m[flushThresholdSizeKey] = size.ValueString()
+		}
 	}
+	return nil
+}
 
-	ingestion, ok := out["ingestionConfig"].(map[string]interface{})
-	if !ok || ingestion == nil {
-		return out
+// refreshFlushThresholds reads the managed flush thresholds back from the first stream config map.
+func refreshFlushThresholds(tableConfig TableConfig, data *TableResourceModel) {
+	maps := streamConfigMapsOf(tableConfig)
+	if len(maps) == 0 {
+		return
+	}
+	if !data.FlushRows.IsNull() {
+		if n, err := strconv.ParseInt(fmt.Sprint(maps[0][flushThresholdRowsKey]), 10, 64); err == nil {
+			data.FlushRows = types.Int64Value(n)
+		}
 	}
-	// copy ingestion map
-	newIngestion := make(map[string]interface{})
-	for k, v := range ingestion {
-		newIngestion[k] = v
+	if !data.FlushSize.IsNull() {
+		if v, ok := maps[0][flushThresholdSizeKey].(string); ok {
+			data.FlushSize = types.StringValue(v)
+		}
 	}
-	out["ingestionConfig"] = newIngestion
+}
 
-	streamIngestion, ok := newIngestion["streamIngestionConfig"].(map[string]interface{})
-	if !ok || streamIngestion == nil {
-		return out
+// stripFlushThresholds removes the flush threshold keys managed by the typed attributes from the state copy of the config.
+func stripFlushThresholds(tableConfig TableConfig, rows types.Int64, size types.String) TableConfig {
+	var paths []string
+	for _, p := range streamConfigMapPaths {
+		if !rows.IsNull() {
+			paths = append(paths, p+"['"+flushThresholdRowsKey+"']")
+		}
+		if !size.IsNull() {
+			paths = append(paths, p+"['"+flushThresholdSizeKey+"']")
+		}
 	}
-	newStreamIngestion := make(map[string]interface{})
-	for k, v := range streamIngestion {
-		newStreamIngestion[k] = v
+	if len(paths) == 0 {
+		return tableConfig
 	}
-	newIngestion["streamIngestionConfig"] = newStreamIngestion
+	return removeJSONPaths(tableConfig, paths...)
+}
 
-	// Handle map shape
-	if scm, ok := newStreamIngestion["streamConfigMaps"].(map[string]interface{}); ok && scm != nil {
-		newScm := make(map[string]interface{})
-		for k, v := range scm {
-			if k == "sasl.jaas.config" {
-				continue
+// kafkaDecoderPropPrefix prefixes the stream config keys managed by kafka_decoder_props.
+const kafkaDecoderPropPrefix = "stream.kafka.decoder.prop."
+
+// kafkaDecoderPropKeys returns the sorted decoder property keys set in any stream config map.
+func kafkaDecoderPropKeys(tableConfig TableConfig) []string {
+	seen := map[string]bool{}
+	for _, m := range streamConfigMapsOf(tableConfig) {
+		for k := range m {
+			if strings.HasPrefix(k, kafkaDecoderPropPrefix) {
+				seen[k] = true
 			}
-			newScm[k] = v
 		}
-		newStreamIngestion["streamConfigMaps"] = newScm
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// injectKafkaDecoderProps sets every kafka_decoder_props entry, prefixed, in every stream config map.
+// It refuses to merge with decoder properties already set in table_config.
+func injectKafkaDecoderProps(ctx context.Context, tableConfig TableConfig, props types.Map) error {
+	if props.IsNull() || props.IsUnknown() {
+		return nil
+	}
+	values := map[string]string{}
+	if diags := props.ElementsAs(ctx, &values, false); diags.HasError() {
+		return fmt.Errorf("reading kafka_decoder_props: %v", diags)
+	}
+	maps := streamConfigMapsOf(tableConfig)
+	if len(maps) == 0 {
+		return fmt.Errorf("kafka_decoder_props requires stream configuration in table_config")
+	}
+	if keys := kafkaDecoderPropKeys(tableConfig); len(keys) > 0 {
+		return fmt.Errorf("table_config already sets %q; remove it or move it into kafka_decoder_props", keys[0])
+	}
+	for _, m := range maps {
+		for k, v := range values {
+			m[kafkaDecoderPropPrefix+k] = v
+		}
+	}
+	return nil
+}
+
+// refreshKafkaDecoderProps reads the decoder properties back from the first stream config map.
+func refreshKafkaDecoderProps(tableConfig TableConfig, data *TableResourceModel) {
+	if data.DecoderProps.IsNull() {
+		return
+	}
+	maps := streamConfigMapsOf(tableConfig)
+	if len(maps) == 0 {
+		return
+	}
+	elems := map[string]attr.Value{}
+	for k, v := range maps[0] {
+		if name, ok := strings.CutPrefix(k, kafkaDecoderPropPrefix); ok {
+			elems[name] = types.StringValue(fmt.Sprint(v))
+		}
+	}
+	data.DecoderProps = types.MapValueMust(types.StringType, elems)
+}
+
+// stripKafkaDecoderProps removes the decoder properties managed by kafka_decoder_props from the state copy of the config.
+func stripKafkaDecoderProps(tableConfig TableConfig, props types.Map) TableConfig {
+	if props.IsNull() {
+		return tableConfig
+	}
+	var paths []string
+	for _, k := range kafkaDecoderPropKeys(tableConfig) {
+		for _, p := range streamConfigMapPaths {
+			paths = append(paths, p+"['"+k+"']")
+		}
+	}
+	if len(paths) == 0 {
+		return tableConfig
+	}
+	return removeJSONPaths(tableConfig, paths...)
+}
+
+// taskTypeConfigsPath is where task_configs are merged into table_config, one object per minion task type.
+const taskTypeConfigsPath = "task.taskTypeConfigsMap"
+
+// taskConfigKeys maps the typed task_configs attributes onto their keys in a task type's config.
+var taskConfigKeys = []struct{ attribute, key string }{
+	{"bucket_time_period", "bucketTimePeriod"},
+	{"buffer_time_period", "bufferTimePeriod"},
+	{"merge_type", "mergeType"},
+}
+
+// taskConfigAttrTypes is the object type of a task_configs entry.
+var taskConfigAttrTypes = map[string]attr.Type{
+	"bucket_time_period": types.StringType,
+	"buffer_time_period": types.StringType,
+	"merge_type":         types.StringType,
+}
+
+func taskTypeConfigPath(taskType string) string {
+	return taskTypeConfigsPath + "['" + taskType + "']"
+}
+
+// taskConfigValues returns the known task_configs values as task type -> Pinot key -> value.
+func taskConfigValues(configs types.Map) map[string]map[string]string {
+	out := map[string]map[string]string{}
+	if configs.IsNull() || configs.IsUnknown() {
 		return out
 	}
+	for taskType, el := range configs.Elements() {
+		obj, ok := el.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		values := map[string]string{}
+		for _, k := range taskConfigKeys {
+			if v, ok := obj.Attributes()[k.attribute].(types.String); ok && !v.IsNull() && !v.IsUnknown() {
+				values[k.key] = v.ValueString()
+			}
+		}
+		out[taskType] = values
+	}
+	return out
+}
 
-	// Handle list-of-maps shape
-	if scmList, ok := newStreamIngestion["streamConfigMaps"].([]interface{}); ok && scmList != nil {
-		newList := make([]interface{}, len(scmList))
-		for i, el := range scmList {
-			if m, ok := el.(map[string]interface{}); ok && m != nil {
-				newMap := make(map[string]interface{})
-				for k, v := range m {
-					if k == "sasl.jaas.config" {
-						continue
-					}
-					newMap[k] = v
-				}
-				newList[i] = newMap
+// taskConfigPaths returns the sorted table_config paths of the keys set through task_configs.
+func taskConfigPaths(configs types.Map) []string {
+	var paths []string
+	for taskType, values := range taskConfigValues(configs) {
+		for k := range values {
+			paths = append(paths, taskTypeConfigPath(taskType)+"."+k)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// taskConfigConflicts returns the task_configs keys that table_config sets too. Other keys of the same task
+// types, and task types not in task_configs, are left to table_config.
+func taskConfigConflicts(tableConfig TableConfig, configs types.Map) []string {
+	var conflicts []string
+	for _, p := range taskConfigPaths(configs) {
+		if _, ok := lookupJSONPath(tableConfig, p); ok {
+			conflicts = append(conflicts, p)
+		}
+	}
+	return conflicts
+}
+
+// injectTaskConfigs merges task_configs into task.taskTypeConfigsMap. It refuses to overwrite keys already set
+// in table_config, since those would be stripped from state again.
+func injectTaskConfigs(tableConfig TableConfig, configs types.Map) error {
+	if conflicts := taskConfigConflicts(tableConfig, configs); len(conflicts) > 0 {
+		return fmt.Errorf("table_config already sets %s; remove it or unset it in task_configs", conflicts[0])
+	}
+	for taskType, values := range taskConfigValues(configs) {
+		for k, v := range values {
+			if err := setJSONPath(tableConfig, taskTypeConfigPath(taskType)+"."+k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refreshTaskConfigs reads the keys set through task_configs back from the remote config, so a key changed or
+// removed outside Terraform shows up as drift on task_configs.
+func refreshTaskConfigs(tableConfig TableConfig, data *TableResourceModel) {
+	if data.TaskConfigs.IsNull() || data.TaskConfigs.IsUnknown() {
+		return
+	}
+	elems := map[string]attr.Value{}
+	for taskType, el := range data.TaskConfigs.Elements() {
+		obj, ok := el.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			elems[taskType] = el
+			continue
+		}
+		attrs := map[string]attr.Value{}
+		for _, k := range taskConfigKeys {
+			current, _ := obj.Attributes()[k.attribute].(types.String)
+			attrs[k.attribute] = current
+			if current.IsNull() {
+				continue
+			}
+			if v, ok := lookupJSONPath(tableConfig, taskTypeConfigPath(taskType)+"."+k.key); ok {
+				attrs[k.attribute] = types.StringValue(fmt.Sprint(v))
 			} else {
-				// leave non-map elements untouched
-				newList[i] = el
+				attrs[k.attribute] = types.StringNull()
+			}
+		}
+		elems[taskType] = types.ObjectValueMust(taskConfigAttrTypes, attrs)
+	}
+	data.TaskConfigs = types.MapValueMust(types.ObjectType{AttrTypes: taskConfigAttrTypes}, elems)
+}
+
+// stripTaskConfigs removes the keys set through task_configs from the state copy of the config. Task type
+// objects, taskTypeConfigsMap and task left empty are dropped too, unless they were present in prior.
+func stripTaskConfigs(tableConfig, prior TableConfig, configs types.Map) TableConfig {
+	paths := taskConfigPaths(configs)
+	if len(paths) == 0 {
+		return tableConfig
+	}
+	out := removeJSONPaths(tableConfig, paths...)
+
+	var parents []string
+	for taskType := range taskConfigValues(configs) {
+		parents = append(parents, taskTypeConfigPath(taskType))
+	}
+	sort.Strings(parents)
+	parents = append(parents, taskTypeConfigsPath, "task")
+	for _, p := range parents {
+		v, ok := lookupJSONPath(out, p)
+		if m, isMap := v.(map[string]interface{}); !ok || !isMap || len(m) > 0 {
+			continue
+		}
+		if _, inPrior := lookupJSONPath(prior, p); !inPrior {
+			out = removeJSONPaths(out, p)
+		}
+	}
+	return out
+}
+
+// configOverride is a typed attribute merged into table_config at path before the config is sent to Pinot,
+// and stripped from the state copy of table_config again so the two never disagree.
+type configOverride struct {
+	attribute string
+	path      string
+	// value is nil when the attribute is unknown; active is false when it is null.
+	value   interface{}
+	active  bool
+	refresh func(remote interface{})
+}
+
+// tableConfigOverrides lists every typed attribute that maps onto a table_config key.
+func tableConfigOverrides(data *TableResourceModel) []configOverride {
+	return []configOverride{
+		stringOverride("broker_tenant", "tenants.broker", &data.BrokerTenant),
+		stringOverride("server_tenant", "tenants.server", &data.ServerTenant),
+		boolOverride("null_handling_enabled", "tableIndexConfig.nullHandlingEnabled", &data.NullHandling),
+		boolOverride("aggregate_metrics", "tableIndexConfig.aggregateMetrics", &data.AggregateMetrics),
+		boolOverride("continue_on_error", "ingestionConfig.continueOnError", &data.ContinueOnError),
+		boolOverride("row_time_value_check", "ingestionConfig.rowTimeValueCheck", &data.RowTimeCheck),
+		boolOverride("minimize_data_movement", "segmentsConfig.minimizeDataMovement", &data.MinimizeMovement),
+		numberStringOverride("max_qps", "quota.maxQueriesPerSecond", &data.MaxQPS),
+		int64Override("query_timeout_ms", "query.timeoutMs", &data.QueryTimeoutMs),
+		stringOverride("peer_segment_download_scheme", "segmentsConfig.peerSegmentDownloadScheme", &data.PeerDownload),
+		stringOverride("deleted_segments_retention_period", "segmentsConfig.deletedSegmentsRetentionPeriod", &data.DeletedRetention),
+		stringOverride("complex_type_delimiter", "ingestionConfig.complexTypeConfig.delimiter", &data.ComplexDelimiter),
+		stringListOverride("complex_type_fields_to_unnest", "ingestionConfig.complexTypeConfig.fieldsToUnnest", &data.FieldsToUnnest),
+	}
+}
+
+func stringOverride(attribute, p string, field *types.String) configOverride {
+	o := configOverride{attribute: attribute, path: p, active: !field.IsNull()}
+	if !field.IsNull() && !field.IsUnknown() {
+		o.value = field.ValueString()
+	}
+	o.refresh = func(remote interface{}) {
+		if v, ok := remote.(string); ok {
+			*field = types.StringValue(v)
+		}
+	}
+	return o
+}
+
+func boolOverride(attribute, p string, field *types.Bool) configOverride {
+	o := configOverride{attribute: attribute, path: p, active: !field.IsNull()}
+	if !field.IsNull() && !field.IsUnknown() {
+		o.value = field.ValueBool()
+	}
+	o.refresh = func(remote interface{}) {
+		// Pinot may echo booleans back as strings.
+		if v, ok := asBool(remote); ok {
+			*field = types.BoolValue(v)
+		}
+	}
+	return o
+}
+
+// numberStringOverride is a stringOverride for numbers Pinot stores as strings (e.g. "100" comes back as "100.0").
+func numberStringOverride(attribute, p string, field *types.String) configOverride {
+	o := stringOverride(attribute, p, field)
+	o.refresh = func(remote interface{}) {
+		rv := fmt.Sprint(remote)
+		if rf, err := strconv.ParseFloat(rv, 64); err == nil {
+			if cur, err := strconv.ParseFloat(field.ValueString(), 64); err == nil && cur == rf {
+				return
+			}
+		}
+		*field = types.StringValue(rv)
+	}
+	return o
+}
+
+// int64Override sets an integer; Pinot may return it as a number or a string.
+func int64Override(attribute, p string, field *types.Int64) configOverride {
+	o := configOverride{attribute: attribute, path: p, active: !field.IsNull()}
+	if !field.IsNull() && !field.IsUnknown() {
+		o.value = field.ValueInt64()
+	}
+	o.refresh = func(remote interface{}) {
+		if v, err := strconv.ParseFloat(fmt.Sprint(remote), 64); err == nil && v == math.Trunc(v) {
+			*field = types.Int64Value(int64(v))
+		}
+	}
+	return o
+}
+
+func stringListOverride(attribute, p string, field *types.List) configOverride {
+	o := configOverride{attribute: attribute, path: p, active: !field.IsNull()}
+	if !field.IsNull() && !field.IsUnknown() {
+		value := make([]interface{}, 0, len(field.Elements()))
+		for _, el := range field.Elements() {
+			sv, ok := el.(types.String)
+			if !ok || sv.IsUnknown() {
+				value = nil
+				break
+			}
+			value = append(value, sv.ValueString())
+		}
+		if value != nil {
+			o.value = value
+		}
+	}
+	o.refresh = func(remote interface{}) {
+		list, ok := remote.([]interface{})
+		if !ok {
+			return
+		}
+		elems := make([]attr.Value, 0, len(list))
+		for _, el := range list {
+			sv, ok := el.(string)
+			if !ok {
+				return
 			}
+			elems = append(elems, types.StringValue(sv))
 		}
-		newStreamIngestion["streamConfigMaps"] = newList
+		*field = types.ListValueMust(types.StringType, elems)
 	}
+	return o
+}
+
+// Keys of metadata.customConfigs set from the provider's managed_by_tag and managed_by_owner.
+const (
+	managedByConfigKey = "managed-by"
+	ownerConfigKey     = "owner"
+)
+
+// managedByOverrides returns overrides recording tags in metadata.customConfigs, skipping keys the user's
+// config sets itself. Like typed attributes they are sent to Pinot but kept out of table_config in state.
+func managedByOverrides(tags map[string]string, user TableConfig) []configOverride {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var overrides []configOverride
+	for _, k := range keys {
+		p := "metadata.customConfigs." + k
+		if _, ok := lookupJSONPath(user, p); ok {
+			continue
+		}
+		overrides = append(overrides, configOverride{
+			attribute: "managed_by_tag",
+			path:      p,
+			value:     tags[k],
+			active:    true,
+			refresh:   func(interface{}) {},
+		})
+	}
+	return overrides
+}
+
+// applyConfigOverrides merges the typed attributes into the config sent to Pinot. It refuses to overwrite
+// keys already set in table_config, since those would be stripped from state again.
+func applyConfigOverrides(tableConfig TableConfig, overrides []configOverride) error {
+	for _, o := range overrides {
+		if o.value == nil {
+			continue
+		}
+		if _, ok := lookupJSONPath(tableConfig, o.path); ok {
+			return fmt.Errorf("table_config already sets %s; remove it or unset %s", o.path, o.attribute)
+		}
+		if err := setJSONPath(tableConfig, o.path, o.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshConfigOverrides updates each set attribute from the remote config so drift shows up on the attribute.
+func refreshConfigOverrides(remote TableConfig, overrides []configOverride) {
+	for _, o := range overrides {
+		if !o.active {
+			continue
+		}
+		if v, ok := lookupJSONPath(remote, o.path); ok {
+			o.refresh(v)
+		}
+	}
+}
+
+// stripConfigOverrides removes the keys managed by typed attributes from the state copy of the config. Parent
+// objects left empty are dropped too, unless they were present in prior (the user's config or prior state).
+func stripConfigOverrides(tableConfig, prior TableConfig, overrides []configOverride) TableConfig {
+	out := tableConfig
+	for _, o := range overrides {
+		if !o.active {
+			continue
+		}
+		out = removeJSONPaths(out, o.path)
+
+		parent := o.path
+		for {
+			i := strings.LastIndex(parent, ".")
+			if i < 0 {
+				break
+			}
+			parent = parent[:i]
+			v, ok := lookupJSONPath(out, parent)
+			m, isMap := v.(map[string]interface{})
+			if !ok || !isMap || len(m) > 0 {
+				break
+			}
+			if _, inPrior := lookupJSONPath(prior, parent); inPrior {
+				break
+			}
+			out = removeJSONPaths(out, parent)
+		}
+	}
+	return out
+}
+
+// suffixConfigTableName replaces a bare logical tableName with the suffixed name Pinot expects and reports
+// whether it did (auto_suffix_table_name).
+func suffixConfigTableName(cfg TableConfig, logical, fullName string) bool {
+	if tn, _ := cfg["tableName"].(string); tn != logical || logical == fullName {
+		return false
+	}
+	cfg["tableName"] = fullName
+	return true
+}
+
+// restoreLogicalTableName keeps a bare logical tableName from the prior config in state when Pinot returns the
+// suffixed name it was sent as.
+func restoreLogicalTableName(cfg, prior TableConfig, logical, fullName string) TableConfig {
+	if tn, _ := prior["tableName"].(string); tn == logical && cfg["tableName"] == fullName {
+		cfg["tableName"] = logical
+	}
+	return cfg
+}
+
+// stripDefaultTenants removes the tenants.broker and tenants.server defaults the controller fills in when a table
+// config leaves them out, so omitting tenants does not show a diff after refresh. Values the prior config sets,
+// or that differ from the default, are kept.
+func stripDefaultTenants(cfg, prior TableConfig) TableConfig {
+	var defaults []configOverride
+	for _, p := range []string{"tenants.broker", "tenants.server"} {
+		if _, inPrior := lookupJSONPath(prior, p); inPrior {
+			continue
+		}
+		if v, ok := lookupJSONPath(cfg, p); ok && v == defaultTenant {
+			defaults = append(defaults, configOverride{path: p, active: true})
+		}
+	}
+	return stripConfigOverrides(cfg, prior, defaults)
+}
+
+// validateTenantsExist checks that the configured broker/server tenants are known to the controller.
+func (r *TableResource) validateTenantsExist(ctx context.Context, broker, server types.String) error {
+	if (broker.IsNull() || broker.IsUnknown()) && (server.IsNull() || server.IsUnknown()) {
+		return nil
+	}
+
+	serverTenants, brokerTenants, err := r.client.ListTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list tenants: %w", err)
+	}
+	if !broker.IsNull() && !broker.IsUnknown() && !containsString(brokerTenants, broker.ValueString()) {
+		return fmt.Errorf("broker tenant %q does not exist (known: %s)", broker.ValueString(), strings.Join(brokerTenants, ", "))
+	}
+	if !server.IsNull() && !server.IsUnknown() && !containsString(serverTenants, server.ValueString()) {
+		return fmt.Errorf("server tenant %q does not exist (known: %s)", server.ValueString(), strings.Join(serverTenants, ", "))
+	}
+	return nil
+}
+
+// missingTenants describes each tenant named in tenants.broker or tenants.server of tableConfig that is not among
+// the known tenants. Unset tenants are skipped; the controller places those on DefaultTenant.
+func missingTenants(tableConfig TableConfig, serverTenants, brokerTenants []string) []string {
+	var missing []string
+	for _, ref := range []struct {
+		key   string
+		known []string
+	}{
+		{key: "broker", known: brokerTenants},
+		{key: "server", known: serverTenants},
+	} {
+		name, _ := lookupJSONPath(tableConfig, "tenants."+ref.key)
+		tenant, _ := name.(string)
+		if tenant == "" || containsString(ref.known, tenant) {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("tenants.%s names %s tenant %q, which does not exist (known: %s).",
+			ref.key, ref.key, tenant, strings.Join(ref.known, ", ")))
+	}
+	return missing
+}
+
+func containsString(list []string, s string) bool {
+	for _, el := range list {
+		if el == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Boolean toggles Pinot may echo back as strings or fill in with their default; see normalizeStreamIngestionToggles.
+var (
+	streamIngestionBoolKeys = []string{
+		"columnMajorSegmentBuilderEnabled",
+		"pauselessConsumptionEnabled",
+		"trackFilteredMessageOffsets",
+	}
+	streamConfigBoolKeys = []string{
+		"realtime.segment.serverUploadToDeepStore",
+		"stream.kafka.metadata.populate",
+	}
+	// Top-level config sections and their boolean toggles; see normalizeSectionToggles.
+	sectionBoolKeys = map[string][]string{
+		"tableIndexConfig": {"nullHandlingEnabled", "aggregateMetrics"},
+		"segmentsConfig":   {"minimizeDataMovement"},
+		"ingestionConfig":  {"continueOnError", "rowTimeValueCheck"},
+	}
+)
 
+// normalizeSectionToggles applies the same normalization as normalizeStreamIngestionToggles to the
+// boolean toggles of top-level sections such as tableIndexConfig and segmentsConfig.
+func normalizeSectionToggles(remote, prior TableConfig) TableConfig {
+	out, _ := deepCopyJSON(remote).(map[string]interface{})
+	for section, keys := range sectionBoolKeys {
+		remoteSection, _ := out[section].(map[string]interface{})
+		if remoteSection == nil {
+			continue
+		}
+		priorSection, _ := prior[section].(map[string]interface{})
+		normalizeBoolKeys(remoteSection, priorSection, keys)
+	}
 	return out
 }
 
+// normalizeStreamIngestionToggles returns a copy of the remote config where known boolean toggles under
+// streamIngestionConfig (and its streamConfigMaps, in either shape) keep the prior representation when they
+// are equivalent (true vs "true"), and are dropped when absent from the prior config and still at their false default.
+func normalizeStreamIngestionToggles(remote, prior TableConfig) TableConfig {
+	out, _ := deepCopyJSON(remote).(map[string]interface{})
+	remoteSIC := streamIngestionConfigOf(out)
+	if remoteSIC == nil {
+		return out
+	}
+	priorSIC := streamIngestionConfigOf(prior)
+	normalizeBoolKeys(remoteSIC, priorSIC, streamIngestionBoolKeys)
+
+	switch rm := remoteSIC["streamConfigMaps"].(type) {
+	case map[string]interface{}:
+		pm, _ := priorSIC["streamConfigMaps"].(map[string]interface{})
+		normalizeBoolKeys(rm, pm, streamConfigBoolKeys)
+	case []interface{}:
+		pl, _ := priorSIC["streamConfigMaps"].([]interface{})
+		for i, el := range rm {
+			m, ok := el.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var pm map[string]interface{}
+			if i < len(pl) {
+				pm, _ = pl[i].(map[string]interface{})
+			}
+			normalizeBoolKeys(m, pm, streamConfigBoolKeys)
+		}
+	}
+	return out
+}
+
+func normalizeBoolKeys(remote, prior map[string]interface{}, keys []string) {
+	for _, k := range keys {
+		rv, ok := remote[k]
+		if !ok {
+			continue
+		}
+		rb, ok := asBool(rv)
+		if !ok {
+			continue
+		}
+		pv, inPrior := prior[k]
+		if !inPrior {
+			if !rb {
+				delete(remote, k)
+			}
+			continue
+		}
+		if pb, ok := asBool(pv); ok && pb == rb {
+			remote[k] = pv
+		}
+	}
+}
+
+// asBool accepts both JSON booleans and their string forms ("true"/"false").
+func asBool(v interface{}) (bool, bool) {
+	switch t := v.(type) {
+	case bool:
+		return t, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(t))
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+func streamIngestionConfigOf(cfg TableConfig) map[string]interface{} {
+	ingestion, _ := cfg["ingestionConfig"].(map[string]interface{})
+	streamIngestion, _ := ingestion["streamIngestionConfig"].(map[string]interface{})
+	return streamIngestion
+}
+
 func buildSaslIfProvided(data *TableResourceModel) (string, error) {
 	if data.KafkaUsername.IsNull() && data.KafkaPassword.IsNull() {
 		return "", nil
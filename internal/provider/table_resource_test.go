@@ -6,14 +6,24 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-pinot/internal/client"
 )
 
 const defaultTruncateLen = 256
@@ -379,3 +389,860 @@ func truncate(s string) string {
 	}
 	return s[:defaultTruncateLen] + "…"
 }
+
+func TestNormalizeStreamIngestionToggles(t *testing.T) {
+	cases := map[string]struct {
+		remote string
+		prior  string
+		want   string
+	}{
+		"map shape keeps prior representation": {
+			remote: `{"ingestionConfig":{"streamIngestionConfig":{"columnMajorSegmentBuilderEnabled":"true","streamConfigMaps":{"stream.kafka.metadata.populate":"true"}}}}`,
+			prior:  `{"ingestionConfig":{"streamIngestionConfig":{"columnMajorSegmentBuilderEnabled":true,"streamConfigMaps":{"stream.kafka.metadata.populate":true}}}}`,
+			want:   `{"ingestionConfig":{"streamIngestionConfig":{"columnMajorSegmentBuilderEnabled":true,"streamConfigMaps":{"stream.kafka.metadata.populate":true}}}}`,
+		},
+		"list shape drops server defaults": {
+			remote: `{"ingestionConfig":{"streamIngestionConfig":{"trackFilteredMessageOffsets":false,"streamConfigMaps":[{"streamType":"kafka","realtime.segment.serverUploadToDeepStore":"false"}]}}}`,
+			prior:  `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"}]}}}`,
+			want:   `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"}]}}}`,
+		},
+		"real drift is kept": {
+			remote: `{"ingestionConfig":{"streamIngestionConfig":{"pauselessConsumptionEnabled":true,"streamConfigMaps":[{"stream.kafka.metadata.populate":"false"}]}}}`,
+			prior:  `{"ingestionConfig":{"streamIngestionConfig":{"pauselessConsumptionEnabled":false,"streamConfigMaps":[{"stream.kafka.metadata.populate":true}]}}}`,
+			want:   `{"ingestionConfig":{"streamIngestionConfig":{"pauselessConsumptionEnabled":true,"streamConfigMaps":[{"stream.kafka.metadata.populate":"false"}]}}}`,
+		},
+	}
+
+	for name, tc := range cases {
+		var remote, prior, want TableConfig
+		for _, p := range []struct {
+			in  string
+			out *TableConfig
+		}{{tc.remote, &remote}, {tc.prior, &prior}, {tc.want, &want}} {
+			if err := json.Unmarshal([]byte(p.in), p.out); err != nil {
+				t.Fatalf("%s: invalid test JSON: %v", name, err)
+			}
+		}
+
+		got := normalizeStreamIngestionToggles(remote, prior)
+		gotJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(want)
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("%s:\n got  %s\n want %s", name, gotJSON, wantJSON)
+		}
+	}
+}
+
+func TestConfigOverridesRoundTrip(t *testing.T) {
+	data := TableResourceModel{
+		BrokerTenant: types.StringNull(),
+		ServerTenant: types.StringValue("serverA"),
+		NullHandling: types.BoolValue(true),
+	}
+	overrides := tableConfigOverrides(&data)
+
+	cfg := mustJSONMap(t, `{"tableName":"t","tableIndexConfig":{"loadMode":"MMAP"}}`)
+	user, _ := deepCopyJSON(cfg).(map[string]interface{})
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	want := mustJSONMap(t, `{"tableName":"t","tableIndexConfig":{"loadMode":"MMAP","nullHandlingEnabled":true},"tenants":{"server":"serverA"}}`)
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("after apply = %v, want %v", cfg, want)
+	}
+
+	// Drift on the remote side is reflected in the attribute.
+	remote, _ := deepCopyJSON(cfg).(map[string]interface{})
+	remote["tableIndexConfig"].(map[string]interface{})["nullHandlingEnabled"] = "false"
+	refreshConfigOverrides(remote, overrides)
+	if data.NullHandling.ValueBool() {
+		t.Errorf("null_handling_enabled was not refreshed from remote")
+	}
+
+	if got := stripConfigOverrides(cfg, user, overrides); !reflect.DeepEqual(got, user) {
+		t.Errorf("after strip = %v, want %v", got, user)
+	}
+
+	conflicting := mustJSONMap(t, `{"tableIndexConfig":{"nullHandlingEnabled":false}}`)
+	if err := applyConfigOverrides(conflicting, overrides); err == nil {
+		t.Errorf("expected conflict error when table_config already sets nullHandlingEnabled")
+	}
+}
+
+func TestNormalizeSectionToggles(t *testing.T) {
+	remote := mustJSONMap(t, `{"segmentsConfig":{"replication":"1","minimizeDataMovement":"true"},"tableIndexConfig":{"nullHandlingEnabled":false}}`)
+	prior := mustJSONMap(t, `{"segmentsConfig":{"replication":"1","minimizeDataMovement":true},"tableIndexConfig":{}}`)
+	want := mustJSONMap(t, `{"segmentsConfig":{"replication":"1","minimizeDataMovement":true},"tableIndexConfig":{}}`)
+
+	if got := normalizeSectionToggles(remote, prior); !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeSectionToggles = %v, want %v", got, want)
+	}
+
+	remote = mustJSONMap(t, `{"tableIndexConfig":{"aggregateMetrics":"true"},"ingestionConfig":{"continueOnError":"true","rowTimeValueCheck":"false"}}`)
+	prior = mustJSONMap(t, `{"tableIndexConfig":{"aggregateMetrics":true},"ingestionConfig":{"continueOnError":true,"rowTimeValueCheck":false}}`)
+	if got := normalizeSectionToggles(remote, prior); !reflect.DeepEqual(got, prior) {
+		t.Errorf("toggles not normalized: %v", got)
+	}
+}
+
+func TestAggregateMetricsOverride(t *testing.T) {
+	data := TableResourceModel{AggregateMetrics: types.BoolValue(true), FieldsToUnnest: types.ListNull(types.StringType)}
+	overrides := tableConfigOverrides(&data)
+	cfg := mustJSONMap(t, `{"tableIndexConfig":{"invertedIndexColumns":[]}}`)
+	user, _ := deepCopyJSON(cfg).(map[string]interface{})
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	if got, _ := lookupJSONPath(cfg, "tableIndexConfig.aggregateMetrics"); got != true {
+		t.Errorf("aggregateMetrics = %v, want true", got)
+	}
+
+	// Pinot may echo the toggle back as a string.
+	refreshConfigOverrides(mustJSONMap(t, `{"tableIndexConfig":{"aggregateMetrics":"false"}}`), overrides)
+	if data.AggregateMetrics.ValueBool() {
+		t.Errorf("aggregate_metrics not refreshed from remote")
+	}
+	if got := stripConfigOverrides(cfg, user, overrides); !reflect.DeepEqual(got, user) {
+		t.Errorf("after strip = %v, want %v", got, user)
+	}
+}
+
+func TestMaxQPSOverride(t *testing.T) {
+	data := TableResourceModel{MaxQPS: types.StringValue("100")}
+	o := numberStringOverride("max_qps", "quota.maxQueriesPerSecond", &data.MaxQPS)
+
+	o.refresh("100.0")
+	if got := data.MaxQPS.ValueString(); got != "100" {
+		t.Errorf("equivalent remote value replaced the configured one: %q", got)
+	}
+	o.refresh("50.0")
+	if got := data.MaxQPS.ValueString(); got != "50.0" {
+		t.Errorf("drift not reflected: %q", got)
+	}
+
+	prior := TableResourceModel{
+		TableConfig:     jsontypes.NewNormalizedValue(`{"tableName":"t"}`),
+		InjectedSecrets: types.ListNull(types.StringType),
+		FieldsToUnnest:  types.ListNull(types.StringType),
+		MaxQPS:          types.StringValue("100"),
+	}
+	plan := prior
+	plan.MaxQPS = types.StringValue("200")
+	if reloadRequired(&plan, &prior, defaultReloadTriggerPaths) {
+		t.Errorf("a quota-only change should not reload")
+	}
+	plan.NullHandling = types.BoolValue(true)
+	if !reloadRequired(&plan, &prior, defaultReloadTriggerPaths) {
+		t.Errorf("null_handling_enabled sets tableIndexConfig and should reload")
+	}
+}
+
+func TestReloadRequired(t *testing.T) {
+	prior := TableResourceModel{
+		TableConfig: jsontypes.NewNormalizedValue(`{"tableName":"t","metadata":{"customConfigs":{"owner":"a"}},` +
+			`"tableIndexConfig":{"invertedIndexColumns":["a"]},"ingestionConfig":{"transformConfigs":[]}}`),
+		FieldsToUnnest: types.ListNull(types.StringType),
+	}
+	cases := map[string]struct {
+		config   string
+		triggers []string
+		want     bool
+	}{
+		"metadata only": {
+			config: `{"tableName":"t","metadata":{"customConfigs":{"owner":"b"}},` +
+				`"tableIndexConfig":{"invertedIndexColumns":["a"]},"ingestionConfig":{"transformConfigs":[]}}`,
+			triggers: defaultReloadTriggerPaths,
+		},
+		"index change": {
+			config: `{"tableName":"t","metadata":{"customConfigs":{"owner":"a"}},` +
+				`"tableIndexConfig":{"invertedIndexColumns":["a","b"]},"ingestionConfig":{"transformConfigs":[]}}`,
+			triggers: defaultReloadTriggerPaths,
+			want:     true,
+		},
+		"parent of trigger removed": {
+			config:   `{"tableName":"t","metadata":{"customConfigs":{"owner":"a"}},"tableIndexConfig":{"invertedIndexColumns":["a"]}}`,
+			triggers: defaultReloadTriggerPaths,
+			want:     true,
+		},
+		"custom triggers": {
+			config:   `{"tableName":"t","metadata":{"customConfigs":{"owner":"b"}},"tableIndexConfig":{"invertedIndexColumns":["a"]},"ingestionConfig":{"transformConfigs":[]}}`,
+			triggers: []string{"metadata"},
+			want:     true,
+		},
+		"no triggers": {
+			config:   `{"tableName":"t"}`,
+			triggers: []string{},
+		},
+	}
+	for name, tc := range cases {
+		plan := prior
+		plan.TableConfig = jsontypes.NewNormalizedValue(tc.config)
+		if got := reloadRequired(&plan, &prior, tc.triggers); got != tc.want {
+			t.Errorf("%s: reloadRequired = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestDetectTableType(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		want    string
+		wantErr string
+	}{
+		"offline only":  {body: `{"OFFLINE":{"tableName":"events_OFFLINE"}}`, want: "OFFLINE"},
+		"realtime only": {body: `{"REALTIME":{"tableName":"events_REALTIME"}}`, want: "REALTIME"},
+		"hybrid":        {body: `{"OFFLINE":{"tableName":"events_OFFLINE"},"REALTIME":{"tableName":"events_REALTIME"}}`, wantErr: "events_OFFLINE or events_REALTIME"},
+		"missing":       {body: `{}`, wantErr: "does not exist"},
+	}
+
+	for name, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(tc.body))
+		}))
+		c, err := client.NewPinotClient(srv.URL, "", "")
+		if err != nil {
+			t.Fatalf("NewPinotClient: %v", err)
+		}
+		r := &TableResource{client: c}
+
+		got, err := r.detectTableType(t.Context(), "events")
+		srv.Close()
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: expected error containing %q, got %v", name, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", name, got, tc.want)
+		}
+	}
+}
+
+func TestValidateWithSchema(t *testing.T) {
+	var requests []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		http.Error(w, `{"code":400,"error":"Invalid TableConfigs: events. Time column: ts not found in schema"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &TableResource{client: c}
+	tableConfig := TableConfig{"tableName": "events_REALTIME", "segmentsConfig": map[string]interface{}{"timeColumnName": "ts"}}
+
+	cases := map[string]struct {
+		autoCreate bool
+		schema     string
+		validated  bool
+	}{
+		"inline schema":      {autoCreate: true, schema: `{"schemaName":"events"}`, validated: true},
+		"schema not managed": {autoCreate: false, schema: `{"schemaName":"events"}`, validated: false},
+		"differently named":  {autoCreate: true, schema: `{"schemaName":"shared_events"}`, validated: false},
+	}
+	for name, tc := range cases {
+		requests = nil
+		data := TableResourceModel{
+			TableName:        types.StringValue("events"),
+			TableType:        types.StringValue("REALTIME"),
+			AutoCreateSchema: types.BoolValue(tc.autoCreate),
+			Schema:           jsontypes.NewNormalizedValue(tc.schema),
+		}
+		err := r.validateWithSchema(t.Context(), &data, tableConfig)
+		if !tc.validated {
+			if err != nil || len(requests) != 0 {
+				t.Errorf("%s: err = %v, requests = %v; want no validation", name, err, requests)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "Time column: ts not found in schema") {
+			t.Errorf("%s: err = %v, want the controller's message", name, err)
+		}
+		if len(requests) != 1 || requests[0]["tableName"] != "events" || requests[0]["realtime"] == nil || requests[0]["offline"] != nil {
+			t.Errorf("%s: requests = %v", name, requests)
+		}
+	}
+}
+
+func TestFlushThresholds(t *testing.T) {
+	for name, tc := range map[string]struct {
+		stream  string
+		wantErr bool
+	}{
+		"rows only":            {stream: `{"realtime.segment.flush.threshold.rows": "500000"}`},
+		"size only":            {stream: `{"realtime.segment.flush.threshold.segment.size": "200M"}`},
+		"size with zero rows":  {stream: `{"realtime.segment.flush.threshold.rows": "0", "realtime.segment.flush.threshold.segment.size": "200M"}`},
+		"rows and size":        {stream: `{"realtime.segment.flush.threshold.rows": "500000", "realtime.segment.flush.threshold.segment.size": "200M"}`, wantErr: true},
+		"legacy rows and size": {stream: `{"realtime.segment.flush.threshold.size": "500000", "realtime.segment.flush.threshold.segment.size": "200M"}`, wantErr: true},
+		"no thresholds at all": {stream: `{"streamType": "kafka"}`},
+	} {
+		if err := checkFlushThresholds(mustJSONMap(t, tc.stream)); (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", name, err, tc.wantErr)
+		}
+	}
+
+	cfg := mustJSONMap(t, `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"},{"streamType":"kafka"}]}}}`)
+	userConfig := mustJSONMap(t, `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"},{"streamType":"kafka"}]}}}`)
+	rows, size := types.Int64Value(250000), types.StringNull()
+	if err := injectFlushThresholds(cfg, rows, size); err != nil {
+		t.Fatalf("injectFlushThresholds: %v", err)
+	}
+	for _, m := range streamConfigMapsOf(cfg) {
+		if m[flushThresholdRowsKey] != "250000" {
+			t.Errorf("rows not injected: %v", m)
+		}
+	}
+	if err := injectFlushThresholds(cfg, rows, size); err == nil {
+		t.Error("expected an error when table_config already sets a flush threshold")
+	}
+
+	data := TableResourceModel{FlushRows: types.Int64Value(1), FlushSize: types.StringNull()}
+	refreshFlushThresholds(cfg, &data)
+	if data.FlushRows.ValueInt64() != 250000 {
+		t.Errorf("refreshed rows = %v", data.FlushRows)
+	}
+	if got := stripFlushThresholds(cfg, rows, size); !reflect.DeepEqual(got, userConfig) {
+		t.Errorf("strip: got %v, want %v", got, userConfig)
+	}
+}
+
+func TestKafkaDecoderProps(t *testing.T) {
+	props := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"schema.registry.rest.url": types.StringValue("http://registry:8081"),
+	})
+	for name, stream := range map[string]string{
+		"list shape": `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"}]}}}`,
+		"map shape":  `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":{"streamType":"kafka"}}}}`,
+		"legacy":     `{"tableIndexConfig":{"streamConfigs":{"streamType":"kafka"}}}`,
+	} {
+		cfg := mustJSONMap(t, stream)
+		if err := injectKafkaDecoderProps(t.Context(), cfg, props); err != nil {
+			t.Fatalf("%s: injectKafkaDecoderProps: %v", name, err)
+		}
+		if got := streamConfigMapsOf(cfg)[0][kafkaDecoderPropPrefix+"schema.registry.rest.url"]; got != "http://registry:8081" {
+			t.Errorf("%s: decoder prop not injected: %v", name, cfg)
+		}
+		if err := injectKafkaDecoderProps(t.Context(), cfg, props); err == nil {
+			t.Errorf("%s: expected an error when table_config already sets a decoder property", name)
+		}
+
+		// A property added outside Terraform shows up in the map on refresh.
+		streamConfigMapsOf(cfg)[0][kafkaDecoderPropPrefix+"format"] = "AVRO"
+		data := TableResourceModel{DecoderProps: props}
+		refreshKafkaDecoderProps(cfg, &data)
+		want := types.MapValueMust(types.StringType, map[string]attr.Value{
+			"schema.registry.rest.url": types.StringValue("http://registry:8081"),
+			"format":                   types.StringValue("AVRO"),
+		})
+		if !data.DecoderProps.Equal(want) {
+			t.Errorf("%s: refreshed = %v, want %v", name, data.DecoderProps, want)
+		}
+		if got := stripKafkaDecoderProps(cfg, props); !reflect.DeepEqual(got, mustJSONMap(t, stream)) {
+			t.Errorf("%s: strip: got %v", name, got)
+		}
+	}
+
+	if err := injectKafkaDecoderProps(t.Context(), mustJSONMap(t, `{}`), props); err == nil {
+		t.Error("expected an error without stream configuration")
+	}
+}
+
+func TestTaskConfigs(t *testing.T) {
+	objType := types.ObjectType{AttrTypes: taskConfigAttrTypes}
+	configs := types.MapValueMust(objType, map[string]attr.Value{
+		"RealtimeToOfflineSegmentsTask": types.ObjectValueMust(taskConfigAttrTypes, map[string]attr.Value{
+			"bucket_time_period": types.StringValue("6h"),
+			"buffer_time_period": types.StringValue("1d"),
+			"merge_type":         types.StringNull(),
+		}),
+	})
+	// A key of the same task type and another task type stay passthrough.
+	user := `{"tableName":"events","task":{"taskTypeConfigsMap":{"RealtimeToOfflineSegmentsTask":{"maxNumRecordsPerSegment":"1000000"},"PurgeTask":{}}}}`
+
+	cfg := mustJSONMap(t, user)
+	if err := injectTaskConfigs(cfg, configs); err != nil {
+		t.Fatalf("injectTaskConfigs: %v", err)
+	}
+	want := mustJSONMap(t, `{"tableName":"events","task":{"taskTypeConfigsMap":{"RealtimeToOfflineSegmentsTask":{"maxNumRecordsPerSegment":"1000000","bucketTimePeriod":"6h","bufferTimePeriod":"1d"},"PurgeTask":{}}}}`)
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("injected = %v, want %v", cfg, want)
+	}
+	if err := injectTaskConfigs(cfg, configs); err == nil {
+		t.Error("expected an error when table_config already sets a task config key")
+	}
+
+	// A key changed outside Terraform shows up on refresh.
+	cfg["task"].(map[string]interface{})["taskTypeConfigsMap"].(map[string]interface{})["RealtimeToOfflineSegmentsTask"].(map[string]interface{})["bucketTimePeriod"] = "12h"
+	data := TableResourceModel{TaskConfigs: configs}
+	refreshTaskConfigs(cfg, &data)
+	refreshed := types.MapValueMust(objType, map[string]attr.Value{
+		"RealtimeToOfflineSegmentsTask": types.ObjectValueMust(taskConfigAttrTypes, map[string]attr.Value{
+			"bucket_time_period": types.StringValue("12h"),
+			"buffer_time_period": types.StringValue("1d"),
+			"merge_type":         types.StringNull(),
+		}),
+	})
+	if !data.TaskConfigs.Equal(refreshed) {
+		t.Errorf("refreshed = %v, want %v", data.TaskConfigs, refreshed)
+	}
+
+	if got := stripTaskConfigs(cfg, mustJSONMap(t, user), configs); !reflect.DeepEqual(got, mustJSONMap(t, user)) {
+		t.Errorf("strip: got %v, want %v", got, user)
+	}
+	// Sections that only exist for task_configs are dropped from state.
+	cfg = mustJSONMap(t, `{"tableName":"events"}`)
+	if err := injectTaskConfigs(cfg, configs); err != nil {
+		t.Fatalf("injectTaskConfigs: %v", err)
+	}
+	if got := stripTaskConfigs(cfg, mustJSONMap(t, `{"tableName":"events"}`), configs); !reflect.DeepEqual(got, mustJSONMap(t, `{"tableName":"events"}`)) {
+		t.Errorf("strip without task section: got %v", got)
+	}
+}
+
+func TestMissingSchemaColumns(t *testing.T) {
+	schema := mustJSONMap(t, `{
+		"schemaName": "events",
+		"dimensionFieldSpecs": [{"name": "user_id", "dataType": "STRING"}],
+		"dateTimeFieldSpecs": [{"name": "ts", "dataType": "LONG", "format": "1:MILLISECONDS:EPOCH", "granularity": "1:MILLISECONDS"}]
+	}`)
+	for name, tc := range map[string]struct {
+		config string
+		want   []columnReference
+	}{
+		"all defined": {
+			config: `{"segmentsConfig":{"timeColumnName":"ts"},"ingestionConfig":{"transformConfigs":[{"columnName":"user_id","transformFunction":"jsonPathString(payload, '$.user')"}]}}`,
+		},
+		"missing transform column": {
+			config: `{"segmentsConfig":{"timeColumnName":"ts"},"ingestionConfig":{"transformConfigs":[{"columnName":"user_id"},{"columnName":"country"}]}}`,
+			want:   []columnReference{{source: "ingestionConfig.transformConfigs[1]", column: "country"}},
+		},
+		"missing time column": {
+			config: `{"segmentsConfig":{"timeColumnName":"event_time"}}`,
+			want:   []columnReference{{source: "segmentsConfig.timeColumnName", column: "event_time"}},
+		},
+		"nothing referenced": {config: `{}`},
+	} {
+		if got := missingSchemaColumns(mustJSONMap(t, tc.config), schema); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestTimeTypeMismatch(t *testing.T) {
+	schema := mustJSONMap(t, `{
+		"dateTimeFieldSpecs": [
+			{"name": "ts", "dataType": "LONG", "format": "1:MILLISECONDS:EPOCH", "granularity": "1:MILLISECONDS"},
+			{"name": "day", "dataType": "STRING", "format": "1:DAYS:SIMPLE_DATE_FORMAT:yyyyMMdd", "granularity": "1:DAYS"}
+		]
+	}`)
+	for name, tc := range map[string]struct {
+		config   string
+		mismatch bool
+	}{
+		"matching unit":           {config: `{"segmentsConfig":{"timeColumnName":"ts","timeType":"MILLISECONDS"}}`},
+		"other unit":              {config: `{"segmentsConfig":{"timeColumnName":"ts","timeType":"SECONDS"}}`, mismatch: true},
+		"simple date format":      {config: `{"segmentsConfig":{"timeColumnName":"day","timeType":"MILLISECONDS"}}`},
+		"no time type":            {config: `{"segmentsConfig":{"timeColumnName":"ts"}}`},
+		"column not in schema":    {config: `{"segmentsConfig":{"timeColumnName":"event_time","timeType":"SECONDS"}}`},
+		"no segments config":      {config: `{}`},
+		"lower case time type ok": {config: `{"segmentsConfig":{"timeColumnName":"ts","timeType":"milliseconds"}}`},
+	} {
+		column, timeType, unit, mismatch := timeTypeMismatch(mustJSONMap(t, tc.config), schema)
+		if mismatch != tc.mismatch {
+			t.Errorf("%s: mismatch = %v (%s %s %s), want %v", name, mismatch, column, timeType, unit, tc.mismatch)
+		}
+		if mismatch && (column != "ts" || timeType != "SECONDS" || unit != "MILLISECONDS") {
+			t.Errorf("%s: got %s %s %s", name, column, timeType, unit)
+		}
+	}
+}
+
+func TestComplexTypeConfigOverrides(t *testing.T) {
+	data := TableResourceModel{
+		ComplexDelimiter: types.StringValue("__"),
+		FieldsToUnnest:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("items")}),
+	}
+	overrides := tableConfigOverrides(&data)
+
+	cfg := mustJSONMap(t, `{"ingestionConfig":{"transformConfigs":[]}}`)
+	user, _ := deepCopyJSON(cfg).(map[string]interface{})
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	want := mustJSONMap(t, `{"ingestionConfig":{"transformConfigs":[],"complexTypeConfig":{"delimiter":"__","fieldsToUnnest":["items"]}}}`)
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("after apply = %v, want %v", cfg, want)
+	}
+
+	remote := mustJSONMap(t, `{"ingestionConfig":{"complexTypeConfig":{"delimiter":"__","fieldsToUnnest":["items","tags"]}}}`)
+	refreshConfigOverrides(remote, overrides)
+	if got := len(data.FieldsToUnnest.Elements()); got != 2 {
+		t.Errorf("fields_to_unnest not refreshed from remote: %v", data.FieldsToUnnest)
+	}
+
+	if got := stripConfigOverrides(cfg, user, overrides); !reflect.DeepEqual(got, user) {
+		t.Errorf("after strip = %v, want %v", got, user)
+	}
+}
+
+func TestDeletedSegmentsRetentionPeriod(t *testing.T) {
+	for period, want := range map[string]bool{"7d": true, "0d": true, "1d12h": true, "30m": true, "12h30m15s": true, "": true, "7 days": false, "h": false, "12h1d": false, "7D": false} {
+		if got := retentionPeriodPattern.MatchString(period); got != want {
+			t.Errorf("retentionPeriodPattern.MatchString(%q) = %v, want %v", period, got, want)
+		}
+	}
+
+	data := TableResourceModel{DeletedRetention: types.StringValue("7d"), FieldsToUnnest: types.ListNull(types.StringType)}
+	overrides := tableConfigOverrides(&data)
+	cfg := mustJSONMap(t, `{"segmentsConfig":{"replication":"1"}}`)
+	user, _ := deepCopyJSON(cfg).(map[string]interface{})
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	if got := cfg["segmentsConfig"].(map[string]interface{})["deletedSegmentsRetentionPeriod"]; got != "7d" {
+		t.Errorf("deletedSegmentsRetentionPeriod = %v, want 7d", got)
+	}
+
+	refreshConfigOverrides(mustJSONMap(t, `{"segmentsConfig":{"deletedSegmentsRetentionPeriod":"14d"}}`), overrides)
+	if data.DeletedRetention.ValueString() != "14d" {
+		t.Errorf("deleted_segments_retention_period not refreshed from remote: %v", data.DeletedRetention)
+	}
+
+	if got := stripConfigOverrides(cfg, user, overrides); !reflect.DeepEqual(got, user) {
+		t.Errorf("after strip = %v, want %v", got, user)
+	}
+}
+
+func TestManagedByOverrides(t *testing.T) {
+	tags := map[string]string{managedByConfigKey: "terraform", ownerConfigKey: "data-platform"}
+
+	user := mustJSONMap(t, `{"tableName":"t","metadata":{"customConfigs":{"owner":"analytics"}}}`)
+	cfg, _ := deepCopyJSON(user).(map[string]interface{})
+	overrides := managedByOverrides(tags, user)
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	want := mustJSONMap(t, `{"tableName":"t","metadata":{"customConfigs":{"owner":"analytics","managed-by":"terraform"}}}`)
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("after apply = %v, want %v (the user's owner must win)", cfg, want)
+	}
+	if got := stripConfigOverrides(cfg, user, overrides); !reflect.DeepEqual(got, user) {
+		t.Errorf("after strip = %v, want %v", got, user)
+	}
+
+	bare := mustJSONMap(t, `{"tableName":"t"}`)
+	cfg, _ = deepCopyJSON(bare).(map[string]interface{})
+	overrides = managedByOverrides(tags, bare)
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	if got := stripConfigOverrides(cfg, bare, overrides); !reflect.DeepEqual(got, bare) {
+		t.Errorf("empty metadata left behind: %v", got)
+	}
+
+	if got := managedByOverrides(nil, bare); len(got) != 0 {
+		t.Errorf("expected no overrides without tags, got %d", len(got))
+	}
+}
+
+func TestCanonicalTableConfig(t *testing.T) {
+	secrets := []InjectedSecretModel{{JSONPath: types.StringValue("metadata.customConfigs.token"), Value: types.StringValue("s3cr3t")}}
+	a := mustJSONMap(t, `{
+		"tableName": "events_REALTIME",
+		"metadata": {"customConfigs": {"token": "s3cr3t", "owner": "data"}},
+		"ingestionConfig": {"streamIngestionConfig": {"streamConfigMaps": [{"sasl.jaas.config": "secret", "streamType": "kafka"}]}}
+	}`)
+	b := mustJSONMap(t, `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka","sasl.jaas.config":"other"}]}},"metadata":{"customConfigs":{"owner":"data","token":"rotated"}},"tableName":"events_REALTIME"}`)
+
+	got := canonicalTableConfig(a, secrets)
+	want := `{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"}]}},"metadata":{"customConfigs":{"owner":"data"}},"tableName":"events_REALTIME"}`
+	if got.ValueString() != want {
+		t.Errorf("got %s, want %s", got.ValueString(), want)
+	}
+	if other := canonicalTableConfig(b, secrets); !other.Equal(got) {
+		t.Errorf("equivalent configs render differently: %s vs %s", other.ValueString(), got.ValueString())
+	}
+	if _, ok := a["metadata"].(map[string]interface{})["customConfigs"].(map[string]interface{})["token"]; !ok {
+		t.Error("input config was modified")
+	}
+}
+
+func TestStripDefaultTenants(t *testing.T) {
+	cases := map[string]struct {
+		remote, prior, want string
+	}{
+		"omitted tenants": {
+			remote: `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"DefaultTenant"}}`,
+			prior:  `{"tableName":"t"}`,
+			want:   `{"tableName":"t"}`,
+		},
+		"empty tenants": {
+			remote: `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"DefaultTenant"}}`,
+			prior:  `{"tableName":"t","tenants":{}}`,
+			want:   `{"tableName":"t","tenants":{}}`,
+		},
+		"user sets one tenant": {
+			remote: `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"hot"}}`,
+			prior:  `{"tableName":"t","tenants":{"server":"hot"}}`,
+			want:   `{"tableName":"t","tenants":{"server":"hot"}}`,
+		},
+		"user sets the default explicitly": {
+			remote: `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"DefaultTenant"}}`,
+			prior:  `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"DefaultTenant"}}`,
+			want:   `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"DefaultTenant"}}`,
+		},
+		"changed outside terraform": {
+			remote: `{"tableName":"t","tenants":{"broker":"DefaultTenant","server":"cold"}}`,
+			prior:  `{"tableName":"t"}`,
+			want:   `{"tableName":"t","tenants":{"server":"cold"}}`,
+		},
+	}
+	for name, tc := range cases {
+		got := stripDefaultTenants(mustJSONMap(t, tc.remote), mustJSONMap(t, tc.prior))
+		if want := mustJSONMap(t, tc.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: stripDefaultTenants = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestTableReplication(t *testing.T) {
+	cases := map[string]struct {
+		config string
+		want   int64
+		ok     bool
+	}{
+		"string":  {`{"segmentsConfig":{"replication":"3"}}`, 3, true},
+		"number":  {`{"segmentsConfig":{"replication":2}}`, 2, true},
+		"missing": {`{"segmentsConfig":{}}`, 0, false},
+		"invalid": {`{"segmentsConfig":{"replication":"three"}}`, 0, false},
+	}
+	for name, tc := range cases {
+		got, ok := tableReplication(mustJSONMap(t, tc.config))
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%s: tableReplication = %d, %t, want %d, %t", name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestQueryTimeoutOverride(t *testing.T) {
+	data := TableResourceModel{QueryTimeoutMs: types.Int64Value(15000), FieldsToUnnest: types.ListNull(types.StringType)}
+	cfg := mustJSONMap(t, `{"tableName":"t","query":{"disableGroovy":true}}`)
+	overrides := []configOverride{int64Override("query_timeout_ms", "query.timeoutMs", &data.QueryTimeoutMs)}
+	if err := applyConfigOverrides(cfg, overrides); err != nil {
+		t.Fatalf("applyConfigOverrides: %v", err)
+	}
+	if got, _ := canonicalJSON(cfg); got != `{"query":{"disableGroovy":true,"timeoutMs":15000},"tableName":"t"}` {
+		t.Errorf("config = %s", got)
+	}
+
+	for _, remote := range []interface{}{float64(30000), "30000"} {
+		data.QueryTimeoutMs = types.Int64Value(15000)
+		overrides[0].refresh(remote)
+		if got := data.QueryTimeoutMs.ValueInt64(); got != 30000 {
+			t.Errorf("refresh(%v) = %d, want 30000", remote, got)
+		}
+	}
+
+	stripped := stripConfigOverrides(cfg, mustJSONMap(t, `{"tableName":"t","query":{"disableGroovy":true}}`), overrides)
+	if want := mustJSONMap(t, `{"tableName":"t","query":{"disableGroovy":true}}`); !reflect.DeepEqual(stripped, want) {
+		t.Errorf("stripped = %v, want %v", stripped, want)
+	}
+}
+
+func TestAutoSuffixTableName(t *testing.T) {
+	cfg := mustJSONMap(t, `{"tableName":"events","tableType":"OFFLINE"}`)
+	if !suffixConfigTableName(cfg, "events", "events_OFFLINE") || cfg["tableName"] != "events_OFFLINE" {
+		t.Errorf("bare logical name not suffixed: %v", cfg["tableName"])
+	}
+	for _, name := range []string{"events_OFFLINE", "other", ""} {
+		cfg := TableConfig{"tableName": name}
+		if suffixConfigTableName(cfg, "events", "events_OFFLINE") || cfg["tableName"] != name {
+			t.Errorf("tableName %q should be left for the mismatch check, got %v", name, cfg["tableName"])
+		}
+	}
+
+	remote := TableConfig{"tableName": "events_OFFLINE"}
+	if got := restoreLogicalTableName(remote, TableConfig{"tableName": "events"}, "events", "events_OFFLINE"); got["tableName"] != "events" {
+		t.Errorf("bare prior name not restored: %v", got["tableName"])
+	}
+	remote = TableConfig{"tableName": "events_OFFLINE"}
+	if got := restoreLogicalTableName(remote, TableConfig{"tableName": "events_OFFLINE"}, "events", "events_OFFLINE"); got["tableName"] != "events_OFFLINE" {
+		t.Errorf("suffixed prior name changed: %v", got["tableName"])
+	}
+	remote = TableConfig{"tableName": "events_OFFLINE"}
+	if got := restoreLogicalTableName(remote, nil, "events", "events_OFFLINE"); got["tableName"] != "events_OFFLINE" {
+		t.Errorf("imported name changed: %v", got["tableName"])
+	}
+}
+
+func TestDeleteProtectedTable(t *testing.T) {
+	var deletes int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes++
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &TableResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+	}
+	for attr, v := range map[string]string{"id": "events_OFFLINE", "table_name": "events", "table_type": "OFFLINE"} {
+		if diags := state.SetAttribute(t.Context(), path.Root(attr), v); diags.HasError() {
+			t.Fatalf("SetAttribute(%s): %v", attr, diags)
+		}
+	}
+
+	cases := []struct {
+		protect bool
+		allow   string
+		deleted bool
+	}{
+		{protect: false, allow: "", deleted: true},
+		{protect: true, allow: "", deleted: false},
+		{protect: true, allow: "false", deleted: false},
+		{protect: true, allow: "true", deleted: true},
+	}
+	for _, tc := range cases {
+		t.Setenv(allowDestroyEnv, tc.allow)
+		deletes = 0
+		r.client = c.WithTableProtection(tc.protect)
+		resp := fwresource.DeleteResponse{State: state}
+		r.Delete(t.Context(), fwresource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() == tc.deleted || (deletes > 0) != tc.deleted {
+			t.Errorf("protect=%v %s=%q: deletes = %d, diags = %v", tc.protect, allowDestroyEnv, tc.allow, deletes, resp.Diagnostics)
+		}
+	}
+}
+
+func TestDeleteTableControllerURL(t *testing.T) {
+	var defaultHits int
+	def := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultHits++
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer def.Close()
+	var deleted []string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.Method+" "+r.URL.RequestURI())
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer other.Close()
+	c, err := client.NewPinotClient(def.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &TableResource{client: c}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+	}
+	for attr, v := range map[string]string{"id": "events_OFFLINE", "table_name": "events", "table_type": "OFFLINE", "controller_url": other.URL} {
+		if diags := state.SetAttribute(t.Context(), path.Root(attr), v); diags.HasError() {
+			t.Fatalf("SetAttribute(%s): %v", attr, diags)
+		}
+	}
+
+	resp := fwresource.DeleteResponse{State: state}
+	r.Delete(t.Context(), fwresource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete: %v", resp.Diagnostics)
+	}
+	if defaultHits != 0 {
+		t.Errorf("provider controller got %d requests, want none", defaultHits)
+	}
+	if len(deleted) != 1 || deleted[0] != "DELETE /tables/events?type=OFFLINE" {
+		t.Errorf("controller_url got %v, want one typed delete", deleted)
+	}
+}
+
+func TestMissingTenants(t *testing.T) {
+	servers, brokers := []string{"DefaultTenant", "analytics"}, []string{"DefaultTenant"}
+	cases := map[string]struct {
+		config string
+		want   []string
+	}{
+		"known": {config: `{"tenants":{"broker":"DefaultTenant","server":"analytics"}}`},
+		"unset": {config: `{"tableName":"t_OFFLINE"}`},
+		"missing broker": {
+			config: `{"tenants":{"broker":"analytics","server":"analytics"}}`,
+			want:   []string{`tenants.broker names broker tenant "analytics", which does not exist (known: DefaultTenant).`},
+		},
+		"both missing": {
+			config: `{"tenants":{"broker":"b","server":"s"}}`,
+			want: []string{
+				`tenants.broker names broker tenant "b", which does not exist (known: DefaultTenant).`,
+				`tenants.server names server tenant "s", which does not exist (known: DefaultTenant, analytics).`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		if got := missingTenants(mustJSONMap(t, tc.config), servers, brokers); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: missingTenants = %q, want %q", name, got, tc.want)
+		}
+	}
+}
+
+func TestModifyPlanReadBacks(t *testing.T) {
+	r := &TableResource{}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+	}
+	for attr, v := range map[string]string{
+		"id":               "events_OFFLINE",
+		"table_name":       "events",
+		"table_type":       "OFFLINE",
+		"table_config":     `{"tableName":"events_OFFLINE"}`,
+		"canonical_config": `{"tableName":"events_OFFLINE"}`,
+		"config_version":   "3",
+	} {
+		if diags := state.SetAttribute(t.Context(), path.Root(attr), v); diags.HasError() {
+			t.Fatalf("SetAttribute(%s): %v", attr, diags)
+		}
+	}
+
+	modify := func(plan tfsdk.Plan) types.String {
+		resp := fwresource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(t.Context(), fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("ModifyPlan: %v", resp.Diagnostics)
+		}
+		var version types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(t.Context(), path.Root("config_version"), &version)...)
+		return version
+	}
+
+	if got := modify(tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}); got.ValueString() != "3" {
+		t.Errorf("config_version without changes = %v, want the prior version", got)
+	}
+	changed := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	if diags := changed.SetAttribute(t.Context(), path.Root("table_config"), `{"tableName":"events_OFFLINE","tenants":{}}`); diags.HasError() {
+		t.Fatalf("SetAttribute(table_config): %v", diags)
+	}
+	if got := modify(changed); !got.IsUnknown() {
+		t.Errorf("config_version with a table_config change = %v, want unknown", got)
+	}
+}
@@ -0,0 +1,149 @@
+// internal/provider/table_tasks_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &TableTasksDataSource{}
+
+type TableTasksDataSource struct {
+	client *client.PinotClient
+}
+
+type TableTasksDataSourceModel struct {
+	ID        types.String     `tfsdk:"id"`
+	TableName types.String     `tfsdk:"table_name"`
+	TaskType  types.String     `tfsdk:"task_type"`
+	Tasks     []TableTaskModel `tfsdk:"tasks"`
+}
+
+// TableTaskModel is a minion task type configured on a table.
+type TableTaskModel struct {
+	TaskType     types.String `tfsdk:"task_type"`
+	Config       types.Map    `tfsdk:"config"` // map[string]string
+	LastRun      types.String `tfsdk:"last_run"`
+	LastRunState types.String `tfsdk:"last_run_state"`
+}
+
+func NewTableTasksDataSource() datasource.DataSource {
+	return &TableTasksDataSource{}
+}
+
+func (d *TableTasksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_tasks"
+}
+
+func (d *TableTasksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the minion task types configured on a Pinot table (`task.taskTypeConfigsMap`) with the state of each type's most recent run.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier: `<table_name>` or `<table_name>/<task_type>`.",
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table name as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+			},
+			"task_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return this task type, e.g. `MergeRollupTask`.",
+			},
+			"tasks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Configured task types, sorted by type.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"task_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Task type, e.g. `MergeRollupTask`.",
+						},
+						"config": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "The task type's configuration from the table config.",
+						},
+						"last_run": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the most recent run for this table; null if the task has not run yet.",
+						},
+						"last_run_state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "State of the most recent run, e.g. `COMPLETED`, `IN_PROGRESS` or `FAILED`; null if the task has not run yet.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TableTasksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TableTasksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TableTasksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tableName := data.TableName.ValueString()
+	if _, typ := splitTableID(tableName); typ == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_name"),
+			"Invalid Table Name",
+			"table_name must be in the form <logical>_OFFLINE or <logical>_REALTIME.",
+		)
+		return
+	}
+
+	tasks, err := d.client.GetTableTasks(ctx, tableName)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Table Tasks", "Could not read the tasks of table "+tableName, err)
+		return
+	}
+
+	data.ID = types.StringValue(tableName)
+	if !data.TaskType.IsNull() {
+		data.ID = types.StringValue(tableName + "/" + data.TaskType.ValueString())
+	}
+	data.Tasks = []TableTaskModel{}
+	for _, t := range tasks {
+		if !data.TaskType.IsNull() && t.TaskType != data.TaskType.ValueString() {
+			continue
+		}
+		config, diags := types.MapValueFrom(ctx, types.StringType, t.Config)
+		resp.Diagnostics.Append(diags...)
+		data.Tasks = append(data.Tasks, TableTaskModel{
+			TaskType:     types.StringValue(t.TaskType),
+			Config:       config,
+			LastRun:      stringOrNull(t.LastRun),
+			LastRunState: stringOrNull(t.LastRunState),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,373 @@
+// internal/provider/table_with_schema_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &TableWithSchemaResource{}
+var _ resource.ResourceWithImportState = &TableWithSchemaResource{}
+var _ resource.ResourceWithValidateConfig = &TableWithSchemaResource{}
+
+// TableWithSchemaResource manages a table and the schema it uses as one unit, so they are always created,
+// updated and deleted in the order Pinot needs.
+type TableWithSchemaResource struct {
+	client *client.PinotClient
+}
+
+type TableWithSchemaResourceModel struct {
+	ID            types.String         `tfsdk:"id"`
+	TableName     types.String         `tfsdk:"table_name"`
+	TableType     types.String         `tfsdk:"table_type"`
+	Schema        jsontypes.Normalized `tfsdk:"schema"`
+	TableConfig   jsontypes.Normalized `tfsdk:"table_config"`
+	ControllerURL types.String         `tfsdk:"controller_url"`
+}
+
+func NewTableWithSchemaResource() resource.Resource {
+	return &TableWithSchemaResource{}
+}
+
+func (r *TableWithSchemaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table_with_schema"
+}
+
+func (r *TableWithSchemaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a table together with its schema: the schema is created before the table and deleted after it, " +
+			"and schema changes are applied before table changes, so no `depends_on` is needed. " +
+			"The schema is named after the table. Use `pinot_schema` and `pinot_table` instead for schemas shared by several tables or for the table settings only `pinot_table` offers.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Table identifier: `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Logical table name, also the name of the schema.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of table: `OFFLINE` or `REALTIME`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("OFFLINE", "REALTIME"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "JSON schema. Its `schemaName` must be `table_name`. Prefer `jsonencode({...})`.",
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"table_config": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "JSON table config. Its `tableName` must be `<table_name>_<TYPE>` and its `tableType` `table_type`; " +
+					"`segmentsConfig.schemaName`, when set, must be `table_name`. Prefer `jsonencode({...})`.",
+				CustomType: jsontypes.NormalizedType{},
+			},
+		},
+	}
+}
+
+func (r *TableWithSchemaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TableWithSchemaResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TableWithSchemaResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.TableName.IsUnknown() || data.TableType.IsUnknown() {
+		return
+	}
+	logical, typ := data.TableName.ValueString(), data.TableType.ValueString()
+
+	if !data.Schema.IsNull() && !data.Schema.IsUnknown() {
+		var schemaConfig SchemaConfig
+		if diags := data.Schema.Unmarshal(&schemaConfig); diags.HasError() {
+			resp.Diagnostics.AddAttributeError(path.Root("schema"), "Invalid Schema JSON", "schema must be a JSON object.")
+		} else if name, _ := schemaConfig["schemaName"].(string); name != logical {
+			resp.Diagnostics.AddAttributeError(path.Root("schema"), "Schema Name Mismatch",
+				fmt.Sprintf("schemaName is %q but must be the table name %q.", name, logical))
+		}
+	}
+
+	if !data.TableConfig.IsNull() && !data.TableConfig.IsUnknown() {
+		var tableConfig TableConfig
+		if diags := data.TableConfig.Unmarshal(&tableConfig); diags.HasError() {
+			resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Invalid Table Config JSON", "table_config must be a JSON object.")
+			return
+		}
+		for _, msg := range tableWithSchemaMismatches(tableConfig, logical, typ) {
+			resp.Diagnostics.AddAttributeError(path.Root("table_config"), "Table Config Mismatch", msg)
+		}
+	}
+}
+
+// tableWithSchemaMismatches describes where tableConfig disagrees with the table name and type of the resource.
+func tableWithSchemaMismatches(tableConfig TableConfig, logical, typ string) []string {
+	var msgs []string
+	if name, _ := tableConfig["tableName"].(string); name != joinTableID(logical, typ) {
+		msgs = append(msgs, fmt.Sprintf("tableName is %q but must be %q.", name, joinTableID(logical, typ)))
+	}
+	if t, _ := tableConfig["tableType"].(string); !strings.EqualFold(t, typ) {
+		msgs = append(msgs, fmt.Sprintf("tableType is %q but must be %q.", t, typ))
+	}
+	if sn, ok := lookupJSONPath(tableConfig, "segmentsConfig.schemaName"); ok && sn != logical {
+		msgs = append(msgs, fmt.Sprintf("segmentsConfig.schemaName is %v but must be %q.", sn, logical))
+	}
+	return msgs
+}
+
+func (r *TableWithSchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TableWithSchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableWithSchemaResource{client: clientFor(r.client, data.ControllerURL)}
+
+	schemaConfig, tableConfig := r.configs(&resp.Diagnostics, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
+
+	// Catch mismatches between the schema and the table config before either is created.
+	if err := validateTableConfigs(ctx, r.client, data.TableName.ValueString(), data.TableType.ValueString(), schemaConfig, tableConfig); err != nil {
+		addAPIError(&resp.Diagnostics, "Invalid Pinot Table Config", "Table "+fullTableName+" does not validate against its schema", err)
+		return
+	}
+
+	// CreateSchema adopts an identical existing schema, which must survive a failed table create.
+	_, err := r.client.GetSchema(ctx, data.TableName.ValueString())
+	createdSchema := client.IsNotFound(err)
+	if err := r.client.CreateSchema(ctx, schemaConfig); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Pinot Schema", "Could not create schema "+data.TableName.ValueString(), err)
+		return
+	}
+	if err := r.client.CreateTable(ctx, tableConfig); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Pinot Table", "Could not create table "+fullTableName, err)
+		if !createdSchema {
+			return
+		}
+		// Don't leave behind the schema created for this table.
+		if delErr := r.client.DeleteSchema(ctx, data.TableName.ValueString()); delErr != nil {
+			resp.Diagnostics.AddWarning(
+				"Pinot Schema Left Behind",
+				fmt.Sprintf("Schema %s was created for the table but could not be deleted: %v", data.TableName.ValueString(), delErr),
+			)
+		}
+		return
+	}
+
+	data.ID = types.StringValue(fullTableName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TableWithSchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TableWithSchemaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableWithSchemaResource{client: clientFor(r.client, data.ControllerURL)}
+
+	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
+	tableConfig, err := r.client.GetTable(ctx, fullTableName)
+	if err != nil {
+		// Without the table there is nothing left to manage; both are recreated.
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Table", "Could not read table "+fullTableName, err)
+		return
+	}
+	var priorConfig TableConfig
+	if !data.TableConfig.IsNull() && !data.TableConfig.IsUnknown() {
+		resp.Diagnostics.Append(data.TableConfig.Unmarshal(&priorConfig)...)
+	}
+	var priorSchema SchemaConfig
+	if !data.Schema.IsNull() && !data.Schema.IsUnknown() {
+		resp.Diagnostics.Append(data.Schema.Unmarshal(&priorSchema)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configJSON, err := canonicalJSON(tableWithSchemaConfigForState(tableConfig, priorConfig, r.client.ManagedByTags()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Marshaling Table Config", "Could not marshal table configuration to JSON: "+err.Error())
+		return
+	}
+	data.TableConfig = jsontypes.NewNormalizedValue(configJSON)
+
+	schemaConfig, err := r.client.GetSchema(ctx, data.TableName.ValueString())
+	switch {
+	case client.IsNotFound(err):
+		// A schema deleted outside Terraform shows up as a change and is created again on apply.
+		data.Schema = jsontypes.NewNormalizedNull()
+	case err != nil:
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Schema", "Could not read schema "+data.TableName.ValueString(), err)
+		return
+	default:
+		schemaConfig = normalizeDefaultNullValues(schemaConfig, priorSchema)
+		schemaConfig = stripServerDefaultMaxLength(schemaConfig, priorSchema)
+		schemaJSON, err := canonicalJSON(schemaConfig)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Marshaling Schema", "Could not marshal schema to JSON: "+err.Error())
+			return
+		}
+		data.Schema = jsontypes.NewNormalizedValue(schemaJSON)
+	}
+
+	data.ID = types.StringValue(fullTableName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// tableWithSchemaConfigForState normalizes the table config read from the controller for state the way
+// pinot_table does for a config without typed attributes.
+func tableWithSchemaConfigForState(remote, prior TableConfig, managedByTags map[string]string) TableConfig {
+	overrides := managedByOverrides(managedByTags, prior)
+	remote = normalizeStreamIngestionToggles(remote, prior)
+	remote = normalizeSectionToggles(remote, prior)
+	clean := cleanTableConfigForState(remote, nil, false)
+	clean = stripConfigOverrides(clean, prior, overrides)
+	return stripDefaultTenants(clean, prior)
+}
+
+func (r *TableWithSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TableWithSchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableWithSchemaResource{client: clientFor(r.client, plan.ControllerURL)}
+
+	schemaConfig, tableConfig := r.configs(&resp.Diagnostics, &plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fullTableName := joinTableID(plan.TableName.ValueString(), plan.TableType.ValueString())
+
+	if !plan.Schema.Equal(state.Schema) || !plan.TableConfig.Equal(state.TableConfig) {
+		if err := validateTableConfigs(ctx, r.client, plan.TableName.ValueString(), plan.TableType.ValueString(), schemaConfig, tableConfig); err != nil {
+			addAPIError(&resp.Diagnostics, "Invalid Pinot Table Config", "Table "+fullTableName+" does not validate against its schema", err)
+			return
+		}
+	}
+
+	// The schema goes first so the table can refer to columns it adds.
+	if !plan.Schema.Equal(state.Schema) {
+		err := r.client.UpdateSchema(ctx, schemaConfig)
+		if client.IsNotFound(err) {
+			err = r.client.CreateSchema(ctx, schemaConfig)
+		}
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Updating Pinot Schema", "Could not update schema "+plan.TableName.ValueString(), err)
+			return
+		}
+	}
+	if !plan.TableConfig.Equal(state.TableConfig) {
+		if err := client.RetryOnConflict(ctx, func() error { return r.client.UpdateTable(ctx, tableConfig) }); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Updating Pinot Table", "Could not update table "+fullTableName, err)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(fullTableName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TableWithSchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TableWithSchemaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TableWithSchemaResource{client: clientFor(r.client, data.ControllerURL)}
+
+	fullTableName := joinTableID(data.TableName.ValueString(), data.TableType.ValueString())
+	if err := checkTableDeleteAllowed(r.client, fullTableName); err != nil {
+		resp.Diagnostics.AddError("Pinot Table Is Protected", err.Error())
+		return
+	}
+
+	// The table goes first; Pinot refuses to delete a schema a table still uses.
+	if err := r.client.DeleteTable(ctx, fullTableName); err != nil && !client.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Table", "Could not delete table "+fullTableName, err)
+		return
+	}
+	err := client.RetryOnConflict(ctx, func() error { return r.client.DeleteSchema(ctx, data.TableName.ValueString()) })
+	if err != nil && !client.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Schema", "Could not delete schema "+data.TableName.ValueString(), err)
+	}
+}
+
+func (r *TableWithSchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	logical, typ := splitTableID(req.ID)
+	if logical == "" || typ == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format: tableName_TYPE (e.g., myTable_OFFLINE or myTable_REALTIME)",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_name"), logical)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_type"), typ)...)
+}
+
+// configs decodes the schema and the table config of data, adding the provider's managed-by tags to the latter.
+func (r *TableWithSchemaResource) configs(diags *diag.Diagnostics, data *TableWithSchemaResourceModel) (SchemaConfig, TableConfig) {
+	var schemaConfig SchemaConfig
+	diags.Append(data.Schema.Unmarshal(&schemaConfig)...)
+	var tableConfig TableConfig
+	diags.Append(data.TableConfig.Unmarshal(&tableConfig)...)
+	if diags.HasError() {
+		return nil, nil
+	}
+
+	userConfig, _ := deepCopyJSON(tableConfig).(map[string]interface{})
+	if err := applyConfigOverrides(tableConfig, managedByOverrides(r.client.ManagedByTags(), userConfig)); err != nil {
+		diags.AddAttributeError(path.Root("table_config"), "Conflicting Table Configuration", err.Error())
+	}
+	return schemaConfig, tableConfig
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestTableWithSchemaMismatches(t *testing.T) {
+	if got := tableWithSchemaMismatches(mustJSONMap(t,
+		`{"tableName":"events_OFFLINE","tableType":"OFFLINE","segmentsConfig":{"schemaName":"events"}}`), "events", "OFFLINE"); len(got) != 0 {
+		t.Errorf("matching config reported %q", got)
+	}
+	got := tableWithSchemaMismatches(mustJSONMap(t,
+		`{"tableName":"events","tableType":"REALTIME","segmentsConfig":{"schemaName":"other"}}`), "events", "OFFLINE")
+	if len(got) != 3 {
+		t.Errorf("mismatches = %q, want one each for tableName, tableType and schemaName", got)
+	}
+}
+
+func TestTableWithSchemaOrdering(t *testing.T) {
+	var calls []string
+	failValidate, failTable, schemaExists := false, false, false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if !schemaExists && r.Method == http.MethodGet && r.URL.Path == "/schemas/events" {
+			http.Error(w, `{"code":404,"error":"Schema not found"}`, http.StatusNotFound)
+			return
+		}
+		if failValidate && r.URL.Path == "/tableConfigs/validate" {
+			http.Error(w, `{"code":400,"error":"Column 'ts' not found in schema"}`, http.StatusBadRequest)
+			return
+		}
+		if failTable && r.Method == http.MethodPost && r.URL.Path == "/tables" {
+			http.Error(w, `{"code":400,"error":"Invalid table config"}`, http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &TableWithSchemaResource{client: c}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	model := func(schemaJSON string) TableWithSchemaResourceModel {
+		return TableWithSchemaResourceModel{
+			ID:            types.StringValue("events_OFFLINE"),
+			TableName:     types.StringValue("events"),
+			TableType:     types.StringValue("OFFLINE"),
+			Schema:        jsontypes.NewNormalizedValue(schemaJSON),
+			TableConfig:   jsontypes.NewNormalizedValue(`{"tableName":"events_OFFLINE","tableType":"OFFLINE"}`),
+			ControllerURL: types.StringNull(),
+		}
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(t.Context(), model(`{"schemaName":"events"}`)); diags.HasError() {
+		t.Fatalf("Plan.Set: %v", diags)
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(t.Context(), model(`{"schemaName":"events","metricFieldSpecs":[]}`)); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		calls = nil
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Create: %v", resp.Diagnostics)
+		}
+		if want := []string{"POST /tableConfigs/validate", "GET /schemas/events", "POST /schemas", "POST /tables"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("create rolls back the schema", func(t *testing.T) {
+		calls, failTable = nil, true
+		defer func() { failTable = false }()
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("Create succeeded although the table was rejected")
+		}
+		if want := []string{"POST /tableConfigs/validate", "GET /schemas/events", "POST /schemas", "POST /tables", "DELETE /schemas/events"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("create keeps an adopted schema", func(t *testing.T) {
+		calls, failTable, schemaExists = nil, true, true
+		defer func() { failTable, schemaExists = false, false }()
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("Create succeeded although the table was rejected")
+		}
+		if want := []string{"POST /tableConfigs/validate", "GET /schemas/events", "POST /schemas", "POST /tables"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("create validates first", func(t *testing.T) {
+		calls, failValidate = nil, true
+		defer func() { failValidate = false }()
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+		if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Column 'ts' not found in schema") {
+			t.Fatalf("diags = %v, want the controller's validation error", resp.Diagnostics)
+		}
+		if want := []string{"POST /tableConfigs/validate"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		calls = nil
+		resp := fwresource.UpdateResponse{State: state}
+		r.Update(t.Context(), fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Update: %v", resp.Diagnostics)
+		}
+		// Only the schema changed.
+		if len(calls) != 2 || calls[0] != "POST /tableConfigs/validate" || !strings.HasPrefix(calls[1], "PUT /schemas/events") {
+			t.Errorf("calls = %v, want the validation and the schema update", calls)
+		}
+	})
+
+	t.Run("update validates first", func(t *testing.T) {
+		calls, failValidate = nil, true
+		defer func() { failValidate = false }()
+		resp := fwresource.UpdateResponse{State: state}
+		r.Update(t.Context(), fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("Update succeeded although the configs do not validate")
+		}
+		if want := []string{"POST /tableConfigs/validate"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		calls = nil
+		resp := fwresource.DeleteResponse{State: state}
+		r.Delete(t.Context(), fwresource.DeleteRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Delete: %v", resp.Diagnostics)
+		}
+		if want := []string{"DELETE /tables/events_OFFLINE", "DELETE /schemas/events"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+}
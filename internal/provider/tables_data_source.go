@@ -0,0 +1,119 @@
+// internal/provider/tables_data_source.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ datasource.DataSource = &TablesDataSource{}
+
+type TablesDataSource struct {
+	client *client.PinotClient
+}
+
+type TablesDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	TableType types.String `tfsdk:"table_type"`
+	Tenant    types.String `tfsdk:"tenant"`
+	Tables    types.List   `tfsdk:"tables"` // []string
+}
+
+func NewTablesDataSource() datasource.DataSource {
+	return &TablesDataSource{}
+}
+
+func (d *TablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tables"
+}
+
+func (d *TablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the tables in the cluster, optionally only those of one type or on one tenant.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Data source identifier: `<table_type>/<tenant>`, with `*` for unset filters.",
+			},
+			"table_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list tables of this type: `OFFLINE` or `REALTIME`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("OFFLINE", "REALTIME"),
+				},
+			},
+			"tenant": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Only list tables whose broker or server tenant is this tenant (tables without a tenant are on `DefaultTenant`). " +
+					"The controller cannot filter by tenant, so this reads the config of every table, one request per table; leave it unset on large clusters unless needed.",
+			},
+			"tables": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Matching tables as `<logical>_<TYPE>`, sorted.",
+			},
+		},
+	}
+}
+
+func (d *TablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TablesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		tables []string
+		err    error
+	)
+	if data.Tenant.IsNull() {
+		tables, err = d.client.ListTableNames(ctx, data.TableType.ValueString())
+	} else {
+		tables, err = d.client.ListTablesOnTenant(ctx, data.TableType.ValueString(), data.Tenant.ValueString())
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Listing Pinot Tables", "Could not list tables", err)
+		return
+	}
+
+	id := []string{"*", "*"}
+	if !data.TableType.IsNull() {
+		id[0] = data.TableType.ValueString()
+	}
+	if !data.Tenant.IsNull() {
+		id[1] = data.Tenant.ValueString()
+	}
+	data.ID = types.StringValue(strings.Join(id, "/"))
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, tables)
+	resp.Diagnostics.Append(diags...)
+	data.Tables = list
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,231 @@
+// internal/provider/task_schedule_resource.go
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &TaskScheduleResource{}
+var _ resource.ResourceWithValidateConfig = &TaskScheduleResource{}
+
+// defaultTaskWaitTimeout bounds wait_for_completion when wait_timeout is not set.
+const defaultTaskWaitTimeout = 30 * time.Minute
+
+// taskPollInterval is how often subtask states are polled while waiting for a task; a variable so tests can shorten it.
+var taskPollInterval = 10 * time.Second
+
+type TaskScheduleResource struct {
+	client *client.PinotClient
+}
+
+type TaskScheduleResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	TableName         types.String `tfsdk:"table_name"`
+	TaskType          types.String `tfsdk:"task_type"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	WaitTimeout       types.String `tfsdk:"wait_timeout"`
+	TaskNames         types.List   `tfsdk:"task_names"` // []string
+	ControllerURL     types.String `tfsdk:"controller_url"`
+}
+
+func NewTaskScheduleResource() resource.Resource {
+	return &TaskScheduleResource{}
+}
+
+func (r *TaskScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_schedule"
+}
+
+func (r *TaskScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a minion task of a table right away (`POST /tasks/schedule`) instead of waiting for its cron schedule, e.g. a `MergeRollupTask` after a backfill. " +
+			"The task type must be configured in the table's `task.taskTypeConfigsMap`. " +
+			"The task is scheduled on create and whenever `table_name`, `task_type` or `triggers` change; destroying the resource does nothing.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Timestamp of the scheduling.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Table to run the task for as `<logical>_<TYPE>` (e.g., `user_events_OFFLINE`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"task_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Minion task type to run (e.g., `MergeRollupTask`, `RealtimeToOfflineSegmentsTask`).",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that schedule the task again when changed.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Wait until every subtask of the scheduled tasks has COMPLETED or failed, polling their states. " +
+					"The apply fails, naming the failed subtasks, when any of them failed or `wait_timeout` passed first; the task is then scheduled again on the next apply. Defaults to `false`.",
+			},
+			"wait_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("How long `wait_for_completion` waits, as a Go duration (e.g., `1h`). Defaults to `%s`.", defaultTaskWaitTimeout),
+			},
+			"task_names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Names of the scheduled tasks (e.g., `Task_MergeRollupTask_<id>_<millis>`); empty when the task generator found nothing to do.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TaskScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TaskScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TaskScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.TableName.IsUnknown() {
+		if _, typ := splitTableID(data.TableName.ValueString()); typ == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("table_name"),
+				"Invalid Table Name",
+				"table_name must be in the form <logical>_OFFLINE or <logical>_REALTIME.",
+			)
+		}
+	}
+	parseDurationAttribute(&resp.Diagnostics, "wait_timeout", data.WaitTimeout)
+}
+
+func (r *TaskScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TaskScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TaskScheduleResource{client: clientFor(r.client, data.ControllerURL)}
+
+	tableName, taskType := data.TableName.ValueString(), data.TaskType.ValueString()
+	names, err := r.client.ScheduleTask(ctx, taskType, tableName)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Scheduling Pinot Task", fmt.Sprintf("Could not schedule %s for table %s", taskType, tableName), err)
+		return
+	}
+	if len(names) == 0 {
+		resp.Diagnostics.AddWarning(
+			"No Pinot Task Scheduled",
+			fmt.Sprintf("The controller scheduled no %s for table %s, which usually means there was nothing to do. "+
+				"Check that the task type is configured in the table's task.taskTypeConfigsMap.", taskType, tableName),
+		)
+	}
+
+	if data.WaitForCompletion.ValueBool() && len(names) > 0 {
+		timeout := defaultTaskWaitTimeout
+		if d, ok := parseDurationAttribute(&resp.Diagnostics, "wait_timeout", data.WaitTimeout); ok {
+			timeout = d
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := r.waitForTasks(ctx, names, timeout); err != nil {
+			resp.Diagnostics.AddError(
+				"Pinot Task Did Not Complete",
+				fmt.Sprintf("%s for table %s was scheduled but did not complete: %v", taskType, tableName, err),
+			)
+			return
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.TaskNames, diags = types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForTasks waits up to timeout for every subtask of the named tasks to finish and returns the failures of
+// all of them joined.
+func (r *TaskScheduleResource) waitForTasks(ctx context.Context, names []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var errs []error
+	for _, name := range names {
+		if err := r.client.WaitForTask(ctx, name, taskPollInterval); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Read keeps state as-is; the controller drops finished tasks after a while.
+func (r *TaskScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only applies changes to the wait settings, which take effect the next time the task is scheduled.
+func (r *TaskScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TaskScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	plan.TaskNames = state.TaskNames
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TaskScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestTaskScheduleWaitForCompletion(t *testing.T) {
+	orig := taskPollInterval
+	taskPollInterval = time.Millisecond
+	defer func() { taskPollInterval = orig }()
+
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks/schedule":
+			task := "Task_MergeRollupTask_ok_1700000000000"
+			if r.URL.Query().Get("tableName") == "broken_OFFLINE" {
+				task = "Task_MergeRollupTask_bad_1700000000000"
+			}
+			_, _ = w.Write([]byte(`{"MergeRollupTask":"` + task + `"}`))
+		case "/tasks/subtask/Task_MergeRollupTask_ok_1700000000000/state":
+			polls++
+			if polls == 1 {
+				_, _ = w.Write([]byte(`{"Task_MergeRollupTask_ok_1700000000000_0":"IN_PROGRESS"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Task_MergeRollupTask_ok_1700000000000_0":"COMPLETED"}`))
+		case "/tasks/subtask/Task_MergeRollupTask_bad_1700000000000/state":
+			_, _ = w.Write([]byte(`{"Task_MergeRollupTask_bad_1700000000000_0":"COMPLETED","Task_MergeRollupTask_bad_1700000000000_1":"TASK_ERROR"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &TaskScheduleResource{client: c}
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+
+	create := func(table string) fwresource.CreateResponse {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		if diags := plan.Set(t.Context(), TaskScheduleResourceModel{
+			ID:                types.StringUnknown(),
+			TableName:         types.StringValue(table),
+			TaskType:          types.StringValue("MergeRollupTask"),
+			Triggers:          types.MapNull(types.StringType),
+			WaitForCompletion: types.BoolValue(true),
+			WaitTimeout:       types.StringValue("1m"),
+			TaskNames:         types.ListUnknown(types.StringType),
+			ControllerURL:     types.StringNull(),
+		}); diags.HasError() {
+			t.Fatalf("Plan.Set: %v", diags)
+		}
+		resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+		return resp
+	}
+
+	resp := create("events_OFFLINE")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", resp.Diagnostics)
+	}
+	if polls != 2 {
+		t.Errorf("subtasks polled %d times, want until completed", polls)
+	}
+	var got TaskScheduleResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &got)...)
+	if names := got.TaskNames.Elements(); len(names) != 1 || names[0].(types.String).ValueString() != "Task_MergeRollupTask_ok_1700000000000" {
+		t.Errorf("task_names = %v", got.TaskNames)
+	}
+
+	resp = create("broken_OFFLINE")
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Task_MergeRollupTask_bad_1700000000000_1 (TASK_ERROR)") {
+		t.Errorf("diags = %v, want an error naming the failed subtask", resp.Diagnostics)
+	}
+}
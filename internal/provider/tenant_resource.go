@@ -0,0 +1,315 @@
+// internal/provider/tenant_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &TenantResource{}
+var _ resource.ResourceWithImportState = &TenantResource{}
+var _ resource.ResourceWithValidateConfig = &TenantResource{}
+
+type TenantResource struct {
+	client *client.PinotClient
+}
+
+type TenantResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	TenantName         types.String `tfsdk:"tenant_name"`
+	TenantRole         types.String `tfsdk:"tenant_role"`
+	NumberOfInstances  types.Int64  `tfsdk:"number_of_instances"`
+	OfflineInstances   types.Int64  `tfsdk:"offline_instances"`
+	RealtimeInstances  types.Int64  `tfsdk:"realtime_instances"`
+	AllocatedOffline   types.Int64  `tfsdk:"allocated_offline_instances"`
+	AllocatedRealtime  types.Int64  `tfsdk:"allocated_realtime_instances"`
+	AllocatedInstances types.Int64  `tfsdk:"allocated_instances"`
+	ControllerURL      types.String `tfsdk:"controller_url"`
+}
+
+func NewTenantResource() resource.Resource {
+	return &TenantResource{}
+}
+
+func (r *TenantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant"
+}
+
+func (r *TenantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a server or broker tenant via the Controller `/tenants` API, which tags untagged instances for it. " +
+			"A warning is shown when the cluster had fewer untagged instances than requested.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Tenant identifier: `<tenant_name>|<tenant_role>`, since a server and a broker tenant may share a name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tenant_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Tenant name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenant_role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Tenant role: `SERVER` or `BROKER`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("SERVER", "BROKER"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"number_of_instances": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of brokers to tag. Required for `BROKER` tenants; not allowed for `SERVER` tenants.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"offline_instances": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of servers to tag for OFFLINE tables. Only for `SERVER` tenants, which need this or `realtime_instances`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"realtime_instances": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of servers to tag for REALTIME tables. Only for `SERVER` tenants, which need this or `offline_instances`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"allocated_offline_instances": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of servers actually tagged for OFFLINE tables; apply and refresh warn when it differs from `offline_instances`. Null for `BROKER` tenants.",
+			},
+			"allocated_realtime_instances": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of servers actually tagged for REALTIME tables; apply and refresh warn when it differs from `realtime_instances`. Null for `BROKER` tenants.",
+			},
+			"allocated_instances": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of instances actually tagged for the tenant.",
+			},
+		},
+	}
+}
+
+func (r *TenantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TenantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TenantResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.TenantRole.IsUnknown() {
+		return
+	}
+
+	switch data.TenantRole.ValueString() {
+	case "SERVER":
+		if !data.NumberOfInstances.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("number_of_instances"), "Invalid Attribute Combination",
+				"number_of_instances applies to BROKER tenants; set offline_instances and realtime_instances for SERVER tenants.")
+		}
+		if data.OfflineInstances.IsNull() && data.RealtimeInstances.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("offline_instances"), "Missing Instance Count",
+				"SERVER tenants need offline_instances, realtime_instances or both.")
+		}
+	case "BROKER":
+		for _, attr := range []struct {
+			name  string
+			value types.Int64
+		}{{"offline_instances", data.OfflineInstances}, {"realtime_instances", data.RealtimeInstances}} {
+			if !attr.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(attr.name), "Invalid Attribute Combination",
+					attr.name+" applies to SERVER tenants; set number_of_instances for BROKER tenants.")
+			}
+		}
+		if data.NumberOfInstances.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("number_of_instances"), "Missing Instance Count",
+				"BROKER tenants need number_of_instances.")
+		}
+	}
+}
+
+func (r *TenantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TenantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TenantResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.client.CreateTenant(ctx, tenantFromModel(&data)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Pinot Tenant", fmt.Sprintf("Could not create tenant %q", data.TenantName.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(tenantID(data.TenantName.ValueString(), data.TenantRole.ValueString()))
+	if err := r.readAllocation(ctx, &resp.Diagnostics, &data); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Tenant", fmt.Sprintf("Could not read tenant %q", data.TenantName.ValueString()), err)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TenantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TenantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TenantResource{client: clientFor(r.client, data.ControllerURL)}
+
+	// The controller does not keep the requested counts, so they stay as in state.
+	if err := r.readAllocation(ctx, &resp.Diagnostics, &data); err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Tenant", fmt.Sprintf("Could not read tenant %q", data.TenantName.ValueString()), err)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TenantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TenantResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TenantResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.client.UpdateTenant(ctx, tenantFromModel(&data)); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Updating Pinot Tenant", fmt.Sprintf("Could not update tenant %q", data.TenantName.ValueString()), err)
+		return
+	}
+
+	data.ID = types.StringValue(tenantID(data.TenantName.ValueString(), data.TenantRole.ValueString()))
+	if err := r.readAllocation(ctx, &resp.Diagnostics, &data); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot Tenant", fmt.Sprintf("Could not read tenant %q", data.TenantName.ValueString()), err)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TenantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TenantResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TenantResource{client: clientFor(r.client, data.ControllerURL)}
+
+	err := r.client.DeleteTenant(ctx, data.TenantName.ValueString(), data.TenantRole.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot Tenant", fmt.Sprintf("Could not delete tenant %q", data.TenantName.ValueString()), err)
+	}
+}
+
+func (r *TenantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, role, ok := strings.Cut(req.ID, "|")
+	role = strings.ToUpper(role)
+	if !ok || name == "" || (role != "SERVER" && role != "BROKER") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in format: name|ROLE (e.g., analytics|SERVER or analytics|BROKER)",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tenantID(name, role))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_role"), role)...)
+}
+
+func tenantID(name, role string) string {
+	return name + "|" + role
+}
+
+func tenantFromModel(data *TenantResourceModel) client.Tenant {
+	return client.Tenant{
+		TenantName:        data.TenantName.ValueString(),
+		TenantRole:        data.TenantRole.ValueString(),
+		NumberOfInstances: int(data.NumberOfInstances.ValueInt64()),
+		OfflineInstances:  int(data.OfflineInstances.ValueInt64()),
+		RealtimeInstances: int(data.RealtimeInstances.ValueInt64()),
+	}
+}
+
+// readAllocation sets the allocated_* attributes from the instances tagged for the tenant, and warns when they
+// differ from the requested counts. Imported tenants have no requested counts and are not checked.
+func (r *TenantResource) readAllocation(ctx context.Context, diags *diag.Diagnostics, data *TenantResourceModel) error {
+	name, role := data.TenantName.ValueString(), data.TenantRole.ValueString()
+	instances, err := r.client.GetTenant(ctx, name, role)
+	if err != nil {
+		return err
+	}
+	data.AllocatedInstances = types.Int64Value(int64(len(instances)))
+	data.AllocatedOffline = types.Int64Null()
+	data.AllocatedRealtime = types.Int64Null()
+
+	var shortfalls []string
+	if role == "BROKER" {
+		shortfalls = append(shortfalls, allocationShortfall("brokers", data.NumberOfInstances, data.AllocatedInstances)...)
+	} else {
+		offline, realtime, err := r.client.CountTenantServers(ctx, name)
+		if err != nil {
+			return err
+		}
+		data.AllocatedOffline = types.Int64Value(int64(offline))
+		data.AllocatedRealtime = types.Int64Value(int64(realtime))
+		shortfalls = append(shortfalls, allocationShortfall("OFFLINE servers", data.OfflineInstances, data.AllocatedOffline)...)
+		shortfalls = append(shortfalls, allocationShortfall("REALTIME servers", data.RealtimeInstances, data.AllocatedRealtime)...)
+	}
+	if len(shortfalls) > 0 {
+		diags.AddWarning(
+			"Pinot Tenant Allocation Differs From Request",
+			fmt.Sprintf("Tenant %s (%s) has %s. The cluster may not have enough untagged instances; add instances and apply again.",
+				name, role, strings.Join(shortfalls, " and ")),
+		)
+	}
+	return nil
+}
+
+// allocationShortfall describes the difference between a requested and an allocated instance count, if any.
+func allocationShortfall(kind string, requested, allocated types.Int64) []string {
+	if requested.IsNull() || requested.IsUnknown() || requested.ValueInt64() == allocated.ValueInt64() {
+		return nil
+	}
+	return []string{fmt.Sprintf("%d %s instead of %d", allocated.ValueInt64(), kind, requested.ValueInt64())}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+func TestTenantResourceAllocation(t *testing.T) {
+	missing := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			_, _ = w.Write([]byte(`{"status":"OK"}`))
+			return
+		}
+		if missing {
+			http.Error(w, `{"code":404,"error":"Tenant analytics not found"}`, http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("tableType") {
+		case "OFFLINE":
+			_, _ = w.Write([]byte(`{"ServerInstances":["Server_a_8098"]}`))
+		case "REALTIME":
+			_, _ = w.Write([]byte(`{"ServerInstances":["Server_b_8098"]}`))
+		default:
+			_, _ = w.Write([]byte(`{"ServerInstances":["Server_a_8098","Server_b_8098"]}`))
+		}
+	}))
+	defer srv.Close()
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &TenantResource{client: c}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(t.Context(), TenantResourceModel{
+		ID:                 types.StringUnknown(),
+		TenantName:         types.StringValue("analytics"),
+		TenantRole:         types.StringValue("SERVER"),
+		NumberOfInstances:  types.Int64Null(),
+		OfflineInstances:   types.Int64Value(2),
+		RealtimeInstances:  types.Int64Value(1),
+		AllocatedOffline:   types.Int64Unknown(),
+		AllocatedRealtime:  types.Int64Unknown(),
+		AllocatedInstances: types.Int64Unknown(),
+		ControllerURL:      types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("Plan.Set: %v", diags)
+	}
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("warnings = %v, want one for the missing OFFLINE server", resp.Diagnostics.Warnings())
+	}
+	var got TenantResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &got)...)
+	if got.ID.ValueString() != "analytics|SERVER" || got.AllocatedOffline.ValueInt64() != 1 ||
+		got.AllocatedRealtime.ValueInt64() != 1 || got.AllocatedInstances.ValueInt64() != 2 {
+		t.Errorf("state = %+v", got)
+	}
+
+	// Read keeps reporting the shortfall until the cluster has enough instances.
+	readResp := fwresource.ReadResponse{State: resp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: resp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	if w := readResp.Diagnostics.Warnings(); len(w) != 1 || !strings.Contains(w[0].Detail(), "1 OFFLINE servers instead of 2") {
+		t.Errorf("warnings = %v, want one for the missing OFFLINE server", w)
+	}
+
+	missing = true
+	readResp = fwresource.ReadResponse{State: resp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: resp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("tenant missing on the controller was not removed from state")
+	}
+}
+
+func TestAllocationShortfall(t *testing.T) {
+	for _, tc := range []struct {
+		requested types.Int64
+		want      []string
+	}{
+		{requested: types.Int64Null()},
+		{requested: types.Int64Unknown()},
+		{requested: types.Int64Value(2)},
+		{requested: types.Int64Value(3), want: []string{"2 brokers instead of 3"}},
+		{requested: types.Int64Value(1), want: []string{"2 brokers instead of 1"}},
+	} {
+		if got := allocationShortfall("brokers", tc.requested, types.Int64Value(2)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("allocationShortfall(%v) = %q, want %q", tc.requested, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// internal/provider/time_boundary_resource.go
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/client"
+)
+
+var _ resource.Resource = &TimeBoundaryResource{}
+var _ resource.ResourceWithImportState = &TimeBoundaryResource{}
+
+type TimeBoundaryResource struct {
+	client *client.PinotClient
+}
+
+type TimeBoundaryResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	TableName     types.String `tfsdk:"table_name"`
+	Strategy      types.String `tfsdk:"strategy"`
+	ControllerURL types.String `tfsdk:"controller_url"`
+}
+
+func NewTimeBoundaryResource() resource.Resource {
+	return &TimeBoundaryResource{}
+}
+
+func (r *TimeBoundaryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_time_boundary"
+}
+
+func (r *TimeBoundaryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sets the query time boundary of a hybrid table via `POST /tables/{name}/timeBoundary`. " +
+			"Destroying the resource deletes the boundary so the broker falls back to its default detection.",
+		Attributes: map[string]schema.Attribute{
+			"controller_url": controllerURLAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time boundary identifier (same as `table_name`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Logical name of the hybrid table, without the `_OFFLINE`/`_REALTIME` suffix.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"strategy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Time boundary strategy passed to the controller. Omit to let the controller compute the boundary from the offline segments' metadata.",
+			},
+		},
+	}
+}
+
+func (r *TimeBoundaryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.PinotClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.PinotClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TimeBoundaryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TimeBoundaryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TimeBoundaryResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if _, typ := splitTableID(data.TableName.ValueString()); typ != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_name"),
+			"Invalid Table Name",
+			"table_name must be the logical name, without the _OFFLINE/_REALTIME suffix.",
+		)
+		return
+	}
+
+	if err := r.client.SetTimeBoundary(ctx, data.TableName.ValueString(), data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Setting Pinot Time Boundary",
+			"Could not set time boundary, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.TableName.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read only checks that the offline half of the hybrid table still exists; the controller does not
+// expose the configured strategy.
+func (r *TimeBoundaryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TimeBoundaryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TimeBoundaryResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if _, err := r.client.GetTable(ctx, joinTableID(data.TableName.ValueString(), "OFFLINE")); err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Pinot Table",
+			"Could not read table "+data.TableName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.TableName.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TimeBoundaryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TimeBoundaryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TimeBoundaryResource{client: clientFor(r.client, data.ControllerURL)}
+
+	if err := r.client.SetTimeBoundary(ctx, data.TableName.ValueString(), data.Strategy.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Setting Pinot Time Boundary",
+			"Could not set time boundary, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TimeBoundaryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TimeBoundaryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r = &TimeBoundaryResource{client: clientFor(r.client, data.ControllerURL)}
+
+	err := r.client.DeleteTimeBoundary(ctx, data.TableName.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Pinot Time Boundary",
+			"Could not delete time boundary, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *TimeBoundaryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("table_name"), req, resp)
+}
@@ -19,19 +19,33 @@ import (
 
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithUpgradeState = &UserResource{}
 
 type UserResource struct {
 	client *client.PinotClient
 }
 
 type UserResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Username    types.String `tfsdk:"username"`
-	Password    types.String `tfsdk:"password"`
-	Component   types.String `tfsdk:"component"`
-	Role        types.String `tfsdk:"role"`
-	Tables      types.List   `tfsdk:"tables"`      // []string
-	Permissions types.List   `tfsdk:"permissions"` // []string
+	ID            types.String `tfsdk:"id"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	Component     types.String `tfsdk:"component"`
+	Role          types.String `tfsdk:"role"`
+	Tables        types.Set    `tfsdk:"tables"`      // []string
+	Permissions   types.Set    `tfsdk:"permissions"` // []string
+	ControllerURL types.String `tfsdk:"controller_url"`
+}
+
+// userResourceModelV0 is the state layout before tables and permissions became sets.
+type userResourceModelV0 struct {
+	ID            types.String `tfsdk:"id"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	Component     types.String `tfsdk:"component"`
+	Role          types.String `tfsdk:"role"`
+	Tables        types.List   `tfsdk:"tables"`
+	Permissions   types.List   `tfsdk:"permissions"`
+	ControllerURL types.String `tfsdk:"controller_url"`
 }
 
 type PinotUser struct {
@@ -51,8 +65,10 @@ func (r *UserResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = rschema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Pinot User via the Controller `/users` API.",
 		Attributes: map[string]rschema.Attribute{
+			"controller_url": controllerURLAttribute(),
 			"id": rschema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Resource identifier (same as `username`).",
@@ -84,12 +100,12 @@ func (r *UserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:            true,
 				MarkdownDescription: "Role: typically `ADMIN` or `USER`.",
 			},
-			"tables": rschema.ListAttribute{
+			"tables": rschema.SetAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
 				MarkdownDescription: "Tables this user applies to (e.g. `ALL`, `DUAL`, ...).",
 			},
-			"permissions": rschema.ListAttribute{
+			"permissions": rschema.SetAttribute{
 				ElementType:         types.StringType,
 				Required:            true,
 				MarkdownDescription: "Permissions (e.g. `READ`, `CREATE`, `UPDATE`, `DELETE`).",
@@ -119,14 +135,15 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &UserResource{client: clientFor(r.client, data.ControllerURL)}
 
 	if data.Password.IsNull() || data.Password.ValueString() == "" {
 		resp.Diagnostics.AddError("Missing password", "Creating a Pinot user requires a non-empty `password`.")
 		return
 	}
 
-	tables := toStringSlice(ctx, &resp.Diagnostics, data.Tables)
-	perms := toStringSlice(ctx, &resp.Diagnostics, data.Permissions)
+	tables := setToStringSlice(ctx, &resp.Diagnostics, data.Tables)
+	perms := setToStringSlice(ctx, &resp.Diagnostics, data.Permissions)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -141,33 +158,15 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	if err := r.client.CreateUser(ctx, payload); err != nil {
-		resp.Diagnostics.AddError("Error Creating Pinot User", err.Error())
+		addAPIError(&resp.Diagnostics, "Error Creating Pinot User", fmt.Sprintf("Could not create user %q", payload.Username), err)
 		return
 	}
 
 	data.ID = types.StringValue(payload.Username)
 
+	// Keep the planned values when the user cannot be read back yet.
 	if u, err := r.fetchUser(ctx, payload.Username, payload.Component); err == nil {
-		data.Username = types.StringValue(u.Username)
-		data.Component = types.StringValue(u.Component)
-		data.Role = types.StringValue(u.Role)
-
-		tablesV, d1 := types.ListValueFrom(ctx, types.StringType, u.Tables)
-		permsV, d2 := types.ListValueFrom(ctx, types.StringType, u.Permissions)
-		resp.Diagnostics.Append(d1...)
-		resp.Diagnostics.Append(d2...)
-		data.Tables = tablesV
-		data.Permissions = permsV
-	} else {
-		data.Username = types.StringValue(payload.Username)
-		data.Component = types.StringValue(payload.Component)
-		data.Role = types.StringValue(payload.Role)
-		tv, d1 := types.ListValueFrom(ctx, types.StringType, tables)
-		pv, d2 := types.ListValueFrom(ctx, types.StringType, perms)
-		resp.Diagnostics.Append(d1...)
-		resp.Diagnostics.Append(d2...)
-		data.Tables = tv
-		data.Permissions = pv
+		resp.Diagnostics.Append(applyRemoteUser(ctx, &data, u)...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -179,26 +178,19 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &UserResource{client: clientFor(r.client, data.ControllerURL)}
 
 	u, err := r.fetchUser(ctx, data.Username.ValueString(), data.Component.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error Reading Pinot User",
-			fmt.Sprintf("Could not read user %q: %v", data.Username.ValueString(), err))
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Pinot User", fmt.Sprintf("Could not read user %q", data.Username.ValueString()), err)
 		return
 	}
 
-	data.ID = types.StringValue(u.Username)
-	data.Username = types.StringValue(u.Username)
-	data.Component = types.StringValue(u.Component)
-	data.Role = types.StringValue(u.Role)
-
-	tablesV, d1 := types.ListValueFrom(ctx, types.StringType, u.Tables)
-	permsV, d2 := types.ListValueFrom(ctx, types.StringType, u.Permissions)
-	resp.Diagnostics.Append(d1...)
-	resp.Diagnostics.Append(d2...)
-	data.Tables = tablesV
-	data.Permissions = permsV
-
+	resp.Diagnostics.Append(applyRemoteUser(ctx, &data, u)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -208,6 +200,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &UserResource{client: clientFor(r.client, plan.ControllerURL)}
 
 	var state UserResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -215,8 +208,8 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	tables := toStringSlice(ctx, &resp.Diagnostics, plan.Tables)
-	perms := toStringSlice(ctx, &resp.Diagnostics, plan.Permissions)
+	tables := setToStringSlice(ctx, &resp.Diagnostics, plan.Tables)
+	perms := setToStringSlice(ctx, &resp.Diagnostics, plan.Permissions)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -258,10 +251,14 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		payload["password"] = current.Password
 	}
 
-	if err := r.client.UpdateUser(ctx, payload); err != nil {
-		resp.Diagnostics.AddError("Error Updating Pinot User", err.Error())
+	if err := client.RetryOnConflict(ctx, func() error { return r.client.UpdateUser(ctx, payload) }); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Updating Pinot User", fmt.Sprintf("Could not update user %q", plan.Username.ValueString()), err)
 		return
 	}
+	// UseStateForUnknown leaves the password unknown when the prior state has none (e.g. after import).
+	if plan.Password.IsUnknown() {
+		plan.Password = state.Password
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -272,11 +269,13 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	r = &UserResource{client: clientFor(r.client, data.ControllerURL)}
+
 	if err := r.client.DeleteUserWithComponent(ctx,
 		data.Username.ValueString(),
 		data.Component.ValueString(),
-	); err != nil {
-		resp.Diagnostics.AddError("Error Deleting Pinot User", err.Error())
+	); err != nil && !client.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, "Error Deleting Pinot User", fmt.Sprintf("Could not delete user %q", data.Username.ValueString()), err)
 		return
 	}
 }
@@ -292,8 +291,75 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 	resource.ImportStatePassthroughID(ctx, path.Root("username"), req, resp)
 }
 
+// UpgradeState migrates version 0 state, where tables and permissions were lists.
+func (r *UserResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &rschema.Schema{
+				Attributes: map[string]rschema.Attribute{
+					"controller_url": rschema.StringAttribute{Optional: true},
+					"id":             rschema.StringAttribute{Computed: true},
+					"username":       rschema.StringAttribute{Required: true},
+					"password":       rschema.StringAttribute{Optional: true, Computed: true, Sensitive: true},
+					"component":      rschema.StringAttribute{Required: true},
+					"role":           rschema.StringAttribute{Required: true},
+					"tables":         rschema.ListAttribute{ElementType: types.StringType, Optional: true},
+					"permissions":    rschema.ListAttribute{ElementType: types.StringType, Required: true},
+				},
+			},
+			StateUpgrader: upgradeUserStateV0,
+		},
+	}
+}
+
+func upgradeUserStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior userResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tables, d := listToSet(ctx, prior.Tables)
+	resp.Diagnostics.Append(d...)
+	perms, d := listToSet(ctx, prior.Permissions)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, UserResourceModel{
+		ID:            prior.ID,
+		Username:      prior.Username,
+		Password:      prior.Password,
+		Component:     prior.Component,
+		Role:          prior.Role,
+		Tables:        tables,
+		Permissions:   perms,
+		ControllerURL: prior.ControllerURL,
+	})...)
+}
+
 /* ---------- helpers ---------- */
 
+// applyRemoteUser refreshes data from the user the controller returned. The
+// password is left as it is in data: the API never returns it, and u.Password
+// holds the BCrypt hash, which must not end up in state.
+func applyRemoteUser(ctx context.Context, data *UserResourceModel, u *PinotUser) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.ID = types.StringValue(u.Username)
+	data.Username = types.StringValue(u.Username)
+	data.Component = types.StringValue(u.Component)
+	data.Role = types.StringValue(u.Role)
+
+	tablesV, d := types.SetValueFrom(ctx, types.StringType, u.Tables)
+	diags.Append(d...)
+	permsV, d := types.SetValueFrom(ctx, types.StringType, u.Permissions)
+	diags.Append(d...)
+	data.Tables = tablesV
+	data.Permissions = permsV
+	return diags
+}
+
 func toStringSlice(ctx context.Context, diags *diag.Diagnostics, l types.List) []string {
 	if l.IsNull() || l.IsUnknown() {
 		return nil
@@ -303,6 +369,36 @@ func toStringSlice(ctx context.Context, diags *diag.Diagnostics, l types.List) [
 	return out
 }
 
+func setToStringSlice(ctx context.Context, diags *diag.Diagnostics, l types.Set) []string {
+	if l.IsNull() || l.IsUnknown() {
+		return nil
+	}
+	var out []string
+	diags.Append(l.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
+// listToSet converts a list of strings to a set, dropping duplicates.
+func listToSet(ctx context.Context, l types.List) (types.Set, diag.Diagnostics) {
+	if l.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+	var diags diag.Diagnostics
+	var elems []string
+	diags.Append(l.ElementsAs(ctx, &elems, false)...)
+	seen := make(map[string]bool, len(elems))
+	unique := make([]string, 0, len(elems))
+	for _, e := range elems {
+		if !seen[e] {
+			seen[e] = true
+			unique = append(unique, e)
+		}
+	}
+	v, d := types.SetValueFrom(ctx, types.StringType, unique)
+	diags.Append(d...)
+	return v, diags
+}
+
 func (r *UserResource) fetchUser(ctx context.Context, username, component string) (*PinotUser, error) {
 	top, err := r.client.GetUser(ctx, username, component)
 	if err != nil {
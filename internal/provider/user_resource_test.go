@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-pinot/internal/client"
 )
 
 func TestAccPinotUser_basic(t *testing.T) {
@@ -35,7 +40,7 @@ func TestAccPinotUser_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("pinot_user.test", "permissions.#", "2"),
 					// tables intentionally set to ["ALL"] to avoid cluster-specific tables
 					resource.TestCheckResourceAttr("pinot_user.test", "tables.#", "1"),
-					resource.TestCheckResourceAttr("pinot_user.test", "tables.0", "ALL"),
+					resource.TestCheckTypeSetElemAttr("pinot_user.test", "tables.*", "ALL"),
 				),
 			},
 			{
@@ -441,3 +446,144 @@ func testAccCheckBrokerQueryDenied(brokerURL, username, password, table string)
 		return nil
 	}
 }
+
+/* ---------- ordering ---------- */
+
+func TestUserReadIgnoresReorderedSets(t *testing.T) {
+	// The controller returns tables/permissions in a different order than declared.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"alice_BROKER":{"username":"alice","component":"BROKER","role":"USER",` +
+			`"tables":["orders","events"],"permissions":["UPDATE","READ"]}}`))
+	}))
+	defer srv.Close()
+
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewPinotClient: %v", err)
+	}
+	r := &UserResource{client: c}
+
+	u, err := r.fetchUser(t.Context(), "alice", "BROKER")
+	if err != nil {
+		t.Fatalf("fetchUser: %v", err)
+	}
+
+	declaredPerms, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"READ", "UPDATE"})
+	declaredTables, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"events"})
+	data := UserResourceModel{Tables: declaredTables, Permissions: declaredPerms}
+	if diags := applyRemoteUser(t.Context(), &data, u); diags.HasError() {
+		t.Fatalf("applyRemoteUser: %v", diags)
+	}
+	if !data.Permissions.Equal(declaredPerms) {
+		t.Errorf("reordered permissions produced a diff: %s", data.Permissions)
+	}
+
+	// A real change still shows up.
+	want, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"events", "orders"})
+	if !data.Tables.Equal(want) {
+		t.Errorf("tables = %s, want %s", data.Tables, want)
+	}
+}
+
+func TestUserUpgradeStateV0(t *testing.T) {
+	r := &UserResource{}
+	upgrader := r.UpgradeState(t.Context())[0]
+
+	tables, _ := types.ListValueFrom(t.Context(), types.StringType, []string{"orders", "events", "orders"})
+	perms, _ := types.ListValueFrom(t.Context(), types.StringType, []string{"UPDATE", "READ"})
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema}
+	if diags := prior.Set(t.Context(), &userResourceModelV0{
+		ID:            types.StringValue("alice"),
+		Username:      types.StringValue("alice"),
+		Password:      types.StringValue("s3cret"),
+		Component:     types.StringValue("BROKER"),
+		Role:          types.StringValue("USER"),
+		Tables:        tables,
+		Permissions:   perms,
+		ControllerURL: types.StringNull(),
+	}); diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+	resp := fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(t.Context(), fwresource.UpgradeStateRequest{State: &prior}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade: %v", resp.Diagnostics)
+	}
+
+	var got UserResourceModel
+	if diags := resp.State.Get(t.Context(), &got); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	wantTables, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"events", "orders"})
+	wantPerms, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"READ", "UPDATE"})
+	if !got.Tables.Equal(wantTables) {
+		t.Errorf("tables = %s, want %s", got.Tables, wantTables)
+	}
+	if !got.Permissions.Equal(wantPerms) {
+		t.Errorf("permissions = %s, want %s", got.Permissions, wantPerms)
+	}
+	if got.Password.ValueString() != "s3cret" || got.Username.ValueString() != "alice" {
+		t.Errorf("scalar attributes not carried over: %+v", got)
+	}
+}
+
+func TestUserReadPreservesPassword(t *testing.T) {
+	// GET returns the BCrypt hash; it must never replace the configured password.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"alice_BROKER":{"username":"alice","component":"BROKER","role":"ADMIN",` +
+			`"password":"$2a$10$hashhashhash","tables":["orders"],"permissions":["READ"]}}`))
+	}))
+	defer srv.Close()
+
+	c, err := client.NewPinotClient(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("NewPinotClient: %v", err)
+	}
+	r := &UserResource{client: c}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(t.Context(), fwresource.SchemaRequest{}, &schemaResp)
+
+	for name, password := range map[string]types.String{
+		"configured": types.StringValue("s3cret"),
+		"imported":   types.StringNull(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			tables, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"orders"})
+			perms, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"READ"})
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			diags := state.Set(t.Context(), &UserResourceModel{
+				ID:            types.StringValue("alice"),
+				Username:      types.StringValue("alice"),
+				Password:      password,
+				Component:     types.StringValue("BROKER"),
+				Role:          types.StringValue("USER"),
+				Tables:        tables,
+				Permissions:   perms,
+				ControllerURL: types.StringNull(),
+			})
+			if diags.HasError() {
+				t.Fatalf("State.Set: %v", diags)
+			}
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(t.Context(), fwresource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Read: %v", resp.Diagnostics)
+			}
+			var got UserResourceModel
+			if diags := resp.State.Get(t.Context(), &got); diags.HasError() {
+				t.Fatalf("State.Get: %v", diags)
+			}
+			if !got.Password.Equal(password) {
+				t.Errorf("password = %s, want %s", got.Password, password)
+			}
+			if got.Role.ValueString() != "ADMIN" {
+				t.Errorf("role = %s, want the refreshed ADMIN", got.Role)
+			}
+		})
+	}
+}